@@ -11,3 +11,21 @@ func (s *BaseSection) Name() string        { return s.name }
 func (s *BaseSection) Headers() []string   { return s.headers }
 func (s *BaseSection) Rows() [][]string    { return s.rows }
 func (s *BaseSection) AddRow(row []string) { s.rows = append(s.rows, row) }
+
+// columnValue returns the value of the named column.
+// Rows of sections read without headers fall back to the column position of the standard Visum layout
+func columnValue(values []string, headers []string, column string, position int) string {
+	if len(headers) > 0 {
+		position = -1
+		for i, header := range headers {
+			if header == column {
+				position = i
+				break
+			}
+		}
+	}
+	if position < 0 || position >= len(values) {
+		return ""
+	}
+	return values[position]
+}
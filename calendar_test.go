@@ -0,0 +1,67 @@
+package ptvvisum
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestGetDayIndexOutsideCalendarPeriod(t *testing.T) {
+	from := time.Date(2026, 1, 5, 0, 0, 0, 0, time.UTC)
+	until := time.Date(2026, 1, 11, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		calendar string
+		day      time.Time
+		index    int
+		found    bool
+	}{
+		{"NOCALENDAR", from, 0, true},
+		{"NOCALENDAR", until.Add(12 * time.Hour), 0, true},
+		{"NOCALENDAR", from.AddDate(0, 0, -1), 0, false},
+		{"NOCALENDAR", until.AddDate(0, 0, 1), 0, false},
+		{"WEEKLY", from.AddDate(0, 0, 2), 2, true},
+		{"WEEKLY", until.AddDate(0, 0, 1), 0, false},
+		{"ANNUAL", from.AddDate(0, 0, 3), 3, true},
+		{"ANNUAL", from.AddDate(0, 0, -1), 0, false},
+	}
+	for _, test := range tests {
+		section := CalendarPeriodSection{Periods: []CalendarPeriod{{Type: test.calendar, ValidFrom: from, ValidUntil: until}}}
+		index, found := section.GetDayIndex(test.day)
+		if index != test.index || found != test.found {
+			t.Errorf("%s GetDayIndex(%s) = %d, %v, want %d, %v", test.calendar, test.day.Format(time.DateOnly), index, found, test.index, test.found)
+		}
+	}
+}
+
+func TestReadValidDays(t *testing.T) {
+	input := "$VISION\r\n" +
+		"$CALENDARPERIOD:TYPE;VALIDFROM;VALIDUNTIL\r\n" +
+		"WEEKLY;05.01.2026;11.01.2026\r\n" +
+		"$VALIDDAYS:NO;CODE;NAME;DAYVECTOR\r\n" +
+		"1;W;Weekdays;0011111\r\n" +
+		"2;Y;Year;" + strings.Repeat("1", 365) + "\r\n"
+	data, err := ReadPTVFromFile(strings.NewReader(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	weekdays, _ := data.ValidDays.GetValidDayByID(1)
+	if weekdays.DayVector != 11111 || weekdays.DayVectorText != "0011111" {
+		t.Errorf("got day vector %d and %q", weekdays.DayVector, weekdays.DayVectorText)
+	}
+	year, _ := data.ValidDays.GetValidDayByID(2)
+	if year.DayVector != 0 || len(year.DayVectorText) != 365 {
+		t.Errorf("got day vector %d and %d days", year.DayVector, len(year.DayVectorText))
+	}
+
+	monday := time.Date(2026, 1, 5, 8, 0, 0, 0, time.UTC)
+	if data.ValidDays.IsValidOn(1, monday, data) {
+		t.Error("weekdays valid on Monday, the first day of the vector is 0")
+	}
+	if !data.ValidDays.IsValidOn(1, monday.AddDate(0, 0, 2), data) {
+		t.Error("weekdays not valid on Wednesday")
+	}
+	if data.ValidDays.IsValidOn(1, monday.AddDate(0, 0, 9), data) {
+		t.Error("weekdays valid after the calendar period")
+	}
+}
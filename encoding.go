@@ -0,0 +1,249 @@
+package ptvvisum
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// Encoding is a character encoding of a network file
+type Encoding string
+
+const (
+	// EncodingAuto detects the encoding by the byte order mark, files without it are read as UTF-8
+	EncodingAuto        Encoding = ""
+	EncodingUTF8        Encoding = "UTF-8"
+	EncodingUTF16LE     Encoding = "UTF-16LE"
+	EncodingUTF16BE     Encoding = "UTF-16BE"
+	EncodingWindows1251 Encoding = "WINDOWS-1251"
+	EncodingLatin1      Encoding = "ISO-8859-1"
+)
+
+var (
+	bomUTF8    = []byte{0xEF, 0xBB, 0xBF}
+	bomUTF16LE = []byte{0xFF, 0xFE}
+	bomUTF16BE = []byte{0xFE, 0xFF}
+)
+
+// hasBOM reports whether files in the encoding start with a byte order mark
+func (e Encoding) hasBOM() bool {
+	switch e {
+	case EncodingAuto, EncodingUTF8, EncodingUTF16LE, EncodingUTF16BE:
+		return true
+	}
+	return false
+}
+
+// newDecoder wraps reader so that it yields UTF-8 text without byte order mark.
+// A byte order mark takes precedence over EncodingAuto and EncodingUTF8
+func newDecoder(reader io.Reader, encoding Encoding) (io.Reader, error) {
+	r := bufio.NewReader(reader)
+	bom, _ := r.Peek(3) // Shorter files have no BOM
+	detected := encoding
+	switch {
+	case bytes.HasPrefix(bom, bomUTF8) && (encoding == EncodingAuto || encoding == EncodingUTF8):
+		r.Discard(len(bomUTF8))
+		detected = EncodingUTF8
+	case bytes.HasPrefix(bom, bomUTF16LE) && (encoding == EncodingAuto || encoding == EncodingUTF16LE):
+		r.Discard(len(bomUTF16LE))
+		detected = EncodingUTF16LE
+	case bytes.HasPrefix(bom, bomUTF16BE) && (encoding == EncodingAuto || encoding == EncodingUTF16BE):
+		r.Discard(len(bomUTF16BE))
+		detected = EncodingUTF16BE
+	}
+
+	switch detected {
+	case EncodingAuto, EncodingUTF8:
+		return r, nil
+	case EncodingUTF16LE:
+		return &decodingReader{r: r, decode: decodeUTF16(binary.LittleEndian)}, nil
+	case EncodingUTF16BE:
+		return &decodingReader{r: r, decode: decodeUTF16(binary.BigEndian)}, nil
+	case EncodingWindows1251:
+		return &decodingReader{r: r, decode: decodeSingleByte(&windows1251)}, nil
+	case EncodingLatin1:
+		return &decodingReader{r: r, decode: decodeSingleByte(&latin1)}, nil
+	}
+	return nil, fmt.Errorf("unsupported encoding: %s", encoding)
+}
+
+// newEncoder wraps writer so that UTF-8 text written to it is stored in the given encoding
+func newEncoder(writer io.Writer, encoding Encoding) (io.Writer, error) {
+	switch encoding {
+	case EncodingAuto, EncodingUTF8:
+		return writer, nil
+	case EncodingUTF16LE:
+		return &encodingWriter{w: writer, encode: encodeUTF16(binary.LittleEndian)}, nil
+	case EncodingUTF16BE:
+		return &encodingWriter{w: writer, encode: encodeUTF16(binary.BigEndian)}, nil
+	case EncodingWindows1251:
+		return &encodingWriter{w: writer, encode: encodeSingleByte(encoding, &windows1251)}, nil
+	case EncodingLatin1:
+		return &encodingWriter{w: writer, encode: encodeSingleByte(encoding, &latin1)}, nil
+	}
+	return nil, fmt.Errorf("unsupported encoding: %s", encoding)
+}
+
+// decodingReader converts text to UTF-8 while it is read
+type decodingReader struct {
+	r io.Reader
+	// decode appends the decoded src to dst and returns the number of consumed bytes.
+	// Incomplete characters at the end of src are left for the next call unless eof is set
+	decode func(dst, src []byte, eof bool) ([]byte, int)
+	buf    [4096]byte
+	src    []byte // Bytes read but not decoded yet
+	dst    []byte // Decoded text not returned yet
+	err    error
+}
+
+func (d *decodingReader) Read(p []byte) (int, error) {
+	for len(d.dst) == 0 {
+		if d.err != nil {
+			return 0, d.err
+		}
+		n, err := d.r.Read(d.buf[:])
+		d.src = append(d.src, d.buf[:n]...)
+		d.err = err
+		var consumed int
+		d.dst, consumed = d.decode(d.dst[:0], d.src, err != nil)
+		d.src = append(d.src[:0], d.src[consumed:]...)
+	}
+	n := copy(p, d.dst)
+	d.dst = d.dst[n:]
+	return n, nil
+}
+
+// encodingWriter converts UTF-8 text to another encoding while it is written
+type encodingWriter struct {
+	w       io.Writer
+	encode  func(dst []byte, r rune) ([]byte, error)
+	pending []byte // Incomplete UTF-8 sequence left by the previous Write
+	buf     []byte
+}
+
+func (e *encodingWriter) Write(p []byte) (int, error) {
+	src := p
+	if len(e.pending) > 0 {
+		src = append(e.pending, p...)
+	}
+	e.buf = e.buf[:0]
+	i := 0
+	for i < len(src) && utf8.FullRune(src[i:]) {
+		r, size := utf8.DecodeRune(src[i:])
+		var err error
+		e.buf, err = e.encode(e.buf, r)
+		if err != nil {
+			return 0, err
+		}
+		i += size
+	}
+	e.pending = append(e.pending[:0], src[i:]...)
+	if _, err := e.w.Write(e.buf); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+func decodeUTF16(order binary.ByteOrder) func(dst, src []byte, eof bool) ([]byte, int) {
+	return func(dst, src []byte, eof bool) ([]byte, int) {
+		i := 0
+		for ; i+1 < len(src); i += 2 {
+			unit := rune(order.Uint16(src[i:]))
+			if !utf16.IsSurrogate(unit) {
+				dst = utf8.AppendRune(dst, unit)
+				continue
+			}
+			if i+3 >= len(src) && !eof {
+				// Wait for the second half of the surrogate pair
+				break
+			}
+			if i+3 < len(src) {
+				if r := utf16.DecodeRune(unit, rune(order.Uint16(src[i+2:]))); r != utf8.RuneError {
+					dst = utf8.AppendRune(dst, r)
+					i += 2
+					continue
+				}
+			}
+			dst = utf8.AppendRune(dst, utf8.RuneError)
+		}
+		if eof && i < len(src) {
+			dst = utf8.AppendRune(dst, utf8.RuneError)
+			i = len(src)
+		}
+		return dst, i
+	}
+}
+
+func encodeUTF16(order binary.AppendByteOrder) func(dst []byte, r rune) ([]byte, error) {
+	return func(dst []byte, r rune) ([]byte, error) {
+		if r1, r2 := utf16.EncodeRune(r); r1 != utf8.RuneError {
+			dst = order.AppendUint16(dst, uint16(r1))
+			return order.AppendUint16(dst, uint16(r2)), nil
+		}
+		return order.AppendUint16(dst, uint16(r)), nil
+	}
+}
+
+func decodeSingleByte(table *[128]rune) func(dst, src []byte, eof bool) ([]byte, int) {
+	return func(dst, src []byte, eof bool) ([]byte, int) {
+		for _, b := range src {
+			if b < utf8.RuneSelf {
+				dst = append(dst, b)
+				continue
+			}
+			dst = utf8.AppendRune(dst, table[b-utf8.RuneSelf])
+		}
+		return dst, len(src)
+	}
+}
+
+func encodeSingleByte(encoding Encoding, table *[128]rune) func(dst []byte, r rune) ([]byte, error) {
+	reverse := make(map[rune]byte, len(table))
+	for i, r := range table {
+		if r != utf8.RuneError {
+			reverse[r] = byte(i + utf8.RuneSelf)
+		}
+	}
+	return func(dst []byte, r rune) ([]byte, error) {
+		if r < utf8.RuneSelf {
+			return append(dst, byte(r)), nil
+		}
+		b, ok := reverse[r]
+		if !ok {
+			return dst, fmt.Errorf("character %q can't be encoded in %s", r, encoding)
+		}
+		return append(dst, b), nil
+	}
+}
+
+// latin1 maps the upper half of ISO-8859-1 to Unicode
+var latin1 = func() (table [128]rune) {
+	for i := range table {
+		table[i] = rune(i + utf8.RuneSelf)
+	}
+	return table
+}()
+
+// windows1251 maps the upper half of the Windows-1251 code page to Unicode, 0x98 is unused
+var windows1251 = [128]rune{
+	0x0402, 0x0403, 0x201A, 0x0453, 0x201E, 0x2026, 0x2020, 0x2021,
+	0x20AC, 0x2030, 0x0409, 0x2039, 0x040A, 0x040C, 0x040B, 0x040F,
+	0x0452, 0x2018, 0x2019, 0x201C, 0x201D, 0x2022, 0x2013, 0x2014,
+	0xFFFD, 0x2122, 0x0459, 0x203A, 0x045A, 0x045C, 0x045B, 0x045F,
+	0x00A0, 0x040E, 0x045E, 0x0408, 0x00A4, 0x0490, 0x00A6, 0x00A7,
+	0x0401, 0x00A9, 0x0404, 0x00AB, 0x00AC, 0x00AD, 0x00AE, 0x0407,
+	0x00B0, 0x00B1, 0x0406, 0x0456, 0x0491, 0x00B5, 0x00B6, 0x00B7,
+	0x0451, 0x2116, 0x0454, 0x00BB, 0x0458, 0x0405, 0x0455, 0x0457,
+	0x0410, 0x0411, 0x0412, 0x0413, 0x0414, 0x0415, 0x0416, 0x0417,
+	0x0418, 0x0419, 0x041A, 0x041B, 0x041C, 0x041D, 0x041E, 0x041F,
+	0x0420, 0x0421, 0x0422, 0x0423, 0x0424, 0x0425, 0x0426, 0x0427,
+	0x0428, 0x0429, 0x042A, 0x042B, 0x042C, 0x042D, 0x042E, 0x042F,
+	0x0430, 0x0431, 0x0432, 0x0433, 0x0434, 0x0435, 0x0436, 0x0437,
+	0x0438, 0x0439, 0x043A, 0x043B, 0x043C, 0x043D, 0x043E, 0x043F,
+	0x0440, 0x0441, 0x0442, 0x0443, 0x0444, 0x0445, 0x0446, 0x0447,
+	0x0448, 0x0449, 0x044A, 0x044B, 0x044C, 0x044D, 0x044E, 0x044F,
+}
@@ -0,0 +1,62 @@
+package ptvvisum
+
+import (
+	"errors"
+	"fmt"
+)
+
+// fieldError is returned by the row parsers when a single column of a row is missing or malformed
+type fieldError struct {
+	column string
+	err    error // nil when a required column is empty
+}
+
+func (e *fieldError) Error() string {
+	if e.err == nil {
+		return fmt.Sprintf("missing required field %s", e.column)
+	}
+	return fmt.Sprintf("error parsing %s: %v", e.column, e.err)
+}
+
+func (e *fieldError) Unwrap() error {
+	return e.err
+}
+
+// parseFieldError reports a value of the column which can't be parsed
+func parseFieldError(column string, err error) error {
+	return &fieldError{column: column, err: err}
+}
+
+// missingFieldError reports an empty value of the required column
+func missingFieldError(column string) error {
+	return &fieldError{column: column}
+}
+
+// ParseError describes a row of a network file which can't be parsed.
+// Use errors.As to get it from the errors returned by the readers
+type ParseError struct {
+	Line    int    // 1-based line number in the source file
+	Section string // Section name without "$", e.g. "LINK"
+	Column  string // Header of the column which failed, empty if the whole row is malformed
+	Value   string // Raw value of the failed column
+	Err     error
+}
+
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("line %d: %v", e.Line, e.Err)
+}
+
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// newParseError attaches the position of a row to the error returned by decodeRow
+func newParseError(section *BaseSection, line int, values []string, err error) *ParseError {
+	parseErr := &ParseError{Line: line, Section: section.name, Err: err}
+	var fieldErr *fieldError
+	if errors.As(err, &fieldErr) {
+		parseErr.Column = fieldErr.column
+		parseErr.Value = columnValue(values, section.headers, fieldErr.column, -1)
+	}
+	return parseErr
+}
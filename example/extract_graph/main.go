@@ -16,11 +16,21 @@ func main() {
 		return
 	}
 	defer file.Close()
-	ptvData, err := ptvvisum.ReadPTVFromFile(file)
+	ptvData, err := ptvvisum.ReadPTVFromFileWithOptions(file, ptvvisum.ReadOptions{
+		Sections:    roadnet.Sections(),
+		SkipRawRows: true,
+	})
 	if err != nil {
 		fmt.Println(err)
 		return
 	}
+	// Broken references would make ExtractGraph fail on the first one, so report them all beforehand
+	if issues := ptvvisum.Validate(ptvData); len(issues) > 0 {
+		for _, issue := range issues {
+			fmt.Println(issue)
+		}
+		return
+	}
 	roadNetwork, err := roadnet.ExtractGraph(ptvData)
 	if err != nil {
 		fmt.Println(err)
@@ -56,9 +56,9 @@ func main() {
 
 	fmt.Println("\nValid Days:")
 	for _, day := range ptvData.ValidDays.Days {
-		fmt.Printf("\tNo: %d, Code: %s, Name: %s, DayVector: %d, "+
+		fmt.Printf("\tNo: %d, Code: %s, Name: %s, DayVector: %s, "+
 			"PrfacHourCost: %.3f, PrfacSupply: %.3f\n",
-			day.No, day.Code, day.Name, day.DayVector,
+			day.No, day.Code, day.Name, day.DayVectorText,
 			day.PrfacHourCost, day.PrfacSupply)
 	}
 
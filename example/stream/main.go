@@ -0,0 +1,39 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	ptvvisum "github.com/lddl/go-ptv-visum"
+)
+
+func main() {
+	file, err := os.Open("./example/sample/example.net")
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	defer file.Close()
+
+	nodesNum := 0
+	linksNum := 0
+	totalLength := 0.0
+	err = ptvvisum.StreamPTV(file, ptvvisum.Visitor{
+		OnNode: func(node ptvvisum.Node) error {
+			nodesNum++
+			return nil
+		},
+		OnLink: func(link ptvvisum.Link) error {
+			linksNum++
+			totalLength += link.GetLengthInKm()
+			return nil
+		},
+	})
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	fmt.Printf("Nodes: %d\n", nodesNum)
+	fmt.Printf("Links: %d\n", linksNum)
+	fmt.Printf("Total links length: %.2f km\n", totalLength)
+}
@@ -0,0 +1,121 @@
+package ptvvisum
+
+import (
+	"sort"
+	"sync"
+)
+
+// index maps a key to the positions of the records having it, in slice order.
+// It is built on first use and rebuilt when the slice has been replaced, its length has changed or
+// a lookup finds the records no longer match the positions, see valid
+type index[T any, K comparable] struct {
+	mu        sync.Mutex
+	first     *T // First element of the indexed slice
+	length    int
+	positions map[K][]int
+}
+
+// lookup returns the positions of the records with the given key
+func (idx *index[T, K]) lookup(records []T, key K, keyOf func(T) K) []int {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+	if !idx.current(records) {
+		idx.build(records, keyOf)
+	}
+	positions := idx.positions[key]
+	if !idx.valid(records, key, keyOf, positions) {
+		idx.build(records, keyOf)
+		positions = idx.positions[key]
+	}
+	return positions
+}
+
+// valid reports whether the indexed positions of key still match the records. A hit whose key has changed
+// means records were edited, removed or appended in place. A miss is checked against the records, as one of
+// them may have been changed to the key. A record changed to a key other records still have is not noticed
+// until the next rebuild
+func (idx *index[T, K]) valid(records []T, key K, keyOf func(T) K, positions []int) bool {
+	for _, i := range positions {
+		if keyOf(records[i]) != key {
+			return false
+		}
+	}
+	if len(positions) > 0 {
+		return true
+	}
+	for _, record := range records {
+		if keyOf(record) == key {
+			return false
+		}
+	}
+	return true
+}
+
+// find returns the records with the given key in slice order
+func (idx *index[T, K]) find(records []T, key K, keyOf func(T) K) []T {
+	return pick(records, idx.lookup(records, key, keyOf))
+}
+
+// findFirst returns the first record with the given key
+func (idx *index[T, K]) findFirst(records []T, key K, keyOf func(T) K) (T, bool) {
+	positions := idx.lookup(records, key, keyOf)
+	if len(positions) == 0 {
+		var zero T
+		return zero, false
+	}
+	return records[positions[0]], true
+}
+
+// reset drops the index, it is built again on the next lookup
+func (idx *index[T, K]) reset() {
+	idx.mu.Lock()
+	idx.positions = nil
+	idx.mu.Unlock()
+}
+
+func (idx *index[T, K]) current(records []T) bool {
+	if idx.positions == nil || len(records) != idx.length {
+		return false
+	}
+	return len(records) == 0 || &records[0] == idx.first
+}
+
+func (idx *index[T, K]) build(records []T, keyOf func(T) K) {
+	idx.positions = make(map[K][]int)
+	for i, record := range records {
+		key := keyOf(record)
+		idx.positions[key] = append(idx.positions[key], i)
+	}
+	idx.first = nil
+	if len(records) > 0 {
+		idx.first = &records[0]
+	}
+	idx.length = len(records)
+}
+
+// pick returns the records at the given positions
+func pick[T any](records []T, positions []int) []T {
+	if len(positions) == 0 {
+		return nil
+	}
+	result := make([]T, len(positions))
+	for i, position := range positions {
+		result[i] = records[position]
+	}
+	return result
+}
+
+// mergePositions joins the results of two lookups keeping slice order, positions found by both are taken once
+func mergePositions(a, b []int) []int {
+	merged := make([]int, 0, len(a)+len(b))
+	merged = append(merged, a...)
+	merged = append(merged, b...)
+	sort.Ints(merged)
+	unique := merged[:0]
+	for _, position := range merged {
+		if len(unique) == 0 || position != unique[len(unique)-1] {
+			unique = append(unique, position)
+		}
+	}
+	return unique
+}
@@ -0,0 +1,52 @@
+package ptvvisum
+
+import (
+	"testing"
+)
+
+func TestIndexFollowsInPlaceChanges(t *testing.T) {
+	section := NodeSection{Nodes: []Node{{ID: 1}, {ID: 2}, {ID: 3}}}
+	if _, found := section.GetNodeByID(2); !found {
+		t.Fatal("node 2 not found")
+	}
+
+	// Key changed in place to one no record had
+	section.Nodes[0].ID = 10
+	if node, found := section.GetNodeByID(10); !found || node.ID != 10 {
+		t.Errorf("node 10 not found after changing the ID in place")
+	}
+	if _, found := section.GetNodeByID(1); found {
+		t.Errorf("node 1 found after changing its ID")
+	}
+
+	// Same slice and length, the second record removed and a new one appended
+	section.Nodes = append(section.Nodes[:1], section.Nodes[2:]...)
+	section.Nodes = append(section.Nodes, Node{ID: 4, Code: "appended"})
+	if node, found := section.GetNodeByID(4); !found || node.Code != "appended" {
+		t.Errorf("appended node 4 not found")
+	}
+	if node, found := section.GetNodeByID(3); !found || node.ID != 3 {
+		t.Errorf("node 3 not found after removing the record before it")
+	}
+	if _, found := section.GetNodeByID(2); found {
+		t.Errorf("removed node 2 found")
+	}
+}
+
+func TestIndexFollowsInPlaceChangesOfMultipleRecords(t *testing.T) {
+	section := LinkSection{Links: []Link{
+		{No: 1, FromNodeNo: 1, ToNodeNo: 2},
+		{No: 2, FromNodeNo: 2, ToNodeNo: 3},
+	}}
+	if links := section.GetLinksByFromNode(1); len(links) != 1 {
+		t.Fatalf("got %d links from node 1, want 1", len(links))
+	}
+
+	section.Links[0].FromNodeNo = 5
+	if links := section.GetLinksByFromNode(5); len(links) != 1 || links[0].No != 1 {
+		t.Errorf("got links %+v from node 5, want link 1", links)
+	}
+	if links := section.GetLinksByFromNode(1); len(links) != 0 {
+		t.Errorf("got links %+v from node 1, want none", links)
+	}
+}
@@ -0,0 +1,142 @@
+package ptvvisum
+
+import "sort"
+
+// JunctionKey identifies a node or a main node owning legs, lanes, lane turns and crosswalks.
+// Exactly one of the numbers is set, the other one is 0
+type JunctionKey struct {
+	NodeNo     int
+	MainNodeNo int
+}
+
+// Junction is the geometry of a node or main node gathered from the LEG, LANE, LANETURN and CROSSWALK sections
+type Junction struct {
+	Key        JunctionKey
+	Legs       []Leg
+	Lanes      []Lane
+	LaneTurns  []LaneTurn
+	Crosswalks []Crosswalk
+}
+
+// GetJunction builds the junction model of a node or main node, false is returned when it has no geometry at all
+func GetJunction(data *PTVData, key JunctionKey) (Junction, bool) {
+	junction := Junction{Key: key}
+	if data.Leg != nil {
+		junction.Legs = data.Leg.GetLegsByJunction(key)
+	}
+	if data.Lane != nil {
+		junction.Lanes = data.Lane.GetLanesByJunction(key)
+	}
+	if data.LaneTurn != nil {
+		junction.LaneTurns = data.LaneTurn.GetLaneTurnsByJunction(key)
+	}
+	if data.Crosswalk != nil {
+		junction.Crosswalks = data.Crosswalk.GetCrosswalksByJunction(key)
+	}
+	if len(junction.Legs) == 0 && len(junction.Lanes) == 0 && len(junction.LaneTurns) == 0 && len(junction.Crosswalks) == 0 {
+		return Junction{}, false
+	}
+	return junction, true
+}
+
+// GetJunctions builds the junction models of all nodes and main nodes with geometry,
+// nodes come first, each group ordered by number
+func GetJunctions(data *PTVData) []Junction {
+	keys := make(map[JunctionKey]bool)
+	if data.Leg != nil {
+		for _, leg := range data.Leg.Legs {
+			keys[leg.JunctionKey()] = true
+		}
+	}
+	if data.Lane != nil {
+		for _, lane := range data.Lane.Lanes {
+			keys[lane.JunctionKey()] = true
+		}
+	}
+	if data.LaneTurn != nil {
+		for _, turn := range data.LaneTurn.LaneTurns {
+			keys[turn.JunctionKey()] = true
+		}
+	}
+	if data.Crosswalk != nil {
+		for _, crosswalk := range data.Crosswalk.Crosswalks {
+			keys[crosswalk.JunctionKey()] = true
+		}
+	}
+
+	sorted := make([]JunctionKey, 0, len(keys))
+	for key := range keys {
+		sorted = append(sorted, key)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].MainNodeNo != sorted[j].MainNodeNo {
+			return sorted[i].MainNodeNo < sorted[j].MainNodeNo
+		}
+		return sorted[i].NodeNo < sorted[j].NodeNo
+	})
+
+	junctions := make([]Junction, 0, len(sorted))
+	for _, key := range sorted {
+		junction, _ := GetJunction(data, key)
+		junctions = append(junctions, junction)
+	}
+	return junctions
+}
+
+// GetLeg returns the leg of the junction with the given orientation
+func (j Junction) GetLeg(orientation string) (Leg, bool) {
+	for _, leg := range j.Legs {
+		if leg.Orientation == orientation {
+			return leg, true
+		}
+	}
+	return Leg{}, false
+}
+
+// GetLegByLink returns the leg of a node junction the link is attached to,
+// using the orientation stored on the link at that node
+func (j Junction) GetLegByLink(link Link) (Leg, bool) {
+	switch {
+	case j.Key.NodeNo == 0:
+		return Leg{}, false
+	case link.ToNodeNo == j.Key.NodeNo:
+		return j.GetLeg(link.ToNodeOrientation)
+	case link.FromNodeNo == j.Key.NodeNo:
+		return j.GetLeg(link.FromNodeOrientation)
+	}
+	return Leg{}, false
+}
+
+// GetLanesByLink returns the lanes of the junction on the given link, ordered by lane number
+func (j Junction) GetLanesByLink(linkNo int) []Lane {
+	var result []Lane
+	for _, lane := range j.Lanes {
+		if lane.LinkNo == linkNo {
+			result = append(result, lane)
+		}
+	}
+	sort.Slice(result, func(a, b int) bool { return result[a].No < result[b].No })
+	return result
+}
+
+// GetLaneTurnsFromLane returns the lane turns of the junction starting on the given lane
+func (j Junction) GetLaneTurnsFromLane(linkNo, laneNo int) []LaneTurn {
+	var result []LaneTurn
+	for _, turn := range j.LaneTurns {
+		if turn.FromLinkNo == linkNo && turn.FromLaneNo == laneNo {
+			result = append(result, turn)
+		}
+	}
+	return result
+}
+
+// GetCrosswalksByLeg returns the crosswalks of the junction crossing the leg with the given orientation
+func (j Junction) GetCrosswalksByLeg(orientation string) []Crosswalk {
+	var result []Crosswalk
+	for _, crosswalk := range j.Crosswalks {
+		if crosswalk.Orientation == orientation {
+			result = append(result, crosswalk)
+		}
+	}
+	return result
+}
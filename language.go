@@ -0,0 +1,248 @@
+package ptvvisum
+
+import "strings"
+
+// Language is the language of section and attribute names in a network file
+type Language string
+
+const (
+	LanguageEnglish Language = "ENG"
+	LanguageGerman  Language = "DEU"
+)
+
+// germanSections maps German section names to English ones
+var germanSections = map[string]string{
+	"POIKATEGORIE":            "POICATEGORY",
+	"BENUTZERDEFATTRIBUTE":    "USERATTDEF",
+	"KALENDERPERIODE":         "CALENDARPERIOD",
+	"VERKEHRSTAGE":            "VALIDDAYS",
+	"NETZ":                    "NETWORK",
+	"VSYS":                    "TSYS",
+	"MODUS":                   "MODE",
+	"NACHFRAGESEGMENT":        "DEMANDSEGMENT",
+	"UMLAUFELEMENTTYP":        "BLOCKITEMTYPE",
+	"TARIFMODELL":             "FAREMODEL",
+	"FZGEINHEIT":              "VEHUNIT",
+	"FZGKOMB":                 "VEHCOMB",
+	"FZGEINHEITZUFZGKOMB":     "VEHUNITTOVEHCOMB",
+	"RICHTUNG":                "DIRECTION",
+	"PUNKT":                   "POINT",
+	"KANTE":                   "EDGE",
+	"ZWISCHENPUNKT":           "EDGEITEM",
+	"TEILFLAECHE":             "FACE",
+	"TEILFLAECHENELEMENT":     "FACEITEM",
+	"FLAECHE":                 "SURFACE",
+	"FLAECHENELEMENT":         "SURFACEITEM",
+	"KNOTEN":                  "NODE",
+	"OBERKNOTEN":              "MAINNODE",
+	"BEZIRK":                  "ZONE",
+	"GEBIET":                  "TERRITORY",
+	"STRECKENTYP":             "LINKTYPE",
+	"STRECKE":                 "LINK",
+	"STRECKENPOLY":            "LINKPOLY",
+	"ABBIEGER":                "TURN",
+	"OBERABBIEGER":            "MAINTURN",
+	"ANBINDUNG":               "CONNECTOR",
+	"HALTESTELLE":             "STOP",
+	"HALTESTELLENBEREICH":     "STOPAREA",
+	"HALTEPUNKT":              "STOPPOINT",
+	"LINIE":                   "LINE",
+	"LINIENROUTE":             "LINEROUTE",
+	"LINIENROUTENELEMENT":     "LINEROUTEITEM",
+	"FAHRZEITPROFIL":          "TIMEPROFILE",
+	"FAHRZEITPROFILELEMENT":   "TIMEPROFILEITEM",
+	"FZGFAHRT":                "VEHJOURNEY",
+	"FZGFAHRTABSCHNITT":       "VEHJOURNEYSECTION",
+	"UEBERGANGSGEHZEITHSTBER": "TRANSFERWALKTIMESTOPAREA",
+	"UMLAUFVERSION":           "BLOCKVERSION",
+	"UMLAUF":                  "BLOCK",
+	"UMLAUFELEMENT":           "BLOCKITEM",
+	"ARM":                     "LEG",
+	"FAHRSTREIFEN":            "LANE",
+	"FAHRSTREIFENABBIEGER":    "LANETURN",
+	"FUSSGAENGERUEBERWEG":     "CROSSWALK",
+	"LSA":                     "SIGNALCONTROL",
+	"SIGNALGRUPPE":            "SIGNALGROUP",
+	"ZAEHLSTELLE":             "COUNTLOCATION",
+	"DETEKTOR":                "DETECTOR",
+}
+
+// germanAttributes maps German column names to English ones.
+// Columns with a parameter such as T0_VSYS(CAR) are translated by the part before the bracket
+var germanAttributes = map[string]string{
+	"DATEITYP":        "FILETYPE",
+	"SPRACHE":         "LANGUAGE",
+	"EINHEIT":         "UNIT",
+	"NR":              "NO",
+	"KOMMENTAR":       "COMMENT",
+	"OBERKATNR":       "PARENTCATNO",
+	"KATNR":           "CATNO",
+	"TYP":             "TYPE",
+	"TYPNR":           "TYPENO",
+	"GUELTIGAB":       "VALIDFROM",
+	"GUELTIGBIS":      "VALIDUNTIL",
+	"TAGESVEKTOR":     "DAYVECTOR",
+	"MASSSTAB":        "SCALE",
+	"LINKSVERKEHR":    "LEFTHANDTRAFFIC",
+	"PKWE":            "PCU",
+	"VSYSSET":         "TSYSSET",
+	"VSYSCODE":        "TSYSCODE",
+	"MODUS":           "MODE",
+	"BESETZUNGSGRAD":  "OCCUPANCYRATE",
+	"ANGETRIEBEN":     "POWERED",
+	"SITZPL":          "SEATCAP",
+	"GESAMTPL":        "TOTALCAP",
+	"FZGKOMBNR":       "VEHCOMBNO",
+	"FZGEINHEITNR":    "VEHUNITNO",
+	"ANZFZGEINH":      "NUMVEHUNITS",
+	"XKOORD":          "XCOORD",
+	"YKOORD":          "YCOORD",
+	"ZKOORD":          "ZCOORD",
+	"VONPUNKTID":      "FROMPOINTID",
+	"NACHPUNKTID":     "TOPOINTID",
+	"KANTEID":         "EDGEID",
+	"TFLAECHEID":      "FACEID",
+	"FLAECHEID":       "SURFACEID",
+	"RICHTUNG":        "DIRECTION",
+	"ENKLAVE":         "ENCLAVE",
+	"STEUERUNGSTYP":   "CONTROLTYPE",
+	"OBERKNOTNR":      "MAINNODENO",
+	"OBERBEZNR":       "MAINZONENO",
+	"ZWERT1":          "ADDVAL1",
+	"ZWERT2":          "ADDVAL2",
+	"ZWERT3":          "ADDVAL3",
+	"T0IV":            "T0PRT",
+	"KAPIV":           "CAPPRT",
+	"V0IV":            "V0PRT",
+	"VMINIV":          "VMINPRT",
+	"GTYP":            "GTYPE",
+	"RANG":            "RANK",
+	"ANZFAHRSTREIFEN": "NUMLANES",
+	"VONKNOTNR":       "FROMNODENO",
+	"NACHKNOTNR":      "TONODENO",
+	"UEBERKNOTNR":     "VIANODENO",
+	"KNOTNR":          "NODENO",
+	"BEZNR":           "ZONENO",
+	"HSTNR":           "STOPNO",
+	"HSTBERNR":        "STOPAREANO",
+	"STRNR":           "LINKNO",
+	"GERICHTET":       "DIRECTED",
+	"LINNAME":         "LINENAME",
+	"LINROUTENAME":    "LINEROUTENAME",
+	"RICHTUNGCODE":    "DIRECTIONCODE",
+	"BETREIBERNR":     "OPERATORNO",
+	"ISTROUTENPUNKT":  "ISROUTEPOINT",
+	"HPUNKTNR":        "STOPPOINTNO",
+	"NACHLAENGE":      "POSTLENGTH",
+	"FZPROFILNAME":    "TIMEPROFILENAME",
+	"LRELEMINDEX":     "LRITEMINDEX",
+	"ANKUNFT":         "ARR",
+	"ABFAHRT":         "DEP",
+	"FZGFAHRTNR":      "VEHJOURNEYNO",
+	"VTAGNR":          "VALIDDAYSNO",
+	"VONHSTBERNR":     "FROMSTOPAREANO",
+	"NACHHSTBERNR":    "TOSTOPAREANO",
+	"ZEIT":            "TIME",
+	"UMLAUFVERSIONID": "BLOCKVERSIONID",
+	"UMLAUFID":        "BLOCKID",
+	"LSANR":           "SCNO",
+	"SGNR":            "SGNO",
+	"UMLAUFZEIT":      "CYCLETIME",
+	"LAENGE":          "LENGTH",
+	"PLANNR":          "PLANNO",
+	"T_OVSYS":         "T_PUTSYS",
+	"T0_VSYS":         "T0_TSYS",
+	"VMAX_IVSYS":      "VMAX_PRTSYS",
+	"VSTD_OVSYS":      "VDEF_PUTSYS",
+	"GEWICHT(IV)":     "WEIGHT(PRT)",
+	"GEWICHT(OV)":     "WEIGHT(PUT)",
+	"ANTEILSV":        "SHAREHGV",
+	"BEMERKUNG":       "NOTES",
+}
+
+// englishSections and englishAttributes map English names back to German ones
+var englishSections, englishAttributes = reverseNames(germanSections), reverseNames(germanAttributes)
+
+func reverseNames(names map[string]string) map[string]string {
+	reversed := make(map[string]string, len(names))
+	for german, english := range names {
+		reversed[english] = german
+	}
+	return reversed
+}
+
+// isGermanHeader reports whether a section header is written with German names.
+// Sections which have the same name in both languages are recognized by their columns
+func isGermanHeader(name string, headers []string) bool {
+	if _, ok := germanSections[name]; ok {
+		return true
+	}
+	if strings.HasPrefix(name, "POIKAT_") {
+		return true
+	}
+	for _, header := range headers {
+		if header == "SPRACHE" {
+			return true
+		}
+	}
+	return false
+}
+
+// translateSectionName converts a section name to the English one, other names are returned unchanged
+func translateSectionName(name string) string {
+	if english, ok := germanSections[name]; ok {
+		return english
+	}
+	if no, found := strings.CutPrefix(name, "POIKAT_"); found {
+		return "POIOFCAT_" + no
+	}
+	return name
+}
+
+// translateHeaders converts German column names to English ones, other names are kept unchanged
+func translateHeaders(headers []string) []string {
+	return translateNames(headers, germanAttributes)
+}
+
+// localizeSectionName converts an English section name to the given language
+func localizeSectionName(name string, language Language) string {
+	if language != LanguageGerman {
+		return name
+	}
+	if german, ok := englishSections[name]; ok {
+		return german
+	}
+	if no, found := strings.CutPrefix(name, "POIOFCAT_"); found {
+		return "POIKAT_" + no
+	}
+	return name
+}
+
+// localizeHeaders converts English column names to the given language
+func localizeHeaders(headers []string, language Language) []string {
+	if language != LanguageGerman {
+		return headers
+	}
+	return translateNames(headers, englishAttributes)
+}
+
+func translateNames(names []string, dictionary map[string]string) []string {
+	if len(names) == 0 {
+		return names
+	}
+	translated := make([]string, len(names))
+	for i, name := range names {
+		translated[i] = name
+		if other, ok := dictionary[name]; ok {
+			translated[i] = other
+			continue
+		}
+		// Parametrized columns such as T0_VSYS(CAR)
+		if base, param, found := strings.Cut(name, "("); found {
+			if other, ok := dictionary[base]; ok {
+				translated[i] = other + "(" + param
+			}
+		}
+	}
+	return translated
+}
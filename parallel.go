@@ -0,0 +1,160 @@
+package ptvvisum
+
+import (
+	"errors"
+	"sync"
+)
+
+// parallelChunkSize is the number of rows handed to a worker at once
+const parallelChunkSize = 1024
+
+// errDecodingStopped is returned to the scanner when a worker has failed, the failure itself is reported by wait
+var errDecodingStopped = errors.New("decoding stopped")
+
+// rowChunk is a batch of consecutive rows of a single section
+type rowChunk struct {
+	section *BaseSection
+	opening *BaseSection // Copy of the section taken at its header, set instead of rows when a section starts
+	lines   []int
+	rows    [][]string
+	records []any
+	errs    []*ParseError
+	done    chan struct{} // Closed when the rows are decoded
+}
+
+// parallelDecoder decodes rows on a pool of workers and stores the records in PTVData in file order,
+// so the result is the same as of decoding them one by one while reading
+type parallelDecoder struct {
+	data      *PTVData
+	lenient   bool
+	current   *rowChunk
+	jobs      chan *rowChunk // Chunks waiting for a worker
+	ordered   chan *rowChunk // All chunks in file order waiting for the collector
+	stopped   chan struct{}  // Closed by the collector on the first error
+	collected chan struct{}  // Closed when the collector has finished
+	workers   sync.WaitGroup
+	err       error
+}
+
+func newParallelDecoder(data *PTVData, workers int, lenient bool) *parallelDecoder {
+	d := &parallelDecoder{
+		data:      data,
+		lenient:   lenient,
+		jobs:      make(chan *rowChunk, workers),
+		ordered:   make(chan *rowChunk, workers*4),
+		stopped:   make(chan struct{}),
+		collected: make(chan struct{}),
+	}
+	d.workers.Add(workers)
+	for i := 0; i < workers; i++ {
+		go d.decode()
+	}
+	go d.collect()
+	return d
+}
+
+// openSection queues the creation of the specialized section after the records of the previous sections
+func (d *parallelDecoder) openSection(section *BaseSection) error {
+	if err := d.flush(); err != nil {
+		return err
+	}
+	opening := *section
+	return d.enqueue(&rowChunk{opening: &opening})
+}
+
+// addRow queues a data row for decoding
+func (d *parallelDecoder) addRow(section *BaseSection, line int, values []string) error {
+	if d.current == nil {
+		d.current = &rowChunk{
+			section: section,
+			lines:   make([]int, 0, parallelChunkSize),
+			rows:    make([][]string, 0, parallelChunkSize),
+			done:    make(chan struct{}),
+		}
+	}
+	d.current.lines = append(d.current.lines, line)
+	d.current.rows = append(d.current.rows, values)
+	if len(d.current.rows) < parallelChunkSize {
+		return nil
+	}
+	return d.flush()
+}
+
+// flush hands the rows collected so far to the workers
+func (d *parallelDecoder) flush() error {
+	chunk := d.current
+	if chunk == nil {
+		return nil
+	}
+	d.current = nil
+	select {
+	case d.jobs <- chunk:
+	case <-d.stopped:
+		return errDecodingStopped
+	}
+	return d.enqueue(chunk)
+}
+
+func (d *parallelDecoder) enqueue(chunk *rowChunk) error {
+	select {
+	case d.ordered <- chunk:
+		return nil
+	case <-d.stopped:
+		return errDecodingStopped
+	}
+}
+
+// wait decodes the remaining rows and returns the first error in file order
+func (d *parallelDecoder) wait() error {
+	d.flush()
+	close(d.jobs)
+	close(d.ordered)
+	<-d.collected
+	d.workers.Wait()
+	return d.err
+}
+
+func (d *parallelDecoder) decode() {
+	defer d.workers.Done()
+	for chunk := range d.jobs {
+		chunk.records = make([]any, len(chunk.rows))
+		chunk.errs = make([]*ParseError, len(chunk.rows))
+		for i, values := range chunk.rows {
+			record, err := decodeRow(chunk.section, values)
+			if err != nil {
+				chunk.errs[i] = newParseError(chunk.section, chunk.lines[i], values, err)
+				continue
+			}
+			chunk.records[i] = record
+		}
+		close(chunk.done)
+	}
+}
+
+func (d *parallelDecoder) collect() {
+	defer close(d.collected)
+	for chunk := range d.ordered {
+		if chunk.opening != nil {
+			if d.err == nil {
+				d.data.addSection(chunk.opening)
+			}
+			continue
+		}
+		<-chunk.done
+		if d.err != nil {
+			continue
+		}
+		for i, record := range chunk.records {
+			if parseErr := chunk.errs[i]; parseErr != nil {
+				if !d.lenient {
+					d.err = parseErr
+					close(d.stopped)
+					break
+				}
+				d.data.Warnings = append(d.data.Warnings, parseErr)
+				continue
+			}
+			d.data.addRecord(record)
+		}
+	}
+}
@@ -0,0 +1,85 @@
+package ptvvisum
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// readSample returns the sample network shipped with the examples
+func readSample(t *testing.T) []byte {
+	t.Helper()
+	raw, err := os.ReadFile("example/sample/example.net")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return raw
+}
+
+// breakRows replaces the first data row after each of the given section headers with a malformed one
+func breakRows(t *testing.T, raw []byte, headers ...string) []byte {
+	t.Helper()
+	lines := strings.Split(string(raw), "\n")
+	for _, header := range headers {
+		found := false
+		for i, line := range lines {
+			if strings.HasPrefix(line, header) && i+2 < len(lines) {
+				// The second row, so that the section already has records
+				lines[i+2] = "broken;row\r"
+				found = true
+				break
+			}
+		}
+		if !found {
+			t.Fatalf("section %s not found in sample", header)
+		}
+	}
+	return []byte(strings.Join(lines, "\n"))
+}
+
+func TestParallelReadMatchesSerial(t *testing.T) {
+	sample := readSample(t)
+	broken := breakRows(t, sample, "$LINK:", "$EDGEITEM:", "$VEHJOURNEY:")
+
+	tests := []struct {
+		name    string
+		input   []byte
+		lenient bool
+	}{
+		{"strict", sample, false},
+		{"lenient", sample, true},
+		{"strict with malformed rows", broken, false},
+		{"lenient with malformed rows", broken, true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			serial, serialErr := ReadPTVFromFileWithOptions(bytes.NewReader(test.input), ReadOptions{Lenient: test.lenient})
+			for _, workers := range []int{2, 3, 16} {
+				parallel, err := ReadPTVFromFileWithOptions(bytes.NewReader(test.input), ReadOptions{Lenient: test.lenient, Workers: workers})
+				if !reflect.DeepEqual(err, serialErr) {
+					t.Errorf("workers %d: got error %v, want %v", workers, err, serialErr)
+				}
+				if !reflect.DeepEqual(parallel, serial) {
+					t.Errorf("workers %d: data differs from serial read", workers)
+				}
+			}
+
+			switch {
+			case test.lenient && bytes.Equal(test.input, broken):
+				if serialErr != nil || len(serial.Warnings) != 3 {
+					t.Errorf("got error %v and %d warnings, want 3 warnings", serialErr, len(serial.Warnings))
+				}
+			case bytes.Equal(test.input, broken):
+				var parseErr *ParseError
+				if !errors.As(serialErr, &parseErr) || parseErr.Section != "EDGEITEM" {
+					t.Errorf("got error %v, want the malformed EDGEITEM row", serialErr)
+				}
+			case serialErr != nil:
+				t.Fatal(serialErr)
+			}
+		})
+	}
+}
@@ -2,6 +2,7 @@ package ptvvisum
 
 import (
 	"bufio"
+	"errors"
 	"fmt"
 	"io"
 	"strings"
@@ -9,51 +10,212 @@ import (
 
 // PTVData represents the complete PTV Visum network file data
 type PTVData struct {
-	Version          *VersionSection
-	Info             *InfoSection
-	POICategory      *POICategorySection
-	UserAttDef       *UserAttDefSection
-	CalendarPeriod   *CalendarPeriodSection
-	ValidDays        *ValidDaysSection
-	Network          *NetworkSection
-	TSys             *TSysSection
-	Mode             *ModeSection
-	DemandSegment    *DemandSegmentSection
-	BlockItemType    *BlockItemTypeSection
-	FareModel        *FareModelSection
-	VehUnit          *VehUnitSection
-	VehComb          *VehCombSection
-	VehUnitToVehComb *VehUnitToVehCombSection
-	Direction        *DirectionSection
-	Point            *PointSection
-	Edge             *EdgeSection
-	EdgeItem         *EdgeItemSection
-	Face             *FaceSection
-	FaceItem         *FaceItemSection
-	Surface          *SurfaceSection
-	SurfaceItem      *SurfaceItemSection
-	Node             *NodeSection
-	Zone             *ZoneSection
-	LinkType         *LinkTypeSection
-	Link             *LinkSection
-	LinkPoly         *LinkPolySection
-	Turn             *TurnSection
-	Connector        *ConnectorSection
+	Version                  *VersionSection
+	Info                     *InfoSection
+	POICategory              *POICategorySection
+	UserAttDef               *UserAttDefSection
+	CalendarPeriod           *CalendarPeriodSection
+	ValidDays                *ValidDaysSection
+	Network                  *NetworkSection
+	TSys                     *TSysSection
+	Mode                     *ModeSection
+	DemandSegment            *DemandSegmentSection
+	BlockItemType            *BlockItemTypeSection
+	FareModel                *FareModelSection
+	VehUnit                  *VehUnitSection
+	VehComb                  *VehCombSection
+	VehUnitToVehComb         *VehUnitToVehCombSection
+	Direction                *DirectionSection
+	Point                    *PointSection
+	Edge                     *EdgeSection
+	EdgeItem                 *EdgeItemSection
+	Face                     *FaceSection
+	FaceItem                 *FaceItemSection
+	Surface                  *SurfaceSection
+	SurfaceItem              *SurfaceItemSection
+	Node                     *NodeSection
+	Zone                     *ZoneSection
+	LinkType                 *LinkTypeSection
+	Link                     *LinkSection
+	LinkPoly                 *LinkPolySection
+	Turn                     *TurnSection
+	Connector                *ConnectorSection
+	Stop                     *StopSection
+	StopArea                 *StopAreaSection
+	StopPoint                *StopPointSection
+	Line                     *LineSection
+	LineRoute                *LineRouteSection
+	LineRouteItem            *LineRouteItemSection
+	TimeProfile              *TimeProfileSection
+	TimeProfileItem          *TimeProfileItemSection
+	VehJourney               *VehJourneySection
+	VehJourneySection        *VehJourneySectionSection
+	TransferWalkTimeStopArea *TransferWalkTimeStopAreaSection
+	POIs                     map[int]*POISection // $POIOFCAT_<n> sections keyed by category number
+	BlockVersion             *BlockVersionSection
+	Block                    *BlockSection
+	BlockItem                *BlockItemSection
+	Leg                      *LegSection
+	Lane                     *LaneSection
+	LaneTurn                 *LaneTurnSection
+	Crosswalk                *CrosswalkSection
+	SignalControl            *SignalControlSection
+	SignalControlToNode      *SignalControlToNodeSection
+	SignalGroup              *SignalGroupSection
+	SignalGroupToTurn        *SignalGroupToTurnSection
+	SignalGroupToLaneTurn    *SignalGroupToLaneTurnSection
+	Stage                    *StageSection
+	SignalGroupToStage       *SignalGroupToStageSection
+	MainNode                 *MainNodeSection
+	MainTurn                 *MainTurnSection
+	Territory                *TerritorySection
+	Screenline               *ScreenlineSection
+	ScreenlinePoly           *ScreenlinePolySection
+	CountLocation            *CountLocationSection
+	Detector                 *DetectorSection
 
 	Sections map[string]Section // Generic access to all sections
+	Warnings []*ParseError      // Rows skipped while reading in lenient mode
+}
+
+// ReadOptions controls which data ReadPTVFromFileWithOptions loads
+type ReadOptions struct {
+	// Sections lists the names of the sections to load, e.g. "NODE", "LINK" and "LINKPOLY".
+	// Rows of other sections are skipped without being parsed and their fields in PTVData stay nil.
+	// All sections are loaded if empty
+	Sections []string
+	// SkipRawRows disables keeping the raw values of every row in addition to the typed records.
+	// Sections are still available in PTVData.Sections but have no rows
+	SkipRawRows bool
+	// Lenient makes reading tolerant to files produced by other Visum versions or hand-edited files.
+	// Sections without support are kept as generic sections in PTVData.Sections instead of failing,
+	// rows which can't be parsed are skipped and reported in PTVData.Warnings
+	Lenient bool
+	// Encoding of the file. If empty it is detected by the byte order mark and files without one are read as UTF-8
+	Encoding Encoding
+	// Workers is the number of goroutines parsing rows while the file is read, e.g. runtime.NumCPU().
+	// Rows are parsed one by one on the reading goroutine if it is below 2. The result does not depend on it
+	Workers int
+}
+
+// wants reports whether the named section has to be loaded
+func (o ReadOptions) wants(name string) bool {
+	if len(o.Sections) == 0 {
+		return true
+	}
+	for _, section := range o.Sections {
+		if strings.EqualFold(strings.TrimPrefix(section, "$"), name) {
+			return true
+		}
+	}
+	return false
 }
 
 // ReadPTVFromFile parses a PTV Visum network file
 func ReadPTVFromFile(reader io.Reader) (*PTVData, error) {
+	return ReadPTVFromFileWithOptions(reader, ReadOptions{})
+}
+
+// ReadPTVFromFileWithOptions parses a PTV Visum network file loading only the data selected by options
+func ReadPTVFromFileWithOptions(reader io.Reader, options ReadOptions) (*PTVData, error) {
 	data := &PTVData{
 		Sections: make(map[string]Section),
 	}
+	var decoder *parallelDecoder
+	if options.Workers > 1 {
+		decoder = newParallelDecoder(data, options.Workers, options.Lenient)
+	}
+
+	onSection := func(section *BaseSection) (bool, error) {
+		if !options.wants(section.name) {
+			return false, nil
+		}
+		if !isTypedSection(section.name) && !options.Lenient {
+			return false, fmt.Errorf("unsupported section: %s", section.name)
+		}
+		// Store section in the data structure
+		data.Sections[section.name] = section
+		if decoder != nil {
+			return true, decoder.openSection(section)
+		}
+		data.addSection(section)
+		return true, nil
+	}
+
+	onRow := func(section *BaseSection, line int, values []string) error {
+		if !options.SkipRawRows {
+			section.AddRow(values)
+		}
+		if decoder != nil {
+			if !isTypedSection(section.name) {
+				return nil
+			}
+			return decoder.addRow(section, line, values)
+		}
+		record, err := decodeRow(section, values)
+		if err != nil {
+			parseErr := newParseError(section, line, values, err)
+			if !options.Lenient {
+				return parseErr
+			}
+			data.Warnings = append(data.Warnings, parseErr)
+			return nil
+		}
+		data.addRecord(record)
+		return nil
+	}
+
+	err := scanPTV(reader, options.Encoding, onSection, onRow)
+	if decoder != nil {
+		// Rows read before a failure of the scanner come first in the file, so their errors take precedence
+		if decodeErr := decoder.wait(); decodeErr != nil {
+			return nil, decodeErr
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	return data, nil
+}
 
-	scanner := bufio.NewScanner(reader)
+// scanPTV reads a PTV Visum network file line by line after transcoding it to UTF-8.
+// Lines have no length limit and quoted values may contain separators and line breaks.
+// onSection is called for every section header and onRow for every data row of the current section.
+// Rows of sections for which onSection returns false are skipped without being split.
+// onRow receives the 1-based line number where the row starts in the file
+func scanPTV(reader io.Reader, encoding Encoding, onSection func(section *BaseSection) (bool, error), onRow func(section *BaseSection, line int, values []string) error) error {
+	decoded, err := newDecoder(reader, encoding)
+	if err != nil {
+		return err
+	}
+	r := bufio.NewReader(decoded)
 	var currentSection *BaseSection
+	var tokenizer rowTokenizer
+	skipRows := false
+	lineNo := 0
+	rowLineNo := 0
+	german := false
+
+	for {
+		rawLine, err := readLine(r)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("error reading PTV file: %w", err)
+		}
+		lineNo++
 
-	for scanner.Scan() {
-		line := strings.TrimSpace(scanner.Text())
+		// Continue a quoted value which spans several lines
+		if tokenizer.pending() {
+			if tokenizer.feed(rawLine) && !skipRows {
+				if err := onRow(currentSection, rowLineNo, tokenizer.row()); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+		line := strings.TrimSpace(rawLine)
 
 		// Skip empty lines
 		if line == "" {
@@ -68,360 +230,506 @@ func ReadPTVFromFile(reader io.Reader) (*PTVData, error) {
 
 		// Handle section headers
 		if strings.HasPrefix(line, "$") {
+			skipRows = false
 			sectionParts := strings.SplitN(line, ":", 2)
 			sectionName := strings.TrimPrefix(sectionParts[0], "$")
 
-			// Create new section
-			currentSection = &BaseSection{
-				name: sectionName,
-				rows: [][]string{},
+			// $VISION marks the beginning of the file and holds no data
+			if sectionName == "VISION" && len(sectionParts) == 1 {
+				currentSection = nil
+				continue
 			}
 
 			// Parse headers if present
+			var headers []string
 			if len(sectionParts) > 1 && sectionParts[1] != "" {
-				currentSection.headers = strings.Split(sectionParts[1], ";")
+				headers = strings.Split(sectionParts[1], ";")
 			}
 
-			// Store section in the data structure
-			data.Sections[sectionName] = currentSection
+			// Files written by German Visum are loaded with English names
+			if isGermanHeader(sectionName, headers) {
+				german = true
+			}
+			if german {
+				sectionName = translateSectionName(sectionName)
+				headers = translateHeaders(headers)
+			}
 
-			// Create specialized section if supported
-			switch sectionName {
-			case "VERSION":
-				data.Version = &VersionSection{BaseSection: *currentSection}
-			case "INFO":
-				data.Info = &InfoSection{BaseSection: *currentSection}
-			case "POICATEGORY":
-				data.POICategory = &POICategorySection{BaseSection: *currentSection}
-			case "USERATTDEF":
-				data.UserAttDef = &UserAttDefSection{BaseSection: *currentSection}
-			case "CALENDARPERIOD":
-				data.CalendarPeriod = &CalendarPeriodSection{BaseSection: *currentSection}
-			case "VALIDDAYS":
-				data.ValidDays = &ValidDaysSection{BaseSection: *currentSection}
-			case "NETWORK":
-				data.Network = &NetworkSection{BaseSection: *currentSection}
-			case "TSYS":
-				data.TSys = &TSysSection{BaseSection: *currentSection}
-			case "MODE":
-				data.Mode = &ModeSection{BaseSection: *currentSection}
-			case "DEMANDSEGMENT":
-				data.DemandSegment = &DemandSegmentSection{BaseSection: *currentSection}
-			case "BLOCKITEMTYPE":
-				data.BlockItemType = &BlockItemTypeSection{BaseSection: *currentSection}
-			case "FAREMODEL":
-				data.FareModel = &FareModelSection{BaseSection: *currentSection}
-			case "VEHUNIT":
-				data.VehUnit = &VehUnitSection{BaseSection: *currentSection}
-			case "VEHCOMB":
-				data.VehComb = &VehCombSection{BaseSection: *currentSection}
-			case "VEHUNITTOVEHCOMB":
-				data.VehUnitToVehComb = &VehUnitToVehCombSection{BaseSection: *currentSection}
-			case "DIRECTION":
-				data.Direction = &DirectionSection{BaseSection: *currentSection}
-			case "POINT":
-				data.Point = &PointSection{BaseSection: *currentSection}
-			case "EDGE":
-				data.Edge = &EdgeSection{BaseSection: *currentSection}
-			case "EDGEITEM":
-				data.EdgeItem = &EdgeItemSection{BaseSection: *currentSection}
-			case "FACE":
-				data.Face = &FaceSection{BaseSection: *currentSection}
-			case "FACEITEM":
-				data.FaceItem = &FaceItemSection{BaseSection: *currentSection}
-			case "SURFACE", "SURFACE:ID": // Handle both formats
-				data.Surface = &SurfaceSection{BaseSection: *currentSection}
-			case "SURFACEITEM":
-				data.SurfaceItem = &SurfaceItemSection{BaseSection: *currentSection}
-			case "NODE":
-				data.Node = &NodeSection{BaseSection: *currentSection}
-			case "ZONE":
-				data.Zone = &ZoneSection{BaseSection: *currentSection}
-			case "LINKTYPE":
-				data.LinkType = &LinkTypeSection{BaseSection: *currentSection}
-			case "LINK":
-				data.Link = &LinkSection{BaseSection: *currentSection}
-			case "LINKPOLY":
-				data.LinkPoly = &LinkPolySection{BaseSection: *currentSection}
-			case "TURN":
-				data.Turn = &TurnSection{BaseSection: *currentSection}
-			case "CONNECTOR":
-				data.Connector = &ConnectorSection{BaseSection: *currentSection}
-			// Skip these public transit and specialized sections in one case
-			case "STOP", "STOPAREA", "STOPPOINT", "LINE", "LINEROUTE", "LINEROUTEITEM",
-				"TIMEPROFILE", "TIMEPROFILEITEM", "VEHJOURNEY", "VEHJOURNEYSECTION",
-				"TRANSFERWALKTIMESTOPAREA", "BLOCKVERSION", "POIOFCAT_32", "POIOFCAT_33", "POIOFCAT_34", "LEG", "LANE", "LANETURN", "CROSSWALK":
-			default:
-				return nil, fmt.Errorf("unsupported section: %s", sectionName)
+			// Create new section
+			currentSection = &BaseSection{
+				name:    sectionName,
+				headers: headers,
+				rows:    [][]string{},
 			}
 
+			wanted, err := onSection(currentSection)
+			if err != nil {
+				return err
+			}
+			skipRows = !wanted
 			continue
 		}
 
 		// Process data rows
-		if currentSection != nil {
-			values := strings.Split(line, ";")
-			currentSection.AddRow(values)
+		if currentSection == nil {
+			continue
+		}
+		if skipRows && !strings.Contains(line, `"`) {
+			// Rows without quotes can't span several lines, so they are skipped without being split
+			continue
+		}
+		rowLineNo = lineNo
+		if !tokenizer.feed(rawLine) {
+			continue
+		}
+		values := tokenizer.row()
+		if skipRows {
+			continue
+		}
+		if err := onRow(currentSection, rowLineNo, values); err != nil {
+			return err
+		}
+	}
 
-			// Process specific section data
-			switch currentSection.name {
-			case "VERSION":
-				if data.Version != nil && len(values) >= 4 {
-					version, fileType, language, unit, err := getVersion(values)
-					if err != nil {
-						return nil, fmt.Errorf("error parsing VERSION data: %w", err)
-					}
-					data.Version.Version = version
-					data.Version.FileType = fileType
-					data.Version.Language = language
-					data.Version.Unit = unit
-				}
-			case "INFO":
-				if data.Info != nil && len(values) >= 2 {
-					infoLine, err := getInfoLine(values)
-					if err != nil {
-						return nil, fmt.Errorf("error parsing INFO data: %w", err)
-					}
-					data.Info.Lines = append(data.Info.Lines, infoLine)
-				}
-			case "POICATEGORY":
-				if data.POICategory != nil && len(values) >= 5 {
-					poiCategory, err := getPoiCategory(values)
-					if err != nil {
-						return nil, fmt.Errorf("error parsing POICATEGORY data: %w", err)
-					}
-					data.POICategory.Categories = append(data.POICategory.Categories, poiCategory)
-				}
-			case "USERATTDEF":
-				if data.UserAttDef != nil {
-					attr, err := getUserAttDef(values)
-					if err != nil {
-						return nil, fmt.Errorf("error parsing USERATTDEF data: %w", err)
-					}
-					data.UserAttDef.Attributes = append(data.UserAttDef.Attributes, attr)
-				}
-			case "CALENDARPERIOD":
-				if data.CalendarPeriod != nil {
-					period, err := getCalendarPeriod(values)
-					if err != nil {
-						return nil, fmt.Errorf("error parsing CALENDARPERIOD data: %w", err)
-					}
-					data.CalendarPeriod.Periods = append(data.CalendarPeriod.Periods, period)
-				}
-			case "VALIDDAYS":
-				if data.ValidDays != nil {
-					day, err := getValidDay(values)
-					if err != nil {
-						return nil, fmt.Errorf("error parsing VALIDDAYS data: %w", err)
-					}
-					data.ValidDays.Days = append(data.ValidDays.Days, day)
-				}
-			case "NETWORK":
-				if data.Network != nil {
-					network, err := getNetwork(values)
-					if err != nil {
-						return nil, fmt.Errorf("error parsing NETWORK data: %w", err)
-					}
-					data.Network.Network = network
-				}
-			case "TSYS":
-				if data.TSys != nil {
-					system, err := getTransportSystem(values)
-					if err != nil {
-						return nil, fmt.Errorf("error parsing TSYS data: %w", err)
-					}
-					data.TSys.Systems = append(data.TSys.Systems, system)
-				}
-			case "MODE":
-				if data.Mode != nil {
-					mode, err := getMode(values)
-					if err != nil {
-						return nil, fmt.Errorf("error parsing MODE data: %w", err)
-					}
-					data.Mode.Modes = append(data.Mode.Modes, mode)
-				}
-			case "DEMANDSEGMENT":
-				if data.DemandSegment != nil {
-					segment, err := getDemandSegment(values)
-					if err != nil {
-						return nil, fmt.Errorf("error parsing DEMANDSEGMENT data: %w", err)
-					}
-					data.DemandSegment.Segments = append(data.DemandSegment.Segments, segment)
-				}
-			case "BLOCKITEMTYPE":
-				if data.BlockItemType != nil {
-					itemType, err := getBlockItemType(values)
-					if err != nil {
-						return nil, fmt.Errorf("error parsing BLOCKITEMTYPE data: %w", err)
-					}
-					data.BlockItemType.Types = append(data.BlockItemType.Types, itemType)
-				}
-			case "FAREMODEL":
-				if data.FareModel != nil {
-					fallbackFare, err := getFallbackFare(values)
-					if err != nil {
-						return nil, fmt.Errorf("error parsing FAREMODEL data: %w", err)
-					}
-					data.FareModel.FallbackFare = fallbackFare
-				}
-			case "VEHUNIT":
-				if data.VehUnit != nil {
-					unit, err := getVehicleUnit(values)
-					if err != nil {
-						return nil, fmt.Errorf("error parsing VEHUNIT data: %w", err)
-					}
-					data.VehUnit.Units = append(data.VehUnit.Units, unit)
-				}
-			case "VEHCOMB":
-				if data.VehComb != nil {
-					comb, err := getVehicleCombination(values)
-					if err != nil {
-						return nil, fmt.Errorf("error parsing VEHCOMB data: %w", err)
-					}
-					data.VehComb.Combinations = append(data.VehComb.Combinations, comb)
-				}
-			case "VEHUNITTOVEHCOMB":
-				if data.VehUnitToVehComb != nil {
-					mapping, err := getVehUnitToVehCombMapping(values)
-					if err != nil {
-						return nil, fmt.Errorf("error parsing VEHUNITTOVEHCOMB data: %w", err)
-					}
-					data.VehUnitToVehComb.Mappings = append(data.VehUnitToVehComb.Mappings, mapping)
-				}
-			case "DIRECTION":
-				if data.Direction != nil {
-					direction, err := getDirection(values)
-					if err != nil {
-						return nil, fmt.Errorf("error parsing DIRECTION data: %w", err)
-					}
-					data.Direction.Directions = append(data.Direction.Directions, direction)
-				}
-			case "POINT":
-				if data.Point != nil {
-					point, err := getPoint(values)
-					if err != nil {
-						return nil, fmt.Errorf("error parsing POINT data: %w", err)
-					}
-					data.Point.Points = append(data.Point.Points, point)
-				}
-			case "EDGE":
-				if data.Edge != nil {
-					edge, err := getEdge(values)
-					if err != nil {
-						return nil, fmt.Errorf("error parsing EDGE data: %w", err)
-					}
-					data.Edge.Edges = append(data.Edge.Edges, edge)
-				}
-			case "EDGEITEM":
-				if data.EdgeItem != nil {
-					item, err := getEdgeItem(values)
-					if err != nil {
-						return nil, fmt.Errorf("error parsing EDGEITEM data: %w", err)
-					}
-					data.EdgeItem.Items = append(data.EdgeItem.Items, item)
-				}
-			case "FACE":
-				if data.Face != nil {
-					face, err := getFace(values)
-					if err != nil {
-						return nil, fmt.Errorf("error parsing FACE data: %w", err)
-					}
-					data.Face.Faces = append(data.Face.Faces, face)
-				}
-			case "FACEITEM":
-				if data.FaceItem != nil {
-					item, err := getFaceItem(values)
-					if err != nil {
-						return nil, fmt.Errorf("error parsing FACEITEM data: %w", err)
-					}
-					data.FaceItem.Items = append(data.FaceItem.Items, item)
-				}
-			case "SURFACE", "SURFACE:ID": // Handle both formats
-				if data.Surface != nil {
-					surface, err := getSurface(values)
-					if err != nil {
-						return nil, fmt.Errorf("error parsing SURFACE data: %w", err)
-					}
-					data.Surface.Surfaces = append(data.Surface.Surfaces, surface)
-				}
-			case "SURFACEITEM":
-				if data.SurfaceItem != nil {
-					item, err := getSurfaceItem(values)
-					if err != nil {
-						return nil, fmt.Errorf("error parsing SURFACEITEM data: %w", err)
-					}
-					data.SurfaceItem.Items = append(data.SurfaceItem.Items, item)
-				}
-			case "NODE":
-				if data.Node != nil {
-					node, err := getNode(values)
-					if err != nil {
-						return nil, fmt.Errorf("error parsing NODE data: %w", err)
-					}
-					data.Node.Nodes = append(data.Node.Nodes, node)
-				}
-			case "ZONE":
-				if data.Zone != nil {
-					zone, err := getZone(values)
-					if err != nil {
-						return nil, fmt.Errorf("error parsing ZONE data: %w", err)
-					}
-					data.Zone.Zones = append(data.Zone.Zones, zone)
-				}
-			case "LINKTYPE":
-				if data.LinkType != nil {
-					// FIXED: Call Headers() method to get the string slice
-					linkType, err := getLinkType(values, data.LinkType.Headers())
-					if err != nil {
-						return nil, fmt.Errorf("error parsing LINKTYPE data: %w", err)
-					}
-					data.LinkType.LinkTypes = append(data.LinkType.LinkTypes, linkType)
-				}
-			case "LINK":
-				if data.Link != nil {
-					link, err := getLink(values, data.Link.Headers())
-					if err != nil {
-						return nil, fmt.Errorf("error parsing LINK data: %w", err)
-					}
-					data.Link.Links = append(data.Link.Links, link)
-				}
-			case "LINKPOLY":
-				if data.LinkPoly != nil {
-					point, err := getLinkPolyPoint(values)
-					if err != nil {
-						return nil, fmt.Errorf("error parsing LINKPOLY data: %w", err)
-					}
-					data.LinkPoly.Points = append(data.LinkPoly.Points, point)
-				}
-			case "TURN":
-				if data.Turn != nil {
-					turn, err := getTurn(values)
-					if err != nil {
-						return nil, fmt.Errorf("error parsing TURN data: %w", err)
-					}
-					data.Turn.Turns = append(data.Turn.Turns, turn)
-				}
-			case "CONNECTOR":
-				if data.Connector != nil {
-					connector, err := getConnector(values, data.Connector.Headers())
-					if err != nil {
-						return nil, fmt.Errorf("error parsing CONNECTOR data: %w", err)
-					}
-					data.Connector.Connectors = append(data.Connector.Connectors, connector)
-				}
-			// Skip these public transit and specialized sections in one case
-			case "STOP", "STOPAREA", "STOPPOINT", "LINE", "LINEROUTE", "LINEROUTEITEM",
-				"TIMEPROFILE", "TIMEPROFILEITEM", "VEHJOURNEY", "VEHJOURNEYSECTION",
-				"TRANSFERWALKTIMESTOPAREA", "BLOCKVERSION", "POIOFCAT_32", "POIOFCAT_33", "POIOFCAT_34", "LEG", "LANE", "LANETURN", "CROSSWALK":
-			default:
-				return nil, fmt.Errorf("unsupported section file parsing: %s", currentSection.name)
+	if tokenizer.pending() {
+		return &ParseError{Line: rowLineNo, Section: currentSection.name, Err: errors.New("unterminated quoted value")}
+	}
+	return nil
+}
+
+// isTypedSection reports whether the section is parsed into typed records
+func isTypedSection(name string) bool {
+	switch name {
+	case "VERSION", "INFO", "POICATEGORY", "USERATTDEF", "CALENDARPERIOD", "VALIDDAYS", "NETWORK", "TSYS", "MODE",
+		"DEMANDSEGMENT", "BLOCKITEMTYPE", "FAREMODEL", "VEHUNIT", "VEHCOMB", "VEHUNITTOVEHCOMB", "DIRECTION", "POINT",
+		"EDGE", "EDGEITEM", "FACE", "FACEITEM", "SURFACE", "SURFACEITEM", "NODE", "ZONE", "LINKTYPE", "LINK", "LINKPOLY",
+		"TURN", "CONNECTOR", "STOP", "STOPAREA", "STOPPOINT", "LINE", "LINEROUTE", "LINEROUTEITEM", "TIMEPROFILE",
+		"TIMEPROFILEITEM", "VEHJOURNEY", "VEHJOURNEYSECTION", "TRANSFERWALKTIMESTOPAREA", "BLOCKVERSION", "BLOCK",
+		"BLOCKITEM", "LEG", "LANE", "LANETURN", "CROSSWALK", "SIGNALCONTROL", "SIGNALCONTROLTONODE", "SIGNALGROUP",
+		"SIGNALGROUPTOTURN", "SIGNALGROUPTOLANETURN", "STAGE", "SIGNALGROUPTOSTAGE", "MAINNODE", "MAINTURN", "TERRITORY",
+		"SCREENLINE", "SCREENLINEPOLY", "COUNTLOCATION", "DETECTOR":
+		return true
+	}
+	_, isPOI := poiCategoryNo(name)
+	return isPOI
+}
+
+// addSection creates the specialized section for the given section header
+func (data *PTVData) addSection(section *BaseSection) {
+	switch section.name {
+	case "VERSION":
+		data.Version = &VersionSection{BaseSection: *section}
+	case "INFO":
+		data.Info = &InfoSection{BaseSection: *section}
+	case "POICATEGORY":
+		data.POICategory = &POICategorySection{BaseSection: *section}
+	case "USERATTDEF":
+		data.UserAttDef = &UserAttDefSection{BaseSection: *section}
+	case "CALENDARPERIOD":
+		data.CalendarPeriod = &CalendarPeriodSection{BaseSection: *section}
+	case "VALIDDAYS":
+		data.ValidDays = &ValidDaysSection{BaseSection: *section}
+	case "NETWORK":
+		data.Network = &NetworkSection{BaseSection: *section}
+	case "TSYS":
+		data.TSys = &TSysSection{BaseSection: *section}
+	case "MODE":
+		data.Mode = &ModeSection{BaseSection: *section}
+	case "DEMANDSEGMENT":
+		data.DemandSegment = &DemandSegmentSection{BaseSection: *section}
+	case "BLOCKITEMTYPE":
+		data.BlockItemType = &BlockItemTypeSection{BaseSection: *section}
+	case "FAREMODEL":
+		data.FareModel = &FareModelSection{BaseSection: *section}
+	case "VEHUNIT":
+		data.VehUnit = &VehUnitSection{BaseSection: *section}
+	case "VEHCOMB":
+		data.VehComb = &VehCombSection{BaseSection: *section}
+	case "VEHUNITTOVEHCOMB":
+		data.VehUnitToVehComb = &VehUnitToVehCombSection{BaseSection: *section}
+	case "DIRECTION":
+		data.Direction = &DirectionSection{BaseSection: *section}
+	case "POINT":
+		data.Point = &PointSection{BaseSection: *section}
+	case "EDGE":
+		data.Edge = &EdgeSection{BaseSection: *section}
+	case "EDGEITEM":
+		data.EdgeItem = &EdgeItemSection{BaseSection: *section}
+	case "FACE":
+		data.Face = &FaceSection{BaseSection: *section}
+	case "FACEITEM":
+		data.FaceItem = &FaceItemSection{BaseSection: *section}
+	case "SURFACE":
+		data.Surface = &SurfaceSection{BaseSection: *section}
+	case "SURFACEITEM":
+		data.SurfaceItem = &SurfaceItemSection{BaseSection: *section}
+	case "NODE":
+		data.Node = &NodeSection{BaseSection: *section}
+	case "ZONE":
+		data.Zone = &ZoneSection{BaseSection: *section}
+	case "LINKTYPE":
+		data.LinkType = &LinkTypeSection{BaseSection: *section}
+	case "LINK":
+		data.Link = &LinkSection{BaseSection: *section}
+	case "LINKPOLY":
+		data.LinkPoly = &LinkPolySection{BaseSection: *section}
+	case "TURN":
+		data.Turn = &TurnSection{BaseSection: *section}
+	case "CONNECTOR":
+		data.Connector = &ConnectorSection{BaseSection: *section}
+	case "STOP":
+		data.Stop = &StopSection{BaseSection: *section}
+	case "STOPAREA":
+		data.StopArea = &StopAreaSection{BaseSection: *section}
+	case "STOPPOINT":
+		data.StopPoint = &StopPointSection{BaseSection: *section}
+	case "LINE":
+		data.Line = &LineSection{BaseSection: *section}
+	case "LINEROUTE":
+		data.LineRoute = &LineRouteSection{BaseSection: *section}
+	case "LINEROUTEITEM":
+		data.LineRouteItem = &LineRouteItemSection{BaseSection: *section}
+	case "TIMEPROFILE":
+		data.TimeProfile = &TimeProfileSection{BaseSection: *section}
+	case "TIMEPROFILEITEM":
+		data.TimeProfileItem = &TimeProfileItemSection{BaseSection: *section}
+	case "VEHJOURNEY":
+		data.VehJourney = &VehJourneySection{BaseSection: *section}
+	case "VEHJOURNEYSECTION":
+		data.VehJourneySection = &VehJourneySectionSection{BaseSection: *section}
+	case "TRANSFERWALKTIMESTOPAREA":
+		data.TransferWalkTimeStopArea = &TransferWalkTimeStopAreaSection{BaseSection: *section}
+	case "BLOCKVERSION":
+		data.BlockVersion = &BlockVersionSection{BaseSection: *section}
+	case "BLOCK":
+		data.Block = &BlockSection{BaseSection: *section}
+	case "BLOCKITEM":
+		data.BlockItem = &BlockItemSection{BaseSection: *section}
+	case "LEG":
+		data.Leg = &LegSection{BaseSection: *section}
+	case "LANE":
+		data.Lane = &LaneSection{BaseSection: *section}
+	case "LANETURN":
+		data.LaneTurn = &LaneTurnSection{BaseSection: *section}
+	case "CROSSWALK":
+		data.Crosswalk = &CrosswalkSection{BaseSection: *section}
+	case "SIGNALCONTROL":
+		data.SignalControl = &SignalControlSection{BaseSection: *section}
+	case "SIGNALCONTROLTONODE":
+		data.SignalControlToNode = &SignalControlToNodeSection{BaseSection: *section}
+	case "SIGNALGROUP":
+		data.SignalGroup = &SignalGroupSection{BaseSection: *section}
+	case "SIGNALGROUPTOTURN":
+		data.SignalGroupToTurn = &SignalGroupToTurnSection{BaseSection: *section}
+	case "SIGNALGROUPTOLANETURN":
+		data.SignalGroupToLaneTurn = &SignalGroupToLaneTurnSection{BaseSection: *section}
+	case "STAGE":
+		data.Stage = &StageSection{BaseSection: *section}
+	case "SIGNALGROUPTOSTAGE":
+		data.SignalGroupToStage = &SignalGroupToStageSection{BaseSection: *section}
+	case "MAINNODE":
+		data.MainNode = &MainNodeSection{BaseSection: *section}
+	case "MAINTURN":
+		data.MainTurn = &MainTurnSection{BaseSection: *section}
+	case "TERRITORY":
+		data.Territory = &TerritorySection{BaseSection: *section}
+	case "SCREENLINE":
+		data.Screenline = &ScreenlineSection{BaseSection: *section}
+	case "SCREENLINEPOLY":
+		data.ScreenlinePoly = &ScreenlinePolySection{BaseSection: *section}
+	case "COUNTLOCATION":
+		data.CountLocation = &CountLocationSection{BaseSection: *section}
+	case "DETECTOR":
+		data.Detector = &DetectorSection{BaseSection: *section}
+	default:
+		if catNo, ok := poiCategoryNo(section.name); ok {
+			if data.POIs == nil {
+				data.POIs = make(map[int]*POISection)
 			}
+			data.POIs[catNo] = &POISection{BaseSection: *section, CategoryNo: catNo}
 		}
 	}
+}
 
-	if err := scanner.Err(); err != nil {
-		return nil, fmt.Errorf("error reading PTV file: %w", err)
+// addRecord stores a record produced by decodeRow in its specialized section
+func (data *PTVData) addRecord(record any) {
+	switch record := record.(type) {
+	case VersionSection:
+		data.Version.Version = record.Version
+		data.Version.FileType = record.FileType
+		data.Version.Language = record.Language
+		data.Version.Unit = record.Unit
+	case InfoLine:
+		data.Info.Lines = append(data.Info.Lines, record)
+	case POICategory:
+		data.POICategory.Categories = append(data.POICategory.Categories, record)
+	case UserAttDef:
+		data.UserAttDef.Attributes = append(data.UserAttDef.Attributes, record)
+	case CalendarPeriod:
+		data.CalendarPeriod.Periods = append(data.CalendarPeriod.Periods, record)
+	case ValidDay:
+		data.ValidDays.Days = append(data.ValidDays.Days, record)
+	case NetworkData:
+		data.Network.Network = record
+	case TransportSystem:
+		data.TSys.Systems = append(data.TSys.Systems, record)
+	case Mode:
+		data.Mode.Modes = append(data.Mode.Modes, record)
+	case DemandSegment:
+		data.DemandSegment.Segments = append(data.DemandSegment.Segments, record)
+	case BlockItemType:
+		data.BlockItemType.Types = append(data.BlockItemType.Types, record)
+	case FareModelSection:
+		data.FareModel.FallbackFare = record.FallbackFare
+	case VehicleUnit:
+		data.VehUnit.Units = append(data.VehUnit.Units, record)
+	case VehicleCombination:
+		data.VehComb.Combinations = append(data.VehComb.Combinations, record)
+	case VehUnitToVehCombMapping:
+		data.VehUnitToVehComb.Mappings = append(data.VehUnitToVehComb.Mappings, record)
+	case Direction:
+		data.Direction.Directions = append(data.Direction.Directions, record)
+	case Point:
+		data.Point.Points = append(data.Point.Points, record)
+	case Edge:
+		data.Edge.Edges = append(data.Edge.Edges, record)
+	case EdgeItem:
+		data.EdgeItem.Items = append(data.EdgeItem.Items, record)
+	case Face:
+		data.Face.Faces = append(data.Face.Faces, record)
+	case FaceItem:
+		data.FaceItem.Items = append(data.FaceItem.Items, record)
+	case Surface:
+		data.Surface.Surfaces = append(data.Surface.Surfaces, record)
+	case SurfaceItem:
+		data.SurfaceItem.Items = append(data.SurfaceItem.Items, record)
+	case Node:
+		data.Node.Nodes = append(data.Node.Nodes, record)
+	case Zone:
+		data.Zone.Zones = append(data.Zone.Zones, record)
+	case LinkType:
+		data.LinkType.LinkTypes = append(data.LinkType.LinkTypes, record)
+	case Link:
+		data.Link.Links = append(data.Link.Links, record)
+	case LinkPolyPoint:
+		data.LinkPoly.Points = append(data.LinkPoly.Points, record)
+	case Turn:
+		data.Turn.Turns = append(data.Turn.Turns, record)
+	case Connector:
+		data.Connector.Connectors = append(data.Connector.Connectors, record)
+	case Stop:
+		data.Stop.Stops = append(data.Stop.Stops, record)
+	case StopArea:
+		data.StopArea.StopAreas = append(data.StopArea.StopAreas, record)
+	case StopPoint:
+		data.StopPoint.StopPoints = append(data.StopPoint.StopPoints, record)
+	case Line:
+		data.Line.Lines = append(data.Line.Lines, record)
+	case LineRoute:
+		data.LineRoute.LineRoutes = append(data.LineRoute.LineRoutes, record)
+	case LineRouteItem:
+		data.LineRouteItem.Items = append(data.LineRouteItem.Items, record)
+	case TimeProfile:
+		data.TimeProfile.TimeProfiles = append(data.TimeProfile.TimeProfiles, record)
+	case TimeProfileItem:
+		data.TimeProfileItem.Items = append(data.TimeProfileItem.Items, record)
+	case VehicleJourney:
+		data.VehJourney.Journeys = append(data.VehJourney.Journeys, record)
+	case VehicleJourneySection:
+		data.VehJourneySection.Sections = append(data.VehJourneySection.Sections, record)
+	case TransferWalkTime:
+		data.TransferWalkTimeStopArea.Transfers = append(data.TransferWalkTimeStopArea.Transfers, record)
+	case POI:
+		data.POIs[record.CatNo].POIs = append(data.POIs[record.CatNo].POIs, record)
+	case BlockVersion:
+		data.BlockVersion.Versions = append(data.BlockVersion.Versions, record)
+	case Block:
+		data.Block.Blocks = append(data.Block.Blocks, record)
+	case BlockItem:
+		data.BlockItem.Items = append(data.BlockItem.Items, record)
+	case Leg:
+		data.Leg.Legs = append(data.Leg.Legs, record)
+	case Lane:
+		data.Lane.Lanes = append(data.Lane.Lanes, record)
+	case LaneTurn:
+		data.LaneTurn.LaneTurns = append(data.LaneTurn.LaneTurns, record)
+	case Crosswalk:
+		data.Crosswalk.Crosswalks = append(data.Crosswalk.Crosswalks, record)
+	case SignalControl:
+		data.SignalControl.SignalControls = append(data.SignalControl.SignalControls, record)
+	case SignalControlToNode:
+		data.SignalControlToNode.Items = append(data.SignalControlToNode.Items, record)
+	case SignalGroup:
+		data.SignalGroup.SignalGroups = append(data.SignalGroup.SignalGroups, record)
+	case SignalGroupToTurn:
+		data.SignalGroupToTurn.Items = append(data.SignalGroupToTurn.Items, record)
+	case SignalGroupToLaneTurn:
+		data.SignalGroupToLaneTurn.Items = append(data.SignalGroupToLaneTurn.Items, record)
+	case Stage:
+		data.Stage.Stages = append(data.Stage.Stages, record)
+	case SignalGroupToStage:
+		data.SignalGroupToStage.Items = append(data.SignalGroupToStage.Items, record)
+	case MainNode:
+		data.MainNode.MainNodes = append(data.MainNode.MainNodes, record)
+	case MainTurn:
+		data.MainTurn.MainTurns = append(data.MainTurn.MainTurns, record)
+	case Territory:
+		data.Territory.Territories = append(data.Territory.Territories, record)
+	case Screenline:
+		data.Screenline.Screenlines = append(data.Screenline.Screenlines, record)
+	case ScreenlinePoint:
+		data.ScreenlinePoly.Points = append(data.ScreenlinePoly.Points, record)
+	case CountLocation:
+		data.CountLocation.CountLocations = append(data.CountLocation.CountLocations, record)
+	case Detector:
+		data.Detector.Detectors = append(data.Detector.Detectors, record)
 	}
+}
 
-	return data, nil
+// decodeRow converts a data row of the given section to its typed record.
+// Columns are looked up by the section header, so any subset and order of columns is accepted.
+// It returns nil for sections without typed support
+func decodeRow(section *BaseSection, values []string) (any, error) {
+	var record any
+	var err error
+	switch section.name {
+	case "VERSION":
+		version := VersionSection{BaseSection: BaseSection{name: section.name, headers: section.headers}}
+		version.Version, version.FileType, version.Language, version.Unit, err = getVersion(values, section.headers)
+		record = version
+	case "INFO":
+		record, err = getInfoLine(values, section.headers)
+	case "POICATEGORY":
+		record, err = getPoiCategory(values, section.headers)
+	case "USERATTDEF":
+		record, err = getUserAttDef(values, section.headers)
+	case "CALENDARPERIOD":
+		record, err = getCalendarPeriod(values, section.headers)
+	case "VALIDDAYS":
+		record, err = getValidDay(values, section.headers)
+	case "NETWORK":
+		record, err = getNetwork(values, section.headers)
+	case "TSYS":
+		record, err = getTransportSystem(values, section.headers)
+	case "MODE":
+		record, err = getMode(values, section.headers)
+	case "DEMANDSEGMENT":
+		record, err = getDemandSegment(values, section.headers)
+	case "BLOCKITEMTYPE":
+		record, err = getBlockItemType(values, section.headers)
+	case "FAREMODEL":
+		fareModel := FareModelSection{BaseSection: BaseSection{name: section.name, headers: section.headers}}
+		fareModel.FallbackFare, err = getFallbackFare(values, section.headers)
+		record = fareModel
+	case "VEHUNIT":
+		record, err = getVehicleUnit(values, section.headers)
+	case "VEHCOMB":
+		record, err = getVehicleCombination(values, section.headers)
+	case "VEHUNITTOVEHCOMB":
+		record, err = getVehUnitToVehCombMapping(values, section.headers)
+	case "DIRECTION":
+		record, err = getDirection(values, section.headers)
+	case "POINT":
+		record, err = getPoint(values, section.headers)
+	case "EDGE":
+		record, err = getEdge(values, section.headers)
+	case "EDGEITEM":
+		record, err = getEdgeItem(values, section.headers)
+	case "FACE":
+		record, err = getFace(values, section.headers)
+	case "FACEITEM":
+		record, err = getFaceItem(values, section.headers)
+	case "SURFACE":
+		record, err = getSurface(values, section.headers)
+	case "SURFACEITEM":
+		record, err = getSurfaceItem(values, section.headers)
+	case "NODE":
+		record, err = getNode(values, section.headers)
+	case "ZONE":
+		record, err = getZone(values, section.headers)
+	case "LINKTYPE":
+		record, err = getLinkType(values, section.headers)
+	case "LINK":
+		record, err = getLink(values, section.headers)
+	case "LINKPOLY":
+		record, err = getLinkPolyPoint(values, section.headers)
+	case "TURN":
+		record, err = getTurn(values, section.headers)
+	case "CONNECTOR":
+		record, err = getConnector(values, section.headers)
+	case "STOP":
+		record, err = getStop(values, section.headers)
+	case "STOPAREA":
+		record, err = getStopArea(values, section.headers)
+	case "STOPPOINT":
+		record, err = getStopPoint(values, section.headers)
+	case "LINE":
+		record, err = getLine(values, section.headers)
+	case "LINEROUTE":
+		record, err = getLineRoute(values, section.headers)
+	case "LINEROUTEITEM":
+		record, err = getLineRouteItem(values, section.headers)
+	case "TIMEPROFILE":
+		record, err = getTimeProfile(values, section.headers)
+	case "TIMEPROFILEITEM":
+		record, err = getTimeProfileItem(values, section.headers)
+	case "VEHJOURNEY":
+		record, err = getVehicleJourney(values, section.headers)
+	case "VEHJOURNEYSECTION":
+		record, err = getVehicleJourneySection(values, section.headers)
+	case "TRANSFERWALKTIMESTOPAREA":
+		record, err = getTransferWalkTime(values, section.headers)
+	case "BLOCKVERSION":
+		record, err = getBlockVersion(values, section.headers)
+	case "BLOCK":
+		record, err = getBlock(values, section.headers)
+	case "BLOCKITEM":
+		record, err = getBlockItem(values, section.headers)
+	case "LEG":
+		record, err = getLeg(values, section.headers)
+	case "LANE":
+		record, err = getLane(values, section.headers)
+	case "LANETURN":
+		record, err = getLaneTurn(values, section.headers)
+	case "CROSSWALK":
+		record, err = getCrosswalk(values, section.headers)
+	case "SIGNALCONTROL":
+		record, err = getSignalControl(values, section.headers)
+	case "SIGNALCONTROLTONODE":
+		record, err = getSignalControlToNode(values, section.headers)
+	case "SIGNALGROUP":
+		record, err = getSignalGroup(values, section.headers)
+	case "SIGNALGROUPTOTURN":
+		record, err = getSignalGroupToTurn(values, section.headers)
+	case "SIGNALGROUPTOLANETURN":
+		record, err = getSignalGroupToLaneTurn(values, section.headers)
+	case "STAGE":
+		record, err = getStage(values, section.headers)
+	case "SIGNALGROUPTOSTAGE":
+		record, err = getSignalGroupToStage(values, section.headers)
+	case "MAINNODE":
+		record, err = getMainNode(values, section.headers)
+	case "MAINTURN":
+		record, err = getMainTurn(values, section.headers)
+	case "TERRITORY":
+		record, err = getTerritory(values, section.headers)
+	case "SCREENLINE":
+		record, err = getScreenline(values, section.headers)
+	case "SCREENLINEPOLY":
+		record, err = getScreenlinePoint(values, section.headers)
+	case "COUNTLOCATION":
+		record, err = getCountLocation(values, section.headers)
+	case "DETECTOR":
+		record, err = getDetector(values, section.headers)
+	default:
+		catNo, ok := poiCategoryNo(section.name)
+		if !ok {
+			return nil, nil
+		}
+		record, err = getPOI(values, section.headers, catNo)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error parsing %s data: %w", section.name, err)
+	}
+	return record, nil
 }
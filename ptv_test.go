@@ -0,0 +1,42 @@
+package ptvvisum
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestReadColumnSubset(t *testing.T) {
+	input := "$VISION\r\n" +
+		"$LINK:NO;FROMNODENO;TONODENO;LENGTH\r\n" +
+		"1;10;20;0.5km\r\n" +
+		"$TURN:FROMNODENO;VIANODENO;TONODENO\r\n" +
+		"10;20;30\r\n"
+	data, err := ReadPTVFromFile(strings.NewReader(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	link, found := data.Link.GetLinkByID(1)
+	if !found {
+		t.Fatal("link 1 not found")
+	}
+	if link.FromNodeNo != 10 || link.ToNodeNo != 20 || link.TypeNo != 0 || link.Length.Meters() != 500 {
+		t.Errorf("got link %+v", link)
+	}
+
+	if len(data.Turn.Turns) != 1 {
+		t.Fatalf("got %d turns, want 1", len(data.Turn.Turns))
+	}
+	if turn := data.Turn.Turns[0]; turn.FromNodeNo != 10 || turn.ViaNodeNo != 20 || turn.ToNodeNo != 30 || turn.TypeNo != 0 {
+		t.Errorf("got turn %+v", turn)
+	}
+}
+
+func TestReadMissingKeyColumn(t *testing.T) {
+	input := "$VISION\r\n" +
+		"$LINK:NO;TONODENO;LENGTH\r\n" +
+		"1;20;0.5km\r\n"
+	if _, err := ReadPTVFromFile(strings.NewReader(input)); err == nil {
+		t.Error("link without FROMNODENO was read, want error")
+	}
+}
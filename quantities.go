@@ -0,0 +1,126 @@
+package ptvvisum
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/lddl/go-ptv-visum/utils"
+)
+
+// Length is a distance as written in the network file, e.g. 0.081km or 264ft.
+// Values without unit are meters
+type Length struct {
+	Value float64
+	Unit  string // Unit as written in the file: km, m, cm, mm, mi, ft or empty
+}
+
+// Speed is a velocity as written in the network file, e.g. 70km/h or 45mph.
+// Values without unit are kilometres per hour
+type Speed struct {
+	Value float64
+	Unit  string // Unit as written in the file: km/h, km/min, m/min, mph, mi/h, m/s, ft/s or empty
+}
+
+// Duration is a time span as written in the network file, e.g. 72s, 1min 30s, 1h 5min or 1d 06:30:00.
+// Values without unit are seconds
+type Duration struct {
+	time.Duration
+	Unit string // Notation of the file: the largest unit (d, h, min or s), utils.ClockNotation or empty
+}
+
+// lengthUnits holds the number of meters in the supported length units
+var lengthUnits = map[string]float64{"": 1, "km": 1000, "m": 1, "cm": 0.01, "mm": 0.001, "mi": 1609.344, "ft": 0.3048}
+
+// speedUnits holds the number of km/h in the supported speed units
+var speedUnits = map[string]float64{
+	"": 1, "km/h": 1, "km/min": 60, "m/min": 0.06, "mph": 1.609344, "mi/h": 1.609344, "m/s": 3.6, "ft/s": 1.09728,
+}
+
+// Meters returns the length in meters
+func (l Length) Meters() float64 {
+	return l.Value * lengthUnits[strings.ToLower(l.Unit)]
+}
+
+// Kilometers returns the length in kilometers
+func (l Length) Kilometers() float64 {
+	return l.Meters() / 1000
+}
+
+// String formats the length in its original unit, the zero Length is empty
+func (l Length) String() string {
+	if l == (Length{}) {
+		return ""
+	}
+	return formatFloat(l.Value) + l.Unit
+}
+
+// KmH returns the speed in kilometers per hour
+func (s Speed) KmH() float64 {
+	return s.Value * speedUnits[strings.ToLower(s.Unit)]
+}
+
+// MetersPerSecond returns the speed in meters per second
+func (s Speed) MetersPerSecond() float64 {
+	return s.KmH() / 3.6
+}
+
+// String formats the speed in its original unit, the zero Speed is empty
+func (s Speed) String() string {
+	if s == (Speed{}) {
+		return ""
+	}
+	return formatFloat(s.Value) + s.Unit
+}
+
+// String formats the duration in its original notation, e.g. 1h 5min or 06:30:00, the zero Duration is empty
+func (d Duration) String() string {
+	if d == (Duration{}) {
+		return ""
+	}
+	return utils.FormatDuration(d.Duration, d.Unit)
+}
+
+// parseLength parses values like 0.081km
+func parseLength(value string) (Length, error) {
+	number, unit, err := parseQuantity(value)
+	if err != nil {
+		return Length{}, err
+	}
+	if _, ok := lengthUnits[strings.ToLower(unit)]; !ok {
+		return Length{}, fmt.Errorf("unknown length unit %q", unit)
+	}
+	return Length{Value: number, Unit: unit}, nil
+}
+
+// parseSpeed parses values like 70km/h
+func parseSpeed(value string) (Speed, error) {
+	number, unit, err := parseQuantity(value)
+	if err != nil {
+		return Speed{}, err
+	}
+	if _, ok := speedUnits[strings.ToLower(unit)]; !ok {
+		return Speed{}, fmt.Errorf("unknown speed unit %q", unit)
+	}
+	return Speed{Value: number, Unit: unit}, nil
+}
+
+// parseDuration parses values like 72s, 1min 30s, 1h 5min or 00:01:30
+func parseDuration(value string) (Duration, error) {
+	duration, notation, err := utils.ParseDurationNotation(value)
+	if err != nil {
+		return Duration{}, err
+	}
+	return Duration{Duration: duration, Unit: notation}, nil
+}
+
+// parseQuantity splits values like 0.081km into the number and the unit
+func parseQuantity(value string) (float64, string, error) {
+	numberPart, unit := splitNumber(strings.TrimSpace(value))
+	number, err := strconv.ParseFloat(strings.Replace(numberPart, ",", ".", 1), 64)
+	if err != nil {
+		return 0, "", err
+	}
+	return number, strings.TrimSpace(unit), nil
+}
@@ -0,0 +1,104 @@
+package ptvvisum
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestParseLength(t *testing.T) {
+	tests := []struct {
+		value     string
+		meters    float64
+		formatted string
+	}{
+		{"0.081km", 81, "0.081km"},
+		{"0,5km", 500, "0.5km"},
+		{"81m", 81, "81m"},
+		{"81", 81, "81"},
+		{"81 m", 81, "81m"},
+		{"150cm", 1.5, "150cm"},
+		{"1500mm", 1.5, "1500mm"},
+		{"1mi", 1609.344, "1mi"},
+		{"264ft", 80.4672, "264ft"},
+		{"2KM", 2000, "2KM"},
+	}
+	for _, test := range tests {
+		length, err := parseLength(test.value)
+		if err != nil {
+			t.Errorf("parseLength(%q): %v", test.value, err)
+			continue
+		}
+		if math.Abs(length.Meters()-test.meters) > 1e-9 {
+			t.Errorf("parseLength(%q).Meters() = %v, want %v", test.value, length.Meters(), test.meters)
+		}
+		if got := length.String(); got != test.formatted {
+			t.Errorf("parseLength(%q).String() = %q, want %q", test.value, got, test.formatted)
+		}
+	}
+
+	for _, value := range []string{"", "km", "1parsec", "1.2.3km"} {
+		if _, err := parseLength(value); err == nil {
+			t.Errorf("parseLength(%q) succeeded, want error", value)
+		}
+	}
+}
+
+func TestParseSpeed(t *testing.T) {
+	tests := []struct {
+		value string
+		kmh   float64
+	}{
+		{"70km/h", 70},
+		{"70", 70},
+		{"1km/min", 60},
+		{"1000m/min", 60},
+		{"10m/s", 36},
+		{"45mph", 72.42048},
+		{"45mi/h", 72.42048},
+		{"10ft/s", 10.9728},
+	}
+	for _, test := range tests {
+		speed, err := parseSpeed(test.value)
+		if err != nil {
+			t.Errorf("parseSpeed(%q): %v", test.value, err)
+			continue
+		}
+		if math.Abs(speed.KmH()-test.kmh) > 1e-9 {
+			t.Errorf("parseSpeed(%q).KmH() = %v, want %v", test.value, speed.KmH(), test.kmh)
+		}
+		if got := speed.String(); got != test.value {
+			t.Errorf("parseSpeed(%q).String() = %q", test.value, got)
+		}
+	}
+
+	if _, err := parseSpeed("70knots"); err == nil {
+		t.Error("parseSpeed(\"70knots\") succeeded, want error")
+	}
+}
+
+func TestParseDuration(t *testing.T) {
+	tests := []struct {
+		value    string
+		duration time.Duration
+	}{
+		{"72s", 72 * time.Second},
+		{"1min 30s", 90 * time.Second},
+		{"1h 5min", 65 * time.Minute},
+		{"00:01:30", 90 * time.Second},
+		{"1d 06:30:00", 30*time.Hour + 30*time.Minute},
+	}
+	for _, test := range tests {
+		duration, err := parseDuration(test.value)
+		if err != nil {
+			t.Errorf("parseDuration(%q): %v", test.value, err)
+			continue
+		}
+		if duration.Duration != test.duration {
+			t.Errorf("parseDuration(%q) = %v, want %v", test.value, duration.Duration, test.duration)
+		}
+		if got := duration.String(); got != test.value {
+			t.Errorf("parseDuration(%q).String() = %q", test.value, got)
+		}
+	}
+}
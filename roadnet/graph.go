@@ -39,6 +39,11 @@ type Graph struct {
 	Edges map[int]*Edge
 }
 
+// Sections returns the sections ExtractGraph uses, so only they can be loaded via ptvvisum.ReadOptions
+func Sections() []string {
+	return []string{"NODE", "LINK", "EDGEITEM", "LINKPOLY"}
+}
+
 // ExtractGraph prepares set of vertices and edges with geometry from the given PTV data
 func ExtractGraph(ptv *ptvvisum.PTVData) (Graph, error) {
 	vertices := make(map[int]*Vertex)
@@ -92,16 +97,8 @@ func ExtractGraph(ptv *ptvvisum.PTVData) (Graph, error) {
 			LanesNum: link.NumLanes,
 			Capacity: link.CapPRT,
 		}
-		length, err := utils.ParseLengthValue(link.Length)
-		if err != nil {
-			return Graph{}, fmt.Errorf("failed to parse length for link %d: %w", link.No, err)
-		}
-		edge.Length = length
-		freeFlowSpeed, err := utils.ParseSpeedValue(link.V0PRT)
-		if err != nil {
-			return Graph{}, fmt.Errorf("failed to parse free flow speed for link %d: %w", link.No, err)
-		}
-		edge.FreeFlowSpeed = freeFlowSpeed
+		edge.Length = link.Length.Meters()
+		edge.FreeFlowSpeed = link.V0PRT.KmH()
 		edges[edgeID] = edge
 		if _, ok := mapEdges[fromNodeID]; !ok {
 			mapEdges[fromNodeID] = make(map[int]*Edge)
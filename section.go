@@ -4,4 +4,5 @@ package ptvvisum
 type Section interface {
 	Name() string
 	Headers() []string
+	Rows() [][]string
 }
@@ -1,7 +1,6 @@
 package ptvvisum
 
 import (
-	"fmt"
 	"strconv"
 	"strings"
 )
@@ -26,68 +25,93 @@ type BlockItemType struct {
 	DischargingFunction          string  // Discharging function specification
 }
 
-// getBlockItemType extracts data from BLOCKITEMTYPE section row
-func getBlockItemType(values []string) (BlockItemType, error) {
-	if len(values) < 2 {
-		return BlockItemType{}, fmt.Errorf("invalid BLOCKITEMTYPE data: %v", values)
+// GetBlockItemTypeByID retrieves a block item type by its number
+func (s *BlockItemTypeSection) GetBlockItemTypeByID(no int) (BlockItemType, bool) {
+	for _, itemType := range s.Types {
+		if itemType.No == no {
+			return itemType, true
+		}
 	}
+	return BlockItemType{}, false
+}
 
+// getBlockItemType extracts data from BLOCKITEMTYPE section row
+func getBlockItemType(values []string, headers []string) (BlockItemType, error) {
 	// Parse the No field
-	no, err := strconv.Atoi(values[0])
+	no, err := strconv.Atoi(columnValue(values, headers, "NO", 0))
 	if err != nil {
-		return BlockItemType{}, fmt.Errorf("error parsing No: %w", err)
+		return BlockItemType{}, parseFieldError("NO", err)
 	}
 
 	// Initialize with required fields
 	itemType := BlockItemType{
 		No:   no,
-		Name: values[1],
+		Name: columnValue(values, headers, "NAME", 1),
 	}
 
 	// Handle optional fields
-	if len(values) > 2 {
-		itemType.DefLength = values[2]
-	}
+	itemType.DefLength = columnValue(values, headers, "DEFLENGTH", 2)
 
-	if len(values) > 3 && values[3] != "" {
-		shareBefore, err := strconv.ParseFloat(strings.Replace(values[3], ",", ".", -1), 64)
+	if value := columnValue(values, headers, "SHAREBEFORE", 3); value != "" {
+		shareBefore, err := strconv.ParseFloat(strings.Replace(value, ",", ".", -1), 64)
 		if err != nil {
-			return BlockItemType{}, fmt.Errorf("error parsing ShareBefore: %w", err)
+			return BlockItemType{}, parseFieldError("SHAREBEFORE", err)
 		}
 		itemType.ShareBefore = shareBefore
 	}
 
-	if len(values) > 4 && values[4] != "" {
-		weightShort, err := strconv.ParseFloat(strings.Replace(values[4], ",", ".", -1), 64)
+	if value := columnValue(values, headers, "WEIGHTFORLAYOVERSSHORT", 4); value != "" {
+		weightShort, err := strconv.ParseFloat(strings.Replace(value, ",", ".", -1), 64)
 		if err != nil {
-			return BlockItemType{}, fmt.Errorf("error parsing WeightForLayoversShort: %w", err)
+			return BlockItemType{}, parseFieldError("WEIGHTFORLAYOVERSSHORT", err)
 		}
 		itemType.WeightForLayoversShort = weightShort
 	}
 
-	if len(values) > 5 && values[5] != "" {
-		weightLong, err := strconv.ParseFloat(strings.Replace(values[5], ",", ".", -1), 64)
+	if value := columnValue(values, headers, "WEIGHTFORLAYOVERSLONG", 5); value != "" {
+		weightLong, err := strconv.ParseFloat(strings.Replace(value, ",", ".", -1), 64)
 		if err != nil {
-			return BlockItemType{}, fmt.Errorf("error parsing WeightForLayoversLong: %w", err)
+			return BlockItemType{}, parseFieldError("WEIGHTFORLAYOVERSLONG", err)
 		}
 		itemType.WeightForLayoversLong = weightLong
 	}
 
-	if len(values) > 6 {
-		itemType.LayoverThresholdShort = values[6]
-	}
+	itemType.LayoverThresholdShort = columnValue(values, headers, "LAYOVERTHRESHOLDSHORT", 6)
+	itemType.LayoverThresholdLong = columnValue(values, headers, "LAYOVERTHRESHOLDLONG", 7)
+	itemType.ChargingFunctionInitGradient = columnValue(values, headers, "CHARGINGFUNCTIONINITIALGRADIENT", 8)
+	itemType.DischargingFunction = columnValue(values, headers, "DISCHARGINGFUNCTION", 9)
 
-	if len(values) > 7 {
-		itemType.LayoverThresholdLong = values[7]
-	}
+	return itemType, nil
+}
 
-	if len(values) > 8 {
-		itemType.ChargingFunctionInitGradient = values[8]
-	}
+// blockItemTypeColumns are the columns written when the section has no headers
+var blockItemTypeColumns = []string{
+	"NO", "NAME", "DEFLENGTH", "SHAREBEFORE", "WEIGHTFORLAYOVERSSHORT", "WEIGHTFORLAYOVERSLONG",
+	"LAYOVERTHRESHOLDSHORT", "LAYOVERTHRESHOLDLONG", "CHARGINGFUNCTIONINITIALGRADIENT", "DISCHARGINGFUNCTION",
+}
 
-	if len(values) > 9 {
-		itemType.DischargingFunction = values[9]
+func (t BlockItemType) attribute(column string) (string, bool) {
+	switch column {
+	case "NO":
+		return formatInt(t.No), true
+	case "NAME":
+		return t.Name, true
+	case "DEFLENGTH":
+		return t.DefLength, true
+	case "SHAREBEFORE":
+		return formatFloat(t.ShareBefore), true
+	case "WEIGHTFORLAYOVERSSHORT":
+		return formatFloat(t.WeightForLayoversShort), true
+	case "WEIGHTFORLAYOVERSLONG":
+		return formatFloat(t.WeightForLayoversLong), true
+	case "LAYOVERTHRESHOLDSHORT":
+		return t.LayoverThresholdShort, true
+	case "LAYOVERTHRESHOLDLONG":
+		return t.LayoverThresholdLong, true
+	case "CHARGINGFUNCTIONINITIALGRADIENT":
+		return t.ChargingFunctionInitGradient, true
+	case "DISCHARGINGFUNCTION":
+		return t.DischargingFunction, true
 	}
-
-	return itemType, nil
+	return "", false
 }
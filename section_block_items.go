@@ -0,0 +1,220 @@
+package ptvvisum
+
+import (
+	"sort"
+	"strconv"
+	"time"
+)
+
+// BlockItemSection represents $BLOCKITEM section
+type BlockItemSection struct {
+	BaseSection
+	Items []BlockItem
+
+	byBlock index[BlockItem, BlockKey]
+}
+
+// BlockItem represents a vehicle journey, empty trip, layover or depot stay within a block
+type BlockItem struct {
+	BlockVersionID      int      // Block version the block belongs to
+	BlockID             int      // Block the item belongs to
+	Index               int      // Position of the item in the block
+	BlockItemTypeNo     int      // Block item type, e.g. 1 vehicle journey, 2 empty trip or 3 layover
+	StartDayIndex       int      // Day of the calendar period the item starts on
+	StartTime           Duration // Start time of day
+	EndDayIndex         int      // Day of the calendar period the item ends on
+	EndTime             Duration // End time of day
+	FromStopPointNo     int      // Stop point the item starts at, 0 if not set
+	ToStopPointNo       int      // Stop point the item ends at, 0 if not set
+	VehJourneyNo        int      // Vehicle journey served by the item, 0 for other item types
+	VehJourneySectionNo int      // Vehicle journey section served by the item, 0 for other item types
+}
+
+// BlockKey returns the key of the block the item belongs to
+func (item BlockItem) BlockKey() BlockKey {
+	return BlockKey{BlockVersionID: item.BlockVersionID, BlockID: item.BlockID}
+}
+
+// Start returns the start of the item from midnight of the first day of the calendar period
+func (item BlockItem) Start() time.Duration {
+	return dayOffset(item.StartDayIndex) + item.StartTime.Duration
+}
+
+// End returns the end of the item from midnight of the first day of the calendar period
+func (item BlockItem) End() time.Duration {
+	return dayOffset(item.EndDayIndex) + item.EndTime.Duration
+}
+
+// dayOffset returns the time from the first day of the calendar period to the day with the given 1-based index
+func dayOffset(dayIndex int) time.Duration {
+	if dayIndex <= 1 {
+		return 0
+	}
+	return time.Duration(dayIndex-1) * 24 * time.Hour
+}
+
+// GetItemsByBlock retrieves all items of a block in block order
+func (s *BlockItemSection) GetItemsByBlock(key BlockKey) []BlockItem {
+	result := s.byBlock.find(s.Items, key, BlockItem.BlockKey)
+
+	// Sort by index to ensure correct order
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Index < result[j].Index
+	})
+
+	return result
+}
+
+// GetItem retrieves the item of a block at the given index
+func (s *BlockItemSection) GetItem(key BlockKey, index int) (BlockItem, bool) {
+	for _, i := range s.byBlock.lookup(s.Items, key, BlockItem.BlockKey) {
+		if s.Items[i].Index == index {
+			return s.Items[i], true
+		}
+	}
+	return BlockItem{}, false
+}
+
+// GetBlockDuration returns the time from the start of the first to the end of the last item of a block
+func (s *BlockItemSection) GetBlockDuration(key BlockKey) time.Duration {
+	items := s.GetItemsByBlock(key)
+	if len(items) == 0 {
+		return 0
+	}
+	start, end := items[0].Start(), items[0].End()
+	for _, item := range items[1:] {
+		start = min(start, item.Start())
+		end = max(end, item.End())
+	}
+	return end - start
+}
+
+// GetBlockItemType retrieves the type of a block item
+func (s *BlockItemSection) GetBlockItemType(key BlockKey, index int, data *PTVData) (BlockItemType, bool) {
+	item, found := s.GetItem(key, index)
+	if !found || data.BlockItemType == nil {
+		return BlockItemType{}, false
+	}
+	return data.BlockItemType.GetBlockItemTypeByID(item.BlockItemTypeNo)
+}
+
+// GetVehicleJourney retrieves the vehicle journey served by a block item
+func (s *BlockItemSection) GetVehicleJourney(key BlockKey, index int, data *PTVData) (VehicleJourney, bool) {
+	item, found := s.GetItem(key, index)
+	if !found || item.VehJourneyNo == 0 || data.VehJourney == nil {
+		return VehicleJourney{}, false
+	}
+	return data.VehJourney.GetVehicleJourneyByID(item.VehJourneyNo)
+}
+
+// Reindex drops the lookup index after blocks of items have been changed in place, it is built again on next use
+func (s *BlockItemSection) Reindex() {
+	s.byBlock.reset()
+}
+
+// Count returns the number of block items in the section
+func (s *BlockItemSection) Count() int {
+	return len(s.Items)
+}
+
+// getBlockItem extracts data from BLOCKITEM section row
+func getBlockItem(values []string, headers []string) (BlockItem, error) {
+	var item BlockItem
+	var err error
+
+	// Parse BLOCKVERSIONID, BLOCKID and INDEX (required fields)
+	requiredFields := []struct {
+		index int
+		dest  *int
+		name  string
+	}{
+		{0, &item.BlockVersionID, "BLOCKVERSIONID"},
+		{1, &item.BlockID, "BLOCKID"},
+		{2, &item.Index, "INDEX"},
+	}
+	for _, field := range requiredFields {
+		value := columnValue(values, headers, field.name, field.index)
+		if value == "" {
+			return BlockItem{}, missingFieldError(field.name)
+		}
+		*field.dest, err = strconv.Atoi(value)
+		if err != nil {
+			return BlockItem{}, parseFieldError(field.name, err)
+		}
+	}
+
+	// Parse integer fields (optional)
+	intFields := []struct {
+		index int
+		dest  *int
+		name  string
+	}{
+		{3, &item.BlockItemTypeNo, "BLOCKITEMTYPENO"},
+		{4, &item.StartDayIndex, "STARTDAYINDEX"},
+		{6, &item.EndDayIndex, "ENDDAYINDEX"},
+		{8, &item.FromStopPointNo, "FROMSTOPPOINTNO"},
+		{9, &item.ToStopPointNo, "TOSTOPPOINTNO"},
+		{10, &item.VehJourneyNo, "VEHJOURNEYNO"},
+		{11, &item.VehJourneySectionNo, "VEHJOURNEYSECTIONNO"},
+	}
+	for _, field := range intFields {
+		if value := columnValue(values, headers, field.name, field.index); value != "" {
+			*field.dest, err = strconv.Atoi(value)
+			if err != nil {
+				return BlockItem{}, parseFieldError(field.name, err)
+			}
+		}
+	}
+
+	// Parse STARTTIME and ENDTIME (optional)
+	if value := columnValue(values, headers, "STARTTIME", 5); value != "" {
+		item.StartTime, err = parseDuration(value)
+		if err != nil {
+			return BlockItem{}, parseFieldError("STARTTIME", err)
+		}
+	}
+	if value := columnValue(values, headers, "ENDTIME", 7); value != "" {
+		item.EndTime, err = parseDuration(value)
+		if err != nil {
+			return BlockItem{}, parseFieldError("ENDTIME", err)
+		}
+	}
+
+	return item, nil
+}
+
+// blockItemColumns are the columns written when the section has no headers
+var blockItemColumns = []string{
+	"BLOCKVERSIONID", "BLOCKID", "INDEX", "BLOCKITEMTYPENO", "STARTDAYINDEX", "STARTTIME", "ENDDAYINDEX", "ENDTIME",
+	"FROMSTOPPOINTNO", "TOSTOPPOINTNO", "VEHJOURNEYNO", "VEHJOURNEYSECTIONNO",
+}
+
+func (item BlockItem) attribute(column string) (string, bool) {
+	switch column {
+	case "BLOCKVERSIONID":
+		return formatInt(item.BlockVersionID), true
+	case "BLOCKID":
+		return formatInt(item.BlockID), true
+	case "INDEX":
+		return formatInt(item.Index), true
+	case "BLOCKITEMTYPENO":
+		return formatInt(item.BlockItemTypeNo), true
+	case "STARTDAYINDEX":
+		return formatInt(item.StartDayIndex), true
+	case "STARTTIME":
+		return item.StartTime.String(), true
+	case "ENDDAYINDEX":
+		return formatInt(item.EndDayIndex), true
+	case "ENDTIME":
+		return item.EndTime.String(), true
+	case "FROMSTOPPOINTNO":
+		return formatOptionalInt(item.FromStopPointNo), true
+	case "TOSTOPPOINTNO":
+		return formatOptionalInt(item.ToStopPointNo), true
+	case "VEHJOURNEYNO":
+		return formatOptionalInt(item.VehJourneyNo), true
+	case "VEHJOURNEYSECTIONNO":
+		return formatOptionalInt(item.VehJourneySectionNo), true
+	}
+	return "", false
+}
@@ -0,0 +1,156 @@
+package ptvvisum
+
+import (
+	"strconv"
+	"time"
+)
+
+// BlockVersionSection represents $BLOCKVERSION section
+type BlockVersionSection struct {
+	BaseSection
+	Versions []BlockVersion
+
+	byID index[BlockVersion, int]
+}
+
+// BlockVersion represents a variant of the vehicle schedule, blocks belong to exactly one block version
+type BlockVersion struct {
+	ID                                   int    // Block version ID
+	Code                                 string // Block version code
+	Name                                 string // Block version name
+	StartDayIndex                        int    // First day of the calendar period covered
+	EndDayIndex                          int    // Last day of the calendar period covered
+	CreateEmptyTrips                     int    // Whether empty trips are created by blocking (0/1)
+	SystemRoutesUsage                    int    // How system routes are used for empty trips
+	UseActiveSystemRoutes                int    // Whether only active system routes are used (0/1)
+	RegardPreparationTimes               int    // Whether preparation times are regarded (0/1)
+	DisregardPrepTimesWithinJourney      int    // Whether preparation times within a vehicle journey are disregarded (0/1)
+	ShortTurningPermitted                int    // Whether short turning is permitted (0/1)
+	EmptyTripShortestPathSearchCriterion string // Criterion of the shortest path search for empty trips, e.g. TIMETSYS
+}
+
+// GetBlockVersionByID retrieves a block version by its ID
+func (s *BlockVersionSection) GetBlockVersionByID(id int) (BlockVersion, bool) {
+	return s.byID.findFirst(s.Versions, id, func(version BlockVersion) int { return version.ID })
+}
+
+// GetFleetSize returns the number of vehicles needed by a block version, one per block,
+// in total and per vehicle combination
+func (s *BlockVersionSection) GetFleetSize(id int, data *PTVData) (int, map[int]int) {
+	byVehComb := make(map[int]int)
+	if data.Block == nil {
+		return 0, byVehComb
+	}
+	blocks := data.Block.GetBlocksByBlockVersion(id)
+	for _, block := range blocks {
+		byVehComb[block.VehCombNo]++
+	}
+	return len(blocks), byVehComb
+}
+
+// GetVehicleHours returns the time the vehicles of a block version are on duty, from the start of the first
+// to the end of the last item of every block
+func (s *BlockVersionSection) GetVehicleHours(id int, data *PTVData) time.Duration {
+	if data.Block == nil || data.BlockItem == nil {
+		return 0
+	}
+	var total time.Duration
+	for _, block := range data.Block.GetBlocksByBlockVersion(id) {
+		total += data.BlockItem.GetBlockDuration(block.Key())
+	}
+	return total
+}
+
+// Reindex drops the lookup index after IDs of block versions have been changed in place, it is built again on next use
+func (s *BlockVersionSection) Reindex() {
+	s.byID.reset()
+}
+
+// Count returns the number of block versions in the section
+func (s *BlockVersionSection) Count() int {
+	return len(s.Versions)
+}
+
+// getBlockVersion extracts data from BLOCKVERSION section row.
+// Attribute references and REQUIREDUSERDEFINEDBLOCKITEMTYPES(n) are kept untyped
+func getBlockVersion(values []string, headers []string) (BlockVersion, error) {
+	var version BlockVersion
+	var err error
+
+	// Parse ID (required field)
+	value := columnValue(values, headers, "ID", 0)
+	if value == "" {
+		return BlockVersion{}, missingFieldError("ID")
+	}
+	version.ID, err = strconv.Atoi(value)
+	if err != nil {
+		return BlockVersion{}, parseFieldError("ID", err)
+	}
+
+	version.Code = columnValue(values, headers, "CODE", 1)
+	version.Name = columnValue(values, headers, "NAME", 2)
+
+	// Parse integer fields (optional)
+	intFields := []struct {
+		index int
+		dest  *int
+		name  string
+	}{
+		{3, &version.StartDayIndex, "STARTDAYINDEX"},
+		{4, &version.EndDayIndex, "ENDDAYINDEX"},
+		{5, &version.CreateEmptyTrips, "CREATEEMPTYTRIPS"},
+		{6, &version.SystemRoutesUsage, "SYSTEMROUTESUSAGE"},
+		{7, &version.UseActiveSystemRoutes, "USEACTIVESYSTEMROUTES"},
+		{8, &version.RegardPreparationTimes, "REGARDPREPARATIONTIMES"},
+		{9, &version.DisregardPrepTimesWithinJourney, "DISREGARDPREPTIMESWITHINJOURNEY"},
+		{10, &version.ShortTurningPermitted, "SHORTTURNINGPERMITTED"},
+	}
+	for _, field := range intFields {
+		if value := columnValue(values, headers, field.name, field.index); value != "" {
+			*field.dest, err = strconv.Atoi(value)
+			if err != nil {
+				return BlockVersion{}, parseFieldError(field.name, err)
+			}
+		}
+	}
+
+	version.EmptyTripShortestPathSearchCriterion = columnValue(values, headers, "EMPTYTRIPSHORTESTPATHSEARCHCRITERION", 11)
+
+	return version, nil
+}
+
+// blockVersionColumns are the columns written when the section has no headers
+var blockVersionColumns = []string{
+	"ID", "CODE", "NAME", "STARTDAYINDEX", "ENDDAYINDEX", "CREATEEMPTYTRIPS", "SYSTEMROUTESUSAGE", "USEACTIVESYSTEMROUTES",
+	"REGARDPREPARATIONTIMES", "DISREGARDPREPTIMESWITHINJOURNEY", "SHORTTURNINGPERMITTED", "EMPTYTRIPSHORTESTPATHSEARCHCRITERION",
+}
+
+func (version BlockVersion) attribute(column string) (string, bool) {
+	switch column {
+	case "ID":
+		return formatInt(version.ID), true
+	case "CODE":
+		return version.Code, true
+	case "NAME":
+		return version.Name, true
+	case "STARTDAYINDEX":
+		return formatInt(version.StartDayIndex), true
+	case "ENDDAYINDEX":
+		return formatInt(version.EndDayIndex), true
+	case "CREATEEMPTYTRIPS":
+		return formatInt(version.CreateEmptyTrips), true
+	case "SYSTEMROUTESUSAGE":
+		return formatInt(version.SystemRoutesUsage), true
+	case "USEACTIVESYSTEMROUTES":
+		return formatInt(version.UseActiveSystemRoutes), true
+	case "REGARDPREPARATIONTIMES":
+		return formatInt(version.RegardPreparationTimes), true
+	case "DISREGARDPREPTIMESWITHINJOURNEY":
+		return formatInt(version.DisregardPrepTimesWithinJourney), true
+	case "SHORTTURNINGPERMITTED":
+		return formatInt(version.ShortTurningPermitted), true
+	case "EMPTYTRIPSHORTESTPATHSEARCHCRITERION":
+		return version.EmptyTripShortestPathSearchCriterion, true
+	}
+	return "", false
+}
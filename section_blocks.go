@@ -0,0 +1,126 @@
+package ptvvisum
+
+import "strconv"
+
+// BlockSection represents $BLOCK section
+type BlockSection struct {
+	BaseSection
+	Blocks []Block
+
+	byKey          index[Block, BlockKey]
+	byBlockVersion index[Block, int]
+}
+
+// BlockKey identifies a block within its block version
+type BlockKey struct {
+	BlockVersionID int // Block version the block belongs to
+	BlockID        int // Block ID
+}
+
+// Block represents the daily duty of one vehicle, a sequence of block items
+type Block struct {
+	BlockVersionID int    // Block version the block belongs to
+	ID             int    // Block ID
+	Code           string // Block code
+	Name           string // Block name
+	VehCombNo      int    // Vehicle combination operating the block, 0 if not set
+}
+
+// Key returns the key of the block
+func (block Block) Key() BlockKey {
+	return BlockKey{BlockVersionID: block.BlockVersionID, BlockID: block.ID}
+}
+
+// GetBlock retrieves a block by its key
+func (s *BlockSection) GetBlock(key BlockKey) (Block, bool) {
+	return s.byKey.findFirst(s.Blocks, key, Block.Key)
+}
+
+// GetBlocksByBlockVersion retrieves all blocks of a block version
+func (s *BlockSection) GetBlocksByBlockVersion(blockVersionID int) []Block {
+	return s.byBlockVersion.find(s.Blocks, blockVersionID, func(block Block) int { return block.BlockVersionID })
+}
+
+// GetBlockVersion retrieves the block version a block belongs to
+func (s *BlockSection) GetBlockVersion(key BlockKey, data *PTVData) (BlockVersion, bool) {
+	if _, found := s.GetBlock(key); !found || data.BlockVersion == nil {
+		return BlockVersion{}, false
+	}
+	return data.BlockVersion.GetBlockVersionByID(key.BlockVersionID)
+}
+
+// GetVehicleCombination retrieves the vehicle combination operating a block
+func (s *BlockSection) GetVehicleCombination(key BlockKey, data *PTVData) (VehicleCombination, bool) {
+	block, found := s.GetBlock(key)
+	if !found || data.VehComb == nil {
+		return VehicleCombination{}, false
+	}
+	return data.VehComb.GetVehicleCombinationByID(block.VehCombNo)
+}
+
+// Reindex drops the lookup indexes after keys of blocks have been changed in place, they are built again on next use
+func (s *BlockSection) Reindex() {
+	s.byKey.reset()
+	s.byBlockVersion.reset()
+}
+
+// Count returns the number of blocks in the section
+func (s *BlockSection) Count() int {
+	return len(s.Blocks)
+}
+
+// getBlock extracts data from BLOCK section row
+func getBlock(values []string, headers []string) (Block, error) {
+	var block Block
+	var err error
+
+	// Parse BLOCKVERSIONID and ID (required fields)
+	value := columnValue(values, headers, "BLOCKVERSIONID", 0)
+	if value == "" {
+		return Block{}, missingFieldError("BLOCKVERSIONID")
+	}
+	block.BlockVersionID, err = strconv.Atoi(value)
+	if err != nil {
+		return Block{}, parseFieldError("BLOCKVERSIONID", err)
+	}
+	value = columnValue(values, headers, "ID", 1)
+	if value == "" {
+		return Block{}, missingFieldError("ID")
+	}
+	block.ID, err = strconv.Atoi(value)
+	if err != nil {
+		return Block{}, parseFieldError("ID", err)
+	}
+
+	block.Code = columnValue(values, headers, "CODE", 2)
+	block.Name = columnValue(values, headers, "NAME", 3)
+
+	// Parse VEHCOMBNO (optional)
+	if value := columnValue(values, headers, "VEHCOMBNO", 4); value != "" {
+		block.VehCombNo, err = strconv.Atoi(value)
+		if err != nil {
+			return Block{}, parseFieldError("VEHCOMBNO", err)
+		}
+	}
+
+	return block, nil
+}
+
+// blockColumns are the columns written when the section has no headers
+var blockColumns = []string{"BLOCKVERSIONID", "ID", "CODE", "NAME", "VEHCOMBNO"}
+
+func (block Block) attribute(column string) (string, bool) {
+	switch column {
+	case "BLOCKVERSIONID":
+		return formatInt(block.BlockVersionID), true
+	case "ID":
+		return formatInt(block.ID), true
+	case "CODE":
+		return block.Code, true
+	case "NAME":
+		return block.Name, true
+	case "VEHCOMBNO":
+		return formatOptionalInt(block.VehCombNo), true
+	}
+	return "", false
+}
@@ -21,53 +21,96 @@ type CalendarPeriod struct {
 	AnalysisTimeIntervalSetNo   int
 }
 
-// getCalendarPeriod extracts data from CALENDARPERIOD section row
-func getCalendarPeriod(values []string) (CalendarPeriod, error) {
-	if len(values) < 5 {
-		return CalendarPeriod{}, fmt.Errorf("invalid CALENDARPERIOD data: %v", values)
+// GetDayIndex returns the position of a calendar day in the day vectors of valid days.
+// Without a calendar every day is the single reference day, a weekly calendar starts on Monday.
+// Days outside ValidFrom and ValidUntil are not found for any calendar type
+func (s *CalendarPeriodSection) GetDayIndex(day time.Time) (int, bool) {
+	if len(s.Periods) == 0 {
+		return 0, false
+	}
+	period := s.Periods[0]
+	date := time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, time.UTC)
+	if date.Before(period.ValidFrom) || (!period.ValidUntil.IsZero() && date.After(period.ValidUntil)) {
+		return 0, false
+	}
+	switch period.Type {
+	case "NOCALENDAR":
+		return 0, true
+	case "WEEKLY":
+		return (int(day.Weekday()) + 6) % 7, true
 	}
+	return int(date.Sub(period.ValidFrom).Hours() / 24), true
+}
 
+// getCalendarPeriod extracts data from CALENDARPERIOD section row
+func getCalendarPeriod(values []string, headers []string) (CalendarPeriod, error) {
 	// Initialize with default values
 	period := CalendarPeriod{
-		Type: values[0],
+		Type: columnValue(values, headers, "TYPE", 0),
 	}
 
 	// Parse dates (format: DD.MM.YYYY)
-	if values[1] != "" {
-		validFrom, err := time.Parse("02.01.2006", values[1])
+	if value := columnValue(values, headers, "VALIDFROM", 1); value != "" {
+		validFrom, err := time.Parse("02.01.2006", value)
 		if err != nil {
-			return CalendarPeriod{}, fmt.Errorf("error parsing ValidFrom date: %w", err)
+			return CalendarPeriod{}, parseFieldError("VALIDFROM", err)
 		}
 		period.ValidFrom = validFrom
 	}
 
-	if values[2] != "" {
-		validUntil, err := time.Parse("02.01.2006", values[2])
+	if value := columnValue(values, headers, "VALIDUNTIL", 2); value != "" {
+		validUntil, err := time.Parse("02.01.2006", value)
 		if err != nil {
-			return CalendarPeriod{}, fmt.Errorf("error parsing ValidUntil date: %w", err)
+			return CalendarPeriod{}, parseFieldError("VALIDUNTIL", err)
 		}
 		period.ValidUntil = validUntil
 	}
 
 	// Parse integer values
-	if values[3] != "" {
-		if _, err := fmt.Sscanf(values[3], "%d", &period.AnalysisPeriodStartDayIndex); err != nil {
-			return CalendarPeriod{}, fmt.Errorf("error parsing AnalysisPeriodStartDayIndex: %w", err)
+	if value := columnValue(values, headers, "ANALYSISPERIODSTARTDAYINDEX", 3); value != "" {
+		if _, err := fmt.Sscanf(value, "%d", &period.AnalysisPeriodStartDayIndex); err != nil {
+			return CalendarPeriod{}, parseFieldError("ANALYSISPERIODSTARTDAYINDEX", err)
 		}
 	}
 
-	if values[4] != "" {
-		if _, err := fmt.Sscanf(values[4], "%d", &period.AnalysisPeriodEndDayIndex); err != nil {
-			return CalendarPeriod{}, fmt.Errorf("error parsing AnalysisPeriodEndDayIndex: %w", err)
+	if value := columnValue(values, headers, "ANALYSISPERIODENDDAYINDEX", 4); value != "" {
+		if _, err := fmt.Sscanf(value, "%d", &period.AnalysisPeriodEndDayIndex); err != nil {
+			return CalendarPeriod{}, parseFieldError("ANALYSISPERIODENDDAYINDEX", err)
 		}
 	}
 
 	// Optional field
-	if len(values) > 5 && values[5] != "" {
-		if _, err := fmt.Sscanf(values[5], "%d", &period.AnalysisTimeIntervalSetNo); err != nil {
-			return CalendarPeriod{}, fmt.Errorf("error parsing AnalysisTimeIntervalSetNo: %w", err)
+	if value := columnValue(values, headers, "ANALYSISTIMEINTERVALSETNO", 5); value != "" {
+		if _, err := fmt.Sscanf(value, "%d", &period.AnalysisTimeIntervalSetNo); err != nil {
+			return CalendarPeriod{}, parseFieldError("ANALYSISTIMEINTERVALSETNO", err)
 		}
 	}
 
 	return period, nil
 }
+
+// calendarPeriodColumns are the columns written when the section has no headers
+var calendarPeriodColumns = []string{
+	"TYPE", "VALIDFROM", "VALIDUNTIL", "ANALYSISPERIODSTARTDAYINDEX", "ANALYSISPERIODENDDAYINDEX", "ANALYSISTIMEINTERVALSETNO",
+}
+
+func (p CalendarPeriod) attribute(column string) (string, bool) {
+	switch column {
+	case "TYPE":
+		return p.Type, true
+	case "VALIDFROM":
+		return formatDate(p.ValidFrom), true
+	case "VALIDUNTIL":
+		return formatDate(p.ValidUntil), true
+	case "ANALYSISPERIODSTARTDAYINDEX":
+		return formatInt(p.AnalysisPeriodStartDayIndex), true
+	case "ANALYSISPERIODENDDAYINDEX":
+		return formatInt(p.AnalysisPeriodEndDayIndex), true
+	case "ANALYSISTIMEINTERVALSETNO":
+		if p.AnalysisTimeIntervalSetNo == 0 {
+			return "", true
+		}
+		return formatInt(p.AnalysisTimeIntervalSetNo), true
+	}
+	return "", false
+}
@@ -4,52 +4,41 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
-
-	"github.com/lddl/go-ptv-visum/utils"
 )
 
 // ConnectorSection represents $CONNECTOR section
 type ConnectorSection struct {
 	BaseSection
 	Connectors []Connector
+
+	byZone index[Connector, int]
+	byNode index[Connector, int]
 }
 
 // Connector represents a single connector in the transportation network
 type Connector struct {
-	ZoneNo       int               // Zone ID
-	NodeNo       int               // Node ID
-	Direction    string            // Direction (O=Origin, D=Destination)
-	TypeNo       int               // Connector type ID
-	TSysSet      string            // Transport systems allowed
-	Length       string            // Length with unit (e.g., "0.903km")
-	T0TSys       map[string]string // Travel time by transport system
-	WeightPRT    float64           // Weight for private transport
-	WeightPUT    float64           // Weight for public transport
-	AddVal       [3]int            // Additional values 1-3
-	LabelPosRelX float64           // X coordinate for label
-	LabelPosRelY float64           // Y coordinate for label
+	ZoneNo       int                 // Zone ID
+	NodeNo       int                 // Node ID
+	Direction    string              // Direction (O=Origin, D=Destination)
+	TypeNo       int                 // Connector type ID
+	TSysSet      string              // Transport systems allowed
+	Length       Length              // Length (e.g., 0.903km)
+	T0TSys       map[string]Duration // Travel time by transport system
+	WeightPRT    float64             // Weight for private transport
+	WeightPUT    float64             // Weight for public transport
+	AddVal       [3]int              // Additional values 1-3
+	LabelPosRelX float64             // X coordinate for label
+	LabelPosRelY float64             // Y coordinate for label
 }
 
 // GetConnectorsByZone retrieves all connectors for a specific zone
 func (s *ConnectorSection) GetConnectorsByZone(zoneNo int) []Connector {
-	var result []Connector
-	for _, connector := range s.Connectors {
-		if connector.ZoneNo == zoneNo {
-			result = append(result, connector)
-		}
-	}
-	return result
+	return s.byZone.find(s.Connectors, zoneNo, connectorZone)
 }
 
 // GetConnectorsByNode retrieves all connectors for a specific node
 func (s *ConnectorSection) GetConnectorsByNode(nodeNo int) []Connector {
-	var result []Connector
-	for _, connector := range s.Connectors {
-		if connector.NodeNo == nodeNo {
-			result = append(result, connector)
-		}
-	}
-	return result
+	return s.byNode.find(s.Connectors, nodeNo, func(connector Connector) int { return connector.NodeNo })
 }
 
 // GetOriginConnectors retrieves all origin connectors
@@ -98,8 +87,9 @@ func (s *ConnectorSection) GetConnectorsByTransportSystem(tsys string) []Connect
 
 // GetConnector retrieves a specific connector by zone, node and direction
 func (s *ConnectorSection) GetConnector(zoneNo, nodeNo int, direction string) (Connector, bool) {
-	for _, connector := range s.Connectors {
-		if connector.ZoneNo == zoneNo && connector.NodeNo == nodeNo && connector.Direction == direction {
+	for _, i := range s.byZone.lookup(s.Connectors, zoneNo, connectorZone) {
+		connector := s.Connectors[i]
+		if connector.NodeNo == nodeNo && connector.Direction == direction {
 			return connector, true
 		}
 	}
@@ -134,6 +124,14 @@ func (s *ConnectorSection) GetAverageTravelTime(tsys string) float64 {
 	return 0
 }
 
+// Reindex drops the lookup indexes after zones or nodes of connectors have been changed in place, they are built again on next use
+func (s *ConnectorSection) Reindex() {
+	s.byZone.reset()
+	s.byNode.reset()
+}
+
+func connectorZone(connector Connector) int { return connector.ZoneNo }
+
 // Count returns the number of connectors in the section
 func (s *ConnectorSection) Count() int {
 	return len(s.Connectors)
@@ -181,53 +179,56 @@ func (s *ConnectorSection) GetNodeConnectivity() map[int]int {
 
 // getConnector extracts data from CONNECTOR section row
 func getConnector(values []string, headers []string) (Connector, error) {
-	if len(values) < 13 {
-		return Connector{}, fmt.Errorf("invalid CONNECTOR data (insufficient fields): %v", values)
-	}
-
 	var connector Connector
 	var err error
 
 	// Initialize maps
-	connector.T0TSys = make(map[string]string)
+	connector.T0TSys = make(map[string]Duration)
 
 	// Parse ZONENO (required field)
-	if values[0] == "" {
-		return Connector{}, fmt.Errorf("missing required field ZONENO")
+	value := columnValue(values, headers, "ZONENO", 0)
+	if value == "" {
+		return Connector{}, missingFieldError("ZONENO")
 	}
-	connector.ZoneNo, err = strconv.Atoi(values[0])
+	connector.ZoneNo, err = strconv.Atoi(value)
 	if err != nil {
-		return Connector{}, fmt.Errorf("error parsing ZONENO: %w", err)
+		return Connector{}, parseFieldError("ZONENO", err)
 	}
 
 	// Parse NODENO (required field)
-	if values[1] == "" {
-		return Connector{}, fmt.Errorf("missing required field NODENO")
+	value = columnValue(values, headers, "NODENO", 1)
+	if value == "" {
+		return Connector{}, missingFieldError("NODENO")
 	}
-	connector.NodeNo, err = strconv.Atoi(values[1])
+	connector.NodeNo, err = strconv.Atoi(value)
 	if err != nil {
-		return Connector{}, fmt.Errorf("error parsing NODENO: %w", err)
+		return Connector{}, parseFieldError("NODENO", err)
 	}
 
 	// Parse DIRECTION (required field)
-	connector.Direction = values[2]
+	connector.Direction = columnValue(values, headers, "DIRECTION", 2)
 	if connector.Direction != "O" && connector.Direction != "D" {
-		return Connector{}, fmt.Errorf("invalid DIRECTION value: %s (should be O or D)", connector.Direction)
+		return Connector{}, parseFieldError("DIRECTION", fmt.Errorf("invalid value %s (should be O or D)", connector.Direction))
 	}
 
 	// Parse TYPENO (required field)
-	if values[3] != "" {
-		connector.TypeNo, err = strconv.Atoi(values[3])
+	if value := columnValue(values, headers, "TYPENO", 3); value != "" {
+		connector.TypeNo, err = strconv.Atoi(value)
 		if err != nil {
-			return Connector{}, fmt.Errorf("error parsing TYPENO: %w", err)
+			return Connector{}, parseFieldError("TYPENO", err)
 		}
 	}
 
 	// Parse TSYSSET (optional)
-	connector.TSysSet = values[4]
+	connector.TSysSet = columnValue(values, headers, "TSYSSET", 4)
 
 	// Parse LENGTH (required field)
-	connector.Length = values[5]
+	if value := columnValue(values, headers, "LENGTH", 5); value != "" {
+		connector.Length, err = parseLength(value)
+		if err != nil {
+			return Connector{}, parseFieldError("LENGTH", err)
+		}
+	}
 
 	// Process T0_TSYS fields based on headers
 	for i := 0; i < len(headers) && i < len(values); i++ {
@@ -242,143 +243,79 @@ func getConnector(values []string, headers []string) (Connector, error) {
 		if strings.HasPrefix(headerName, "T0_TSYS(") {
 			tsys := extractSystemName(headerName)
 			if tsys != "" {
-				connector.T0TSys[tsys] = value
+				connector.T0TSys[tsys], err = parseDuration(value)
+				if err != nil {
+					return Connector{}, parseFieldError(headerName, err)
+				}
 			}
 		}
 	}
 
 	// Parse WEIGHT(PRT) (required field)
-	if values[11] != "" {
-		connector.WeightPRT, err = strconv.ParseFloat(strings.Replace(values[11], ",", ".", -1), 64)
+	if value := columnValue(values, headers, "WEIGHT(PRT)", 11); value != "" {
+		connector.WeightPRT, err = strconv.ParseFloat(strings.Replace(value, ",", ".", -1), 64)
 		if err != nil {
-			return Connector{}, fmt.Errorf("error parsing WEIGHT(PRT): %w", err)
+			return Connector{}, parseFieldError("WEIGHT(PRT)", err)
 		}
 	}
 
 	// Parse WEIGHT(PUT) (required field)
-	if values[12] != "" {
-		connector.WeightPUT, err = strconv.ParseFloat(strings.Replace(values[12], ",", ".", -1), 64)
+	if value := columnValue(values, headers, "WEIGHT(PUT)", 12); value != "" {
+		connector.WeightPUT, err = strconv.ParseFloat(strings.Replace(value, ",", ".", -1), 64)
 		if err != nil {
-			return Connector{}, fmt.Errorf("error parsing WEIGHT(PUT): %w", err)
+			return Connector{}, parseFieldError("WEIGHT(PUT)", err)
 		}
 	}
 
 	// Parse ADDVAL1, ADDVAL2, ADDVAL3 (if available)
-	if len(values) > 13 && values[13] != "" {
-		connector.AddVal[0], err = strconv.Atoi(values[13])
+	if value := columnValue(values, headers, "ADDVAL1", 13); value != "" {
+		connector.AddVal[0], err = strconv.Atoi(value)
 		if err != nil {
-			return Connector{}, fmt.Errorf("error parsing ADDVAL1: %w", err)
+			return Connector{}, parseFieldError("ADDVAL1", err)
 		}
 	}
 
-	if len(values) > 14 && values[14] != "" {
-		connector.AddVal[1], err = strconv.Atoi(values[14])
+	if value := columnValue(values, headers, "ADDVAL2", 14); value != "" {
+		connector.AddVal[1], err = strconv.Atoi(value)
 		if err != nil {
-			return Connector{}, fmt.Errorf("error parsing ADDVAL2: %w", err)
+			return Connector{}, parseFieldError("ADDVAL2", err)
 		}
 	}
 
-	if len(values) > 15 && values[15] != "" {
-		connector.AddVal[2], err = strconv.Atoi(values[15])
+	if value := columnValue(values, headers, "ADDVAL3", 15); value != "" {
+		connector.AddVal[2], err = strconv.Atoi(value)
 		if err != nil {
-			return Connector{}, fmt.Errorf("error parsing ADDVAL3: %w", err)
+			return Connector{}, parseFieldError("ADDVAL3", err)
 		}
 	}
 
 	// Parse LABELPOSRELX (optional)
-	if len(values) > 16 && values[16] != "" {
-		connector.LabelPosRelX, err = strconv.ParseFloat(strings.Replace(values[16], ",", ".", -1), 64)
+	if value := columnValue(values, headers, "LABELPOSRELX", 16); value != "" {
+		connector.LabelPosRelX, err = strconv.ParseFloat(strings.Replace(value, ",", ".", -1), 64)
 		if err != nil {
-			return Connector{}, fmt.Errorf("error parsing LABELPOSRELX: %w", err)
+			return Connector{}, parseFieldError("LABELPOSRELX", err)
 		}
 	}
 
 	// Parse LABELPOSRELY (optional)
-	if len(values) > 17 && values[17] != "" {
-		connector.LabelPosRelY, err = strconv.ParseFloat(strings.Replace(values[17], ",", ".", -1), 64)
+	if value := columnValue(values, headers, "LABELPOSRELY", 17); value != "" {
+		connector.LabelPosRelY, err = strconv.ParseFloat(strings.Replace(value, ",", ".", -1), 64)
 		if err != nil {
-			return Connector{}, fmt.Errorf("error parsing LABELPOSRELY: %w", err)
+			return Connector{}, parseFieldError("LABELPOSRELY", err)
 		}
 	}
 
 	return connector, nil
 }
 
-// GetLengthInKm parses the Length string and returns the value in kilometers
+// GetLengthInKm returns the length in kilometers
 func (c *Connector) GetLengthInKm() float64 {
-	if c.Length == "" {
-		return 0
-	}
-
-	// Extract numeric part
-	numStr := c.Length
-	unit := ""
-
-	// Find where the numeric part ends
-	for i, ch := range c.Length {
-		if !utils.IsDigit(ch) && ch != '.' && ch != ',' {
-			numStr = c.Length[:i]
-			unit = strings.ToLower(c.Length[i:])
-			break
-		}
-	}
-
-	// Convert to float
-	numStr = strings.Replace(numStr, ",", ".", 1)
-	value, err := strconv.ParseFloat(numStr, 64)
-	if err != nil {
-		return 0
-	}
-
-	// Convert to km based on unit
-	switch {
-	case strings.Contains(unit, "km"):
-		return value
-	case strings.Contains(unit, "m"):
-		return value / 1000
-	default:
-		return value // Assume km if no unit specified
-	}
+	return c.Length.Kilometers()
 }
 
 // GetTravelTimeSeconds returns the travel time in seconds for a specific transport system
 func (c *Connector) GetTravelTimeSeconds(tsys string) float64 {
-	timeStr, exists := c.T0TSys[tsys]
-	if !exists || timeStr == "" {
-		return 0
-	}
-
-	// Extract numeric part
-	numStr := timeStr
-	unit := ""
-
-	// Find where the numeric part ends
-	for i, ch := range timeStr {
-		if !utils.IsDigit(ch) && ch != '.' && ch != ',' {
-			numStr = timeStr[:i]
-			unit = strings.ToLower(timeStr[i:])
-			break
-		}
-	}
-
-	// Convert to float
-	numStr = strings.Replace(numStr, ",", ".", 1)
-	value, err := strconv.ParseFloat(numStr, 64)
-	if err != nil {
-		return 0
-	}
-
-	// Convert to seconds based on unit
-	switch {
-	case strings.Contains(unit, "s"):
-		return value
-	case strings.Contains(unit, "min"):
-		return value * 60
-	case strings.Contains(unit, "h"):
-		return value * 3600
-	default:
-		return value // Assume seconds if no unit specified
-	}
+	return c.T0TSys[tsys].Seconds()
 }
 
 // IsOriginConnector returns true if this is an origin connector
@@ -401,3 +338,53 @@ func (c *Connector) AllowsTransportSystem(tsys string) bool {
 	}
 	return false
 }
+
+// defaultColumns returns the columns written for connectors built in code
+func (s *ConnectorSection) defaultColumns() []string {
+	t0TSys := make([]map[string]Duration, 0, len(s.Connectors))
+	for _, connector := range s.Connectors {
+		t0TSys = append(t0TSys, connector.T0TSys)
+	}
+
+	columns := []string{"ZONENO", "NODENO", "DIRECTION", "TYPENO", "TSYSSET", "LENGTH"}
+	columns = append(columns, systemColumns("T0_TSYS", t0TSys...)...)
+	return append(columns, "WEIGHT(PRT)", "WEIGHT(PUT)", "ADDVAL1", "ADDVAL2", "ADDVAL3", "LABELPOSRELX", "LABELPOSRELY")
+}
+
+func (c Connector) attribute(column string) (string, bool) {
+	switch column {
+	case "ZONENO":
+		return formatInt(c.ZoneNo), true
+	case "NODENO":
+		return formatInt(c.NodeNo), true
+	case "DIRECTION":
+		return c.Direction, true
+	case "TYPENO":
+		return formatInt(c.TypeNo), true
+	case "TSYSSET":
+		return c.TSysSet, true
+	case "LENGTH":
+		return c.Length.String(), true
+	case "WEIGHT(PRT)":
+		return formatFloat(c.WeightPRT), true
+	case "WEIGHT(PUT)":
+		return formatFloat(c.WeightPUT), true
+	case "ADDVAL1":
+		return formatInt(c.AddVal[0]), true
+	case "ADDVAL2":
+		return formatInt(c.AddVal[1]), true
+	case "ADDVAL3":
+		return formatInt(c.AddVal[2]), true
+	case "LABELPOSRELX":
+		return formatFloat(c.LabelPosRelX), true
+	case "LABELPOSRELY":
+		return formatFloat(c.LabelPosRelY), true
+	}
+
+	// Travel times are only written for the systems a connector has values for
+	if tsys, ok := systemColumn(column, "T0_TSYS"); ok {
+		value, found := c.T0TSys[tsys]
+		return value.String(), found
+	}
+	return "", false
+}
@@ -0,0 +1,182 @@
+package ptvvisum
+
+import (
+	"strconv"
+	"strings"
+)
+
+// CountLocationSection represents $COUNTLOCATION section
+type CountLocationSection struct {
+	BaseSection
+	CountLocations []CountLocation
+
+	byID   index[CountLocation, int]
+	byLink index[CountLocation, [2]int]
+}
+
+// CountLocation represents a place on one direction of a link where traffic is counted
+type CountLocation struct {
+	No          int                // Count location number
+	Code        string             // Count location code
+	Name        string             // Count location name
+	LinkNo      int                // Link the count location lies on
+	FromNodeNo  int                // From-node of the counted direction of the link
+	RelPos      float64            // Relative position on the link (0..1)
+	CountValue  float64            // Count value
+	CountValues map[string]float64 // Count values per parameter of COUNTVALUE(...) columns, e.g. per transport system
+}
+
+// GetCountLocationByID retrieves a count location by its number
+func (s *CountLocationSection) GetCountLocationByID(no int) (CountLocation, bool) {
+	return s.byID.findFirst(s.CountLocations, no, func(location CountLocation) int { return location.No })
+}
+
+// GetCountLocationsByLink retrieves the count locations on one direction of a link
+func (s *CountLocationSection) GetCountLocationsByLink(linkNo, fromNodeNo int) []CountLocation {
+	return s.byLink.find(s.CountLocations, [2]int{linkNo, fromNodeNo}, countLocationLink)
+}
+
+// GetLink retrieves the counted direction of the link of a count location
+func (s *CountLocationSection) GetLink(no int, data *PTVData) (Link, bool) {
+	location, found := s.GetCountLocationByID(no)
+	if !found || data.Link == nil {
+		return Link{}, false
+	}
+	return data.Link.GetLinkByFromNode(location.LinkNo, location.FromNodeNo)
+}
+
+// GetDetectors retrieves the detectors of a count location
+func (s *CountLocationSection) GetDetectors(no int, data *PTVData) []Detector {
+	if data.Detector == nil {
+		return nil
+	}
+	return data.Detector.GetDetectorsByCountLocation(no)
+}
+
+// Reindex drops the lookup indexes after numbers or links of count locations have been changed in place, they are built again on next use
+func (s *CountLocationSection) Reindex() {
+	s.byID.reset()
+	s.byLink.reset()
+}
+
+func countLocationLink(location CountLocation) [2]int {
+	return [2]int{location.LinkNo, location.FromNodeNo}
+}
+
+// Count returns the number of count locations in the section
+func (s *CountLocationSection) Count() int {
+	return len(s.CountLocations)
+}
+
+// getCountLocation extracts data from COUNTLOCATION section row
+func getCountLocation(values []string, headers []string) (CountLocation, error) {
+	location := CountLocation{CountValues: make(map[string]float64)}
+	var err error
+
+	// Parse NO (required field)
+	value := columnValue(values, headers, "NO", 0)
+	if value == "" {
+		return CountLocation{}, missingFieldError("NO")
+	}
+	location.No, err = strconv.Atoi(value)
+	if err != nil {
+		return CountLocation{}, parseFieldError("NO", err)
+	}
+
+	location.Code = columnValue(values, headers, "CODE", 1)
+	location.Name = columnValue(values, headers, "NAME", 2)
+
+	// Parse LINKNO and FROMNODENO (required fields)
+	value = columnValue(values, headers, "LINKNO", 3)
+	if value == "" {
+		return CountLocation{}, missingFieldError("LINKNO")
+	}
+	location.LinkNo, err = strconv.Atoi(value)
+	if err != nil {
+		return CountLocation{}, parseFieldError("LINKNO", err)
+	}
+	value = columnValue(values, headers, "FROMNODENO", 4)
+	if value == "" {
+		return CountLocation{}, missingFieldError("FROMNODENO")
+	}
+	location.FromNodeNo, err = strconv.Atoi(value)
+	if err != nil {
+		return CountLocation{}, parseFieldError("FROMNODENO", err)
+	}
+
+	// Parse RELPOS (optional)
+	if value := columnValue(values, headers, "RELPOS", 5); value != "" {
+		location.RelPos, err = strconv.ParseFloat(strings.Replace(value, ",", ".", -1), 64)
+		if err != nil {
+			return CountLocation{}, parseFieldError("RELPOS", err)
+		}
+	}
+
+	location.CountValue, err = parseCountValues(values, headers, 6, location.CountValues)
+	if err != nil {
+		return CountLocation{}, err
+	}
+
+	return location, nil
+}
+
+// parseCountValues reads the COUNTVALUE column at the given position and all COUNTVALUE(...) columns into values
+func parseCountValues(row []string, headers []string, position int, values map[string]float64) (float64, error) {
+	var countValue float64
+	var err error
+	if value := columnValue(row, headers, "COUNTVALUE", position); value != "" {
+		countValue, err = strconv.ParseFloat(strings.Replace(value, ",", ".", -1), 64)
+		if err != nil {
+			return 0, parseFieldError("COUNTVALUE", err)
+		}
+	}
+
+	for i := 0; i < len(headers) && i < len(row); i++ {
+		key, ok := systemColumn(headers[i], "COUNTVALUE")
+		if !ok || row[i] == "" {
+			continue
+		}
+		values[key], err = strconv.ParseFloat(strings.Replace(row[i], ",", ".", -1), 64)
+		if err != nil {
+			return 0, parseFieldError(headers[i], err)
+		}
+	}
+	return countValue, nil
+}
+
+// defaultColumns returns the columns written for count locations built in code
+func (s *CountLocationSection) defaultColumns() []string {
+	countValues := make([]map[string]float64, 0, len(s.CountLocations))
+	for _, location := range s.CountLocations {
+		countValues = append(countValues, location.CountValues)
+	}
+
+	columns := []string{"NO", "CODE", "NAME", "LINKNO", "FROMNODENO", "RELPOS", "COUNTVALUE"}
+	return append(columns, systemColumns("COUNTVALUE", countValues...)...)
+}
+
+func (location CountLocation) attribute(column string) (string, bool) {
+	switch column {
+	case "NO":
+		return formatInt(location.No), true
+	case "CODE":
+		return location.Code, true
+	case "NAME":
+		return location.Name, true
+	case "LINKNO":
+		return formatInt(location.LinkNo), true
+	case "FROMNODENO":
+		return formatInt(location.FromNodeNo), true
+	case "RELPOS":
+		return formatFloat(location.RelPos), true
+	case "COUNTVALUE":
+		return formatFloat(location.CountValue), true
+	}
+
+	// Count values are only written for the parameters a count location has values for
+	if key, ok := systemColumn(column, "COUNTVALUE"); ok {
+		value, found := location.CountValues[key]
+		return formatFloat(value), found
+	}
+	return "", false
+}
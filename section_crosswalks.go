@@ -0,0 +1,139 @@
+package ptvvisum
+
+import "strconv"
+
+// CrosswalkSection represents $CROSSWALK section
+type CrosswalkSection struct {
+	BaseSection
+	Crosswalks []Crosswalk
+
+	byJunction index[Crosswalk, JunctionKey]
+}
+
+// Crosswalk represents one direction of a pedestrian crossing over a leg of a node or main node
+type Crosswalk struct {
+	NodeNo      int    // Node of the crosswalk, 0 for crosswalks of main nodes
+	MainNodeNo  int    // Main node of the crosswalk, 0 for crosswalks of nodes
+	Orientation string // Orientation of the leg crossed
+	Index       int    // Position of the crosswalk on the leg
+	Direction   int    // Direction of crossing (0/1)
+	Width       Length // Crosswalk width
+	PedVol      int    // Pedestrian volume
+	Offset      Length // Offset from the stop line
+	ICALength   Length // Crossing length used by the intersection capacity analysis
+}
+
+// JunctionKey returns the key of the junction the crosswalk belongs to
+func (crosswalk Crosswalk) JunctionKey() JunctionKey {
+	return JunctionKey{NodeNo: crosswalk.NodeNo, MainNodeNo: crosswalk.MainNodeNo}
+}
+
+// GetCrosswalksByJunction retrieves all crosswalks of a node or main node
+func (s *CrosswalkSection) GetCrosswalksByJunction(key JunctionKey) []Crosswalk {
+	return s.byJunction.find(s.Crosswalks, key, Crosswalk.JunctionKey)
+}
+
+// Reindex drops the lookup index after nodes of crosswalks have been changed in place, it is built again on next use
+func (s *CrosswalkSection) Reindex() {
+	s.byJunction.reset()
+}
+
+// Count returns the number of crosswalks in the section
+func (s *CrosswalkSection) Count() int {
+	return len(s.Crosswalks)
+}
+
+// getCrosswalk extracts data from CROSSWALK section row
+func getCrosswalk(values []string, headers []string) (Crosswalk, error) {
+	var crosswalk Crosswalk
+	var err error
+
+	// Parse NODENO and MAINNODENO (optional, one of them identifies the junction)
+	if value := columnValue(values, headers, "NODENO", 0); value != "" {
+		crosswalk.NodeNo, err = strconv.Atoi(value)
+		if err != nil {
+			return Crosswalk{}, parseFieldError("NODENO", err)
+		}
+	}
+	if value := columnValue(values, headers, "MAINNODENO", 1); value != "" {
+		crosswalk.MainNodeNo, err = strconv.Atoi(value)
+		if err != nil {
+			return Crosswalk{}, parseFieldError("MAINNODENO", err)
+		}
+	}
+
+	// Parse ORIENTATION (required field)
+	crosswalk.Orientation = columnValue(values, headers, "ORIENTATION", 2)
+	if crosswalk.Orientation == "" {
+		return Crosswalk{}, missingFieldError("ORIENTATION")
+	}
+
+	// Parse integer fields (optional)
+	intFields := []struct {
+		index int
+		dest  *int
+		name  string
+	}{
+		{3, &crosswalk.Index, "INDEX"},
+		{4, &crosswalk.Direction, "DIRECTION"},
+		{6, &crosswalk.PedVol, "PEDVOL"},
+	}
+	for _, field := range intFields {
+		if value := columnValue(values, headers, field.name, field.index); value != "" {
+			*field.dest, err = strconv.Atoi(value)
+			if err != nil {
+				return Crosswalk{}, parseFieldError(field.name, err)
+			}
+		}
+	}
+
+	// Parse lengths (optional)
+	lengthFields := []struct {
+		index int
+		dest  *Length
+		name  string
+	}{
+		{5, &crosswalk.Width, "WIDTH"},
+		{7, &crosswalk.Offset, "OFFSET"},
+		{8, &crosswalk.ICALength, "ICALENGTH"},
+	}
+	for _, field := range lengthFields {
+		if value := columnValue(values, headers, field.name, field.index); value != "" {
+			*field.dest, err = parseLength(value)
+			if err != nil {
+				return Crosswalk{}, parseFieldError(field.name, err)
+			}
+		}
+	}
+
+	return crosswalk, nil
+}
+
+// crosswalkColumns are the columns written when the section has no headers
+var crosswalkColumns = []string{
+	"NODENO", "MAINNODENO", "ORIENTATION", "INDEX", "DIRECTION", "WIDTH", "PEDVOL", "OFFSET", "ICALENGTH",
+}
+
+func (crosswalk Crosswalk) attribute(column string) (string, bool) {
+	switch column {
+	case "NODENO":
+		return formatInt(crosswalk.NodeNo), true
+	case "MAINNODENO":
+		return formatInt(crosswalk.MainNodeNo), true
+	case "ORIENTATION":
+		return crosswalk.Orientation, true
+	case "INDEX":
+		return formatInt(crosswalk.Index), true
+	case "DIRECTION":
+		return formatInt(crosswalk.Direction), true
+	case "WIDTH":
+		return crosswalk.Width.String(), true
+	case "PEDVOL":
+		return formatInt(crosswalk.PedVol), true
+	case "OFFSET":
+		return crosswalk.Offset.String(), true
+	case "ICALENGTH":
+		return crosswalk.ICALength.String(), true
+	}
+	return "", false
+}
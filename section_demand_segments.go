@@ -1,7 +1,6 @@
 package ptvvisum
 
 import (
-	"fmt"
 	"strconv"
 	"strings"
 )
@@ -23,43 +22,60 @@ type DemandSegment struct {
 }
 
 // getDemandSegment extracts data from DEMANDSEGMENT section row
-func getDemandSegment(values []string) (DemandSegment, error) {
-	if len(values) < 6 {
-		return DemandSegment{}, fmt.Errorf("invalid DEMANDSEGMENT data: %v", values)
-	}
-
+func getDemandSegment(values []string, headers []string) (DemandSegment, error) {
 	// Initialize with string values
 	segment := DemandSegment{
-		Code: values[0],
-		Name: values[1],
-		Mode: values[2],
+		Code: columnValue(values, headers, "CODE", 0),
+		Name: columnValue(values, headers, "NAME", 1),
+		Mode: columnValue(values, headers, "MODE", 2),
 	}
 
 	var err error
 
 	// Parse OccupancyRate
-	if values[3] != "" {
-		segment.OccupancyRate, err = strconv.ParseFloat(strings.Replace(values[3], ",", ".", -1), 64)
+	if value := columnValue(values, headers, "OCCUPANCYRATE", 3); value != "" {
+		segment.OccupancyRate, err = strconv.ParseFloat(strings.Replace(value, ",", ".", -1), 64)
 		if err != nil {
-			return DemandSegment{}, fmt.Errorf("error parsing OccupancyRate: %w", err)
+			return DemandSegment{}, parseFieldError("OCCUPANCYRATE", err)
 		}
 	}
 
 	// Parse PrFacAP
-	if values[4] != "" {
-		segment.PrFacAP, err = strconv.ParseFloat(strings.Replace(values[4], ",", ".", -1), 64)
+	if value := columnValue(values, headers, "PRFACAP", 4); value != "" {
+		segment.PrFacAP, err = strconv.ParseFloat(strings.Replace(value, ",", ".", -1), 64)
 		if err != nil {
-			return DemandSegment{}, fmt.Errorf("error parsing PrFacAP: %w", err)
+			return DemandSegment{}, parseFieldError("PRFACAP", err)
 		}
 	}
 
 	// Parse PrFacAH
-	if values[5] != "" {
-		segment.PrFacAH, err = strconv.ParseFloat(strings.Replace(values[5], ",", ".", -1), 64)
+	if value := columnValue(values, headers, "PRFACAH", 5); value != "" {
+		segment.PrFacAH, err = strconv.ParseFloat(strings.Replace(value, ",", ".", -1), 64)
 		if err != nil {
-			return DemandSegment{}, fmt.Errorf("error parsing PrFacAH: %w", err)
+			return DemandSegment{}, parseFieldError("PRFACAH", err)
 		}
 	}
 
 	return segment, nil
 }
+
+// demandSegmentColumns are the columns written when the section has no headers
+var demandSegmentColumns = []string{"CODE", "NAME", "MODE", "OCCUPANCYRATE", "PRFACAP", "PRFACAH"}
+
+func (s DemandSegment) attribute(column string) (string, bool) {
+	switch column {
+	case "CODE":
+		return s.Code, true
+	case "NAME":
+		return s.Name, true
+	case "MODE":
+		return s.Mode, true
+	case "OCCUPANCYRATE":
+		return formatFloat(s.OccupancyRate), true
+	case "PRFACAP":
+		return formatFloat(s.PrFacAP), true
+	case "PRFACAH":
+		return formatFloat(s.PrFacAH), true
+	}
+	return "", false
+}
@@ -0,0 +1,184 @@
+package ptvvisum
+
+import (
+	"strconv"
+	"strings"
+)
+
+// DetectorSection represents $DETECTOR section
+type DetectorSection struct {
+	BaseSection
+	Detectors []Detector
+
+	byID            index[Detector, int]
+	byCountLocation index[Detector, int]
+}
+
+// Detector represents a detector on one direction of a link, either feeding a count location
+// or a signal control with counts
+type Detector struct {
+	No              int                // Detector number
+	Code            string             // Detector code
+	Name            string             // Detector name
+	CountLocationNo int                // Count location the detector belongs to, 0 if none
+	SCNo            int                // Signal control using the detector, 0 if none
+	LinkNo          int                // Link the detector lies on
+	FromNodeNo      int                // From-node of the direction of the link
+	LaneNo          int                // Lane the detector lies on, 0 for all lanes
+	RelPos          float64            // Relative position on the link (0..1)
+	CountValue      float64            // Count value
+	CountValues     map[string]float64 // Count values per parameter of COUNTVALUE(...) columns, e.g. per transport system
+}
+
+// GetDetectorByID retrieves a detector by its number
+func (s *DetectorSection) GetDetectorByID(no int) (Detector, bool) {
+	return s.byID.findFirst(s.Detectors, no, func(detector Detector) int { return detector.No })
+}
+
+// GetDetectorsByCountLocation retrieves the detectors of a count location
+func (s *DetectorSection) GetDetectorsByCountLocation(countLocationNo int) []Detector {
+	return s.byCountLocation.find(s.Detectors, countLocationNo, func(detector Detector) int { return detector.CountLocationNo })
+}
+
+// GetLink retrieves the direction of the link a detector lies on
+func (s *DetectorSection) GetLink(no int, data *PTVData) (Link, bool) {
+	detector, found := s.GetDetectorByID(no)
+	if !found || data.Link == nil {
+		return Link{}, false
+	}
+	return data.Link.GetLinkByFromNode(detector.LinkNo, detector.FromNodeNo)
+}
+
+// GetCountLocation retrieves the count location of a detector
+func (s *DetectorSection) GetCountLocation(no int, data *PTVData) (CountLocation, bool) {
+	detector, found := s.GetDetectorByID(no)
+	if !found || detector.CountLocationNo == 0 || data.CountLocation == nil {
+		return CountLocation{}, false
+	}
+	return data.CountLocation.GetCountLocationByID(detector.CountLocationNo)
+}
+
+// Reindex drops the lookup indexes after numbers or count locations of detectors have been changed in place, they are built again on next use
+func (s *DetectorSection) Reindex() {
+	s.byID.reset()
+	s.byCountLocation.reset()
+}
+
+// Count returns the number of detectors in the section
+func (s *DetectorSection) Count() int {
+	return len(s.Detectors)
+}
+
+// getDetector extracts data from DETECTOR section row
+func getDetector(values []string, headers []string) (Detector, error) {
+	detector := Detector{CountValues: make(map[string]float64)}
+	var err error
+
+	// Parse NO (required field)
+	value := columnValue(values, headers, "NO", 0)
+	if value == "" {
+		return Detector{}, missingFieldError("NO")
+	}
+	detector.No, err = strconv.Atoi(value)
+	if err != nil {
+		return Detector{}, parseFieldError("NO", err)
+	}
+
+	detector.Code = columnValue(values, headers, "CODE", 1)
+	detector.Name = columnValue(values, headers, "NAME", 2)
+
+	// Parse LINKNO and FROMNODENO (required fields)
+	value = columnValue(values, headers, "LINKNO", 5)
+	if value == "" {
+		return Detector{}, missingFieldError("LINKNO")
+	}
+	detector.LinkNo, err = strconv.Atoi(value)
+	if err != nil {
+		return Detector{}, parseFieldError("LINKNO", err)
+	}
+	value = columnValue(values, headers, "FROMNODENO", 6)
+	if value == "" {
+		return Detector{}, missingFieldError("FROMNODENO")
+	}
+	detector.FromNodeNo, err = strconv.Atoi(value)
+	if err != nil {
+		return Detector{}, parseFieldError("FROMNODENO", err)
+	}
+
+	// Parse references (optional)
+	intFields := []struct {
+		index int
+		dest  *int
+		name  string
+	}{
+		{3, &detector.CountLocationNo, "COUNTLOCATIONNO"},
+		{4, &detector.SCNo, "SCNO"},
+		{7, &detector.LaneNo, "LANENO"},
+	}
+	for _, field := range intFields {
+		if value := columnValue(values, headers, field.name, field.index); value != "" {
+			*field.dest, err = strconv.Atoi(value)
+			if err != nil {
+				return Detector{}, parseFieldError(field.name, err)
+			}
+		}
+	}
+
+	// Parse RELPOS (optional)
+	if value := columnValue(values, headers, "RELPOS", 8); value != "" {
+		detector.RelPos, err = strconv.ParseFloat(strings.Replace(value, ",", ".", -1), 64)
+		if err != nil {
+			return Detector{}, parseFieldError("RELPOS", err)
+		}
+	}
+
+	detector.CountValue, err = parseCountValues(values, headers, 9, detector.CountValues)
+	if err != nil {
+		return Detector{}, err
+	}
+
+	return detector, nil
+}
+
+// defaultColumns returns the columns written for detectors built in code
+func (s *DetectorSection) defaultColumns() []string {
+	countValues := make([]map[string]float64, 0, len(s.Detectors))
+	for _, detector := range s.Detectors {
+		countValues = append(countValues, detector.CountValues)
+	}
+
+	columns := []string{"NO", "CODE", "NAME", "COUNTLOCATIONNO", "SCNO", "LINKNO", "FROMNODENO", "LANENO", "RELPOS", "COUNTVALUE"}
+	return append(columns, systemColumns("COUNTVALUE", countValues...)...)
+}
+
+func (detector Detector) attribute(column string) (string, bool) {
+	switch column {
+	case "NO":
+		return formatInt(detector.No), true
+	case "CODE":
+		return detector.Code, true
+	case "NAME":
+		return detector.Name, true
+	case "COUNTLOCATIONNO":
+		return formatOptionalInt(detector.CountLocationNo), true
+	case "SCNO":
+		return formatOptionalInt(detector.SCNo), true
+	case "LINKNO":
+		return formatInt(detector.LinkNo), true
+	case "FROMNODENO":
+		return formatInt(detector.FromNodeNo), true
+	case "LANENO":
+		return formatInt(detector.LaneNo), true
+	case "RELPOS":
+		return formatFloat(detector.RelPos), true
+	case "COUNTVALUE":
+		return formatFloat(detector.CountValue), true
+	}
+
+	// Count values are only written for the parameters a detector has values for
+	if key, ok := systemColumn(column, "COUNTVALUE"); ok {
+		value, found := detector.CountValues[key]
+		return formatFloat(value), found
+	}
+	return "", false
+}
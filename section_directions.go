@@ -1,9 +1,6 @@
 package ptvvisum
 
-import (
-	"fmt"
-	"strconv"
-)
+import "strconv"
 
 // DirectionSection represents $DIRECTION section
 type DirectionSection struct {
@@ -18,24 +15,49 @@ type Direction struct {
 	Name string // Direction name/description
 }
 
-// getDirection extracts data from DIRECTION section row
-func getDirection(values []string) (Direction, error) {
-	if len(values) < 3 {
-		return Direction{}, fmt.Errorf("invalid DIRECTION data: %v", values)
+// GetDirectionByCode retrieves a direction by its code, e.g. ">"
+func (s *DirectionSection) GetDirectionByCode(code string) (Direction, bool) {
+	for _, direction := range s.Directions {
+		if direction.Code == code {
+			return direction, true
+		}
 	}
+	return Direction{}, false
+}
 
+// getDirection extracts data from DIRECTION section row
+func getDirection(values []string, headers []string) (Direction, error) {
 	// Parse NO (required field)
-	no, err := strconv.Atoi(values[0])
+	value := columnValue(values, headers, "NO", 0)
+	if value == "" {
+		return Direction{}, missingFieldError("NO")
+	}
+	no, err := strconv.Atoi(value)
 	if err != nil {
-		return Direction{}, fmt.Errorf("error parsing No: %w", err)
+		return Direction{}, parseFieldError("NO", err)
 	}
 
 	// Create direction with parsed values
 	direction := Direction{
 		No:   no,
-		Code: values[1],
-		Name: values[2],
+		Code: columnValue(values, headers, "CODE", 1),
+		Name: columnValue(values, headers, "NAME", 2),
 	}
 
 	return direction, nil
 }
+
+// directionColumns are the columns written when the section has no headers
+var directionColumns = []string{"NO", "CODE", "NAME"}
+
+func (d Direction) attribute(column string) (string, bool) {
+	switch column {
+	case "NO":
+		return formatInt(d.No), true
+	case "CODE":
+		return d.Code, true
+	case "NAME":
+		return d.Name, true
+	}
+	return "", false
+}
@@ -1,14 +1,15 @@
 package ptvvisum
 
-import (
-	"fmt"
-	"strconv"
-)
+import "strconv"
 
 // EdgeSection represents $EDGE section
 type EdgeSection struct {
 	BaseSection
 	Edges []Edge
+
+	byID        index[Edge, int]
+	byFromPoint index[Edge, int]
+	byToPoint   index[Edge, int]
 }
 
 // Edge represents a single edge/connection between points
@@ -20,82 +21,85 @@ type Edge struct {
 
 // GetEdgeByID retrieves an edge by its ID
 func (s *EdgeSection) GetEdgeByID(id int) (Edge, bool) {
-	for _, e := range s.Edges {
-		if e.ID == id {
-			return e, true
-		}
-	}
-	return Edge{}, false
+	return s.byID.findFirst(s.Edges, id, func(e Edge) int { return e.ID })
 }
 
 // GetEdgesByPointID retrieves all edges connected to a specific point
 func (s *EdgeSection) GetEdgesByPointID(pointID int) []Edge {
-	var result []Edge
-	for _, e := range s.Edges {
-		if e.FromPointID == pointID || e.ToPointID == pointID {
-			result = append(result, e)
-		}
-	}
-	return result
+	outgoing := s.byFromPoint.lookup(s.Edges, pointID, edgeFromPoint)
+	incoming := s.byToPoint.lookup(s.Edges, pointID, edgeToPoint)
+	return pick(s.Edges, mergePositions(outgoing, incoming))
 }
 
 // GetOutgoingEdges retrieves all edges starting from a specific point
 func (s *EdgeSection) GetOutgoingEdges(pointID int) []Edge {
-	var result []Edge
-	for _, e := range s.Edges {
-		if e.FromPointID == pointID {
-			result = append(result, e)
-		}
-	}
-	return result
+	return s.byFromPoint.find(s.Edges, pointID, edgeFromPoint)
 }
 
 // GetIncomingEdges retrieves all edges ending at a specific point
 func (s *EdgeSection) GetIncomingEdges(pointID int) []Edge {
-	var result []Edge
-	for _, e := range s.Edges {
-		if e.ToPointID == pointID {
-			result = append(result, e)
-		}
-	}
-	return result
+	return s.byToPoint.find(s.Edges, pointID, edgeToPoint)
 }
 
-// getEdge extracts data from EDGE section row
-func getEdge(values []string) (Edge, error) {
-	if len(values) < 3 {
-		return Edge{}, fmt.Errorf("invalid EDGE data: %v", values)
-	}
+// Reindex drops the lookup indexes after IDs or points of edges have been changed in place, they are built again on next use
+func (s *EdgeSection) Reindex() {
+	s.byID.reset()
+	s.byFromPoint.reset()
+	s.byToPoint.reset()
+}
+
+func edgeFromPoint(e Edge) int { return e.FromPointID }
+func edgeToPoint(e Edge) int   { return e.ToPointID }
 
+// getEdge extracts data from EDGE section row
+func getEdge(values []string, headers []string) (Edge, error) {
 	var edge Edge
 	var err error
 
 	// Parse ID (required field)
-	if values[0] == "" {
-		return Edge{}, fmt.Errorf("missing required field ID")
+	value := columnValue(values, headers, "ID", 0)
+	if value == "" {
+		return Edge{}, missingFieldError("ID")
 	}
-	edge.ID, err = strconv.Atoi(values[0])
+	edge.ID, err = strconv.Atoi(value)
 	if err != nil {
-		return Edge{}, fmt.Errorf("error parsing ID: %w", err)
+		return Edge{}, parseFieldError("ID", err)
 	}
 
 	// Parse FROMPOINTID (required field)
-	if values[1] == "" {
-		return Edge{}, fmt.Errorf("missing required field FROMPOINTID")
+	value = columnValue(values, headers, "FROMPOINTID", 1)
+	if value == "" {
+		return Edge{}, missingFieldError("FROMPOINTID")
 	}
-	edge.FromPointID, err = strconv.Atoi(values[1])
+	edge.FromPointID, err = strconv.Atoi(value)
 	if err != nil {
-		return Edge{}, fmt.Errorf("error parsing FROMPOINTID: %w", err)
+		return Edge{}, parseFieldError("FROMPOINTID", err)
 	}
 
 	// Parse TOPOINTID (required field)
-	if values[2] == "" {
-		return Edge{}, fmt.Errorf("missing required field TOPOINTID")
+	value = columnValue(values, headers, "TOPOINTID", 2)
+	if value == "" {
+		return Edge{}, missingFieldError("TOPOINTID")
 	}
-	edge.ToPointID, err = strconv.Atoi(values[2])
+	edge.ToPointID, err = strconv.Atoi(value)
 	if err != nil {
-		return Edge{}, fmt.Errorf("error parsing TOPOINTID: %w", err)
+		return Edge{}, parseFieldError("TOPOINTID", err)
 	}
 
 	return edge, nil
 }
+
+// edgeColumns are the columns written when the section has no headers
+var edgeColumns = []string{"ID", "FROMPOINTID", "TOPOINTID"}
+
+func (e Edge) attribute(column string) (string, bool) {
+	switch column {
+	case "ID":
+		return formatInt(e.ID), true
+	case "FROMPOINTID":
+		return formatInt(e.FromPointID), true
+	case "TOPOINTID":
+		return formatInt(e.ToPointID), true
+	}
+	return "", false
+}
@@ -1,7 +1,6 @@
 package ptvvisum
 
 import (
-	"fmt"
 	"math"
 	"sort"
 	"strconv"
@@ -11,6 +10,8 @@ import (
 type FaceItemSection struct {
 	BaseSection
 	Items []FaceItem
+
+	byFace index[FaceItem, int]
 }
 
 // FaceItem represents a single edge in a face definition
@@ -23,12 +24,7 @@ type FaceItem struct {
 
 // GetItemsByFaceID retrieves all items for a specific face
 func (s *FaceItemSection) GetItemsByFaceID(faceID int) []FaceItem {
-	var result []FaceItem
-	for _, item := range s.Items {
-		if item.FaceID == faceID {
-			result = append(result, item)
-		}
-	}
+	result := s.byFace.find(s.Items, faceID, func(item FaceItem) int { return item.FaceID })
 
 	// Sort by index to ensure correct order
 	sort.Slice(result, func(i, j int) bool {
@@ -38,6 +34,11 @@ func (s *FaceItemSection) GetItemsByFaceID(faceID int) []FaceItem {
 	return result
 }
 
+// Reindex drops the lookup index after faces of items have been changed in place, it is built again on next use
+func (s *FaceItemSection) Reindex() {
+	s.byFace.reset()
+}
+
 // GetFaceGeometry builds the complete geometry of a face
 func (s *FaceItemSection) GetFaceGeometry(faceID int, data *PTVData) [][2]float64 {
 	items := s.GetItemsByFaceID(faceID)
@@ -113,49 +114,66 @@ func (s *FaceItemSection) CalculateFaceArea(faceID int, data *PTVData) float64 {
 }
 
 // getFaceItem extracts data from FACEITEM section row
-func getFaceItem(values []string) (FaceItem, error) {
-	if len(values) < 4 {
-		return FaceItem{}, fmt.Errorf("invalid FACEITEM data: %v", values)
-	}
-
+func getFaceItem(values []string, headers []string) (FaceItem, error) {
 	var item FaceItem
 	var err error
 
 	// Parse FACEID (required field)
-	if values[0] == "" {
-		return FaceItem{}, fmt.Errorf("missing required field FACEID")
+	value := columnValue(values, headers, "FACEID", 0)
+	if value == "" {
+		return FaceItem{}, missingFieldError("FACEID")
 	}
-	item.FaceID, err = strconv.Atoi(values[0])
+	item.FaceID, err = strconv.Atoi(value)
 	if err != nil {
-		return FaceItem{}, fmt.Errorf("error parsing FACEID: %w", err)
+		return FaceItem{}, parseFieldError("FACEID", err)
 	}
 
 	// Parse INDEX (required field)
-	if values[1] == "" {
-		return FaceItem{}, fmt.Errorf("missing required field INDEX")
+	value = columnValue(values, headers, "INDEX", 1)
+	if value == "" {
+		return FaceItem{}, missingFieldError("INDEX")
 	}
-	item.Index, err = strconv.Atoi(values[1])
+	item.Index, err = strconv.Atoi(value)
 	if err != nil {
-		return FaceItem{}, fmt.Errorf("error parsing INDEX: %w", err)
+		return FaceItem{}, parseFieldError("INDEX", err)
 	}
 
 	// Parse EDGEID (required field)
-	if values[2] == "" {
-		return FaceItem{}, fmt.Errorf("missing required field EDGEID")
+	value = columnValue(values, headers, "EDGEID", 2)
+	if value == "" {
+		return FaceItem{}, missingFieldError("EDGEID")
 	}
-	item.EdgeID, err = strconv.Atoi(values[2])
+	item.EdgeID, err = strconv.Atoi(value)
 	if err != nil {
-		return FaceItem{}, fmt.Errorf("error parsing EDGEID: %w", err)
+		return FaceItem{}, parseFieldError("EDGEID", err)
 	}
 
 	// Parse DIRECTION (required field)
-	if values[3] == "" {
-		return FaceItem{}, fmt.Errorf("missing required field DIRECTION")
+	value = columnValue(values, headers, "DIRECTION", 3)
+	if value == "" {
+		return FaceItem{}, missingFieldError("DIRECTION")
 	}
-	item.Direction, err = strconv.Atoi(values[3])
+	item.Direction, err = strconv.Atoi(value)
 	if err != nil {
-		return FaceItem{}, fmt.Errorf("error parsing DIRECTION: %w", err)
+		return FaceItem{}, parseFieldError("DIRECTION", err)
 	}
 
 	return item, nil
 }
+
+// faceItemColumns are the columns written when the section has no headers
+var faceItemColumns = []string{"FACEID", "INDEX", "EDGEID", "DIRECTION"}
+
+func (item FaceItem) attribute(column string) (string, bool) {
+	switch column {
+	case "FACEID":
+		return formatInt(item.FaceID), true
+	case "INDEX":
+		return formatInt(item.Index), true
+	case "EDGEID":
+		return formatInt(item.EdgeID), true
+	case "DIRECTION":
+		return formatInt(item.Direction), true
+	}
+	return "", false
+}
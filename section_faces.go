@@ -1,14 +1,13 @@
 package ptvvisum
 
-import (
-	"fmt"
-	"strconv"
-)
+import "strconv"
 
 // FaceSection represents $FACE section
 type FaceSection struct {
 	BaseSection
 	Faces []Face
+
+	byID index[Face, int]
 }
 
 // Face represents a single face/polygon identifier
@@ -18,12 +17,7 @@ type Face struct {
 
 // GetFaceByID retrieves a face by its ID
 func (s *FaceSection) GetFaceByID(id int) (Face, bool) {
-	for _, f := range s.Faces {
-		if f.ID == id {
-			return f, true
-		}
-	}
-	return Face{}, false
+	return s.byID.findFirst(s.Faces, id, func(f Face) int { return f.ID })
 }
 
 // Contains checks if a face ID exists in the section
@@ -32,26 +26,38 @@ func (s *FaceSection) Contains(id int) bool {
 	return found
 }
 
+// Reindex drops the lookup index after face IDs have been changed in place, it is built again on next use
+func (s *FaceSection) Reindex() {
+	s.byID.reset()
+}
+
 // Count returns the number of faces in the section
 func (s *FaceSection) Count() int {
 	return len(s.Faces)
 }
 
 // getFace extracts data from FACE section row
-func getFace(values []string) (Face, error) {
-	if len(values) < 1 {
-		return Face{}, fmt.Errorf("invalid FACE data: %v", values)
-	}
-
+func getFace(values []string, headers []string) (Face, error) {
 	// Parse ID (required field)
-	if values[0] == "" {
-		return Face{}, fmt.Errorf("missing required field ID")
+	value := columnValue(values, headers, "ID", 0)
+	if value == "" {
+		return Face{}, missingFieldError("ID")
 	}
 
-	id, err := strconv.Atoi(values[0])
+	id, err := strconv.Atoi(value)
 	if err != nil {
-		return Face{}, fmt.Errorf("error parsing ID: %w", err)
+		return Face{}, parseFieldError("ID", err)
 	}
 
 	return Face{ID: id}, nil
 }
+
+// faceColumns are the columns written when the section has no headers
+var faceColumns = []string{"ID"}
+
+func (f Face) attribute(column string) (string, bool) {
+	if column == "ID" {
+		return formatInt(f.ID), true
+	}
+	return "", false
+}
@@ -1,7 +1,6 @@
 package ptvvisum
 
 import (
-	"fmt"
 	"strconv"
 	"strings"
 )
@@ -13,16 +12,32 @@ type FareModelSection struct {
 }
 
 // getFallbackFare extracts fallback fare value from FAREMODEL section
-func getFallbackFare(values []string) (float64, error) {
-	if len(values) < 1 || values[0] == "" {
-		return 0.0, fmt.Errorf("invalid FAREMODEL data: %v", values)
+func getFallbackFare(values []string, headers []string) (float64, error) {
+	value := columnValue(values, headers, "FALLBACKFARE", 0)
+	if value == "" {
+		return 0.0, missingFieldError("FALLBACKFARE")
 	}
 
 	// Parse the fallback fare value
-	fallbackFare, err := strconv.ParseFloat(strings.Replace(values[0], ",", ".", -1), 64)
+	fallbackFare, err := strconv.ParseFloat(strings.Replace(value, ",", ".", -1), 64)
 	if err != nil {
-		return 0.0, fmt.Errorf("error parsing fallback fare: %w", err)
+		return 0.0, parseFieldError("FALLBACKFARE", err)
 	}
 
 	return fallbackFare, nil
 }
+
+// fareModelColumns are the columns written when the section has no headers
+var fareModelColumns = []string{"FALLBACKFARE"}
+
+// fareModelRecord adapts FareModelSection to the single row written for it
+type fareModelRecord struct {
+	*FareModelSection
+}
+
+func (r fareModelRecord) attribute(column string) (string, bool) {
+	if column == "FALLBACKFARE" {
+		return formatFloat(r.FallbackFare), true
+	}
+	return "", false
+}
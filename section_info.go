@@ -13,16 +13,30 @@ type InfoLine struct {
 	Text  string
 }
 
-func getInfoLine(values []string) (InfoLine, error) {
-	if len(values) < 2 {
-		return InfoLine{}, fmt.Errorf("invalid INFO data: %v", values)
+func getInfoLine(values []string, headers []string) (InfoLine, error) {
+	value := columnValue(values, headers, "INDEX", 0)
+	if value == "" {
+		return InfoLine{}, missingFieldError("INDEX")
 	}
 	var index int
-	if _, err := fmt.Sscanf(values[0], "%d", &index); err != nil {
-		return InfoLine{}, fmt.Errorf("error parsing index: %w", err)
+	if _, err := fmt.Sscanf(value, "%d", &index); err != nil {
+		return InfoLine{}, parseFieldError("INDEX", err)
 	}
 	return InfoLine{
 		Index: index,
-		Text:  values[1],
+		Text:  columnValue(values, headers, "TEXT", 1),
 	}, nil
 }
+
+// infoColumns are the columns written when the section has no headers
+var infoColumns = []string{"INDEX", "TEXT"}
+
+func (l InfoLine) attribute(column string) (string, bool) {
+	switch column {
+	case "INDEX":
+		return formatInt(l.Index), true
+	case "TEXT":
+		return l.Text, true
+	}
+	return "", false
+}
@@ -1,7 +1,6 @@
 package ptvvisum
 
 import (
-	"fmt"
 	"math"
 	"sort"
 	"strconv"
@@ -12,6 +11,8 @@ import (
 type EdgeItemSection struct {
 	BaseSection
 	Items []EdgeItem
+
+	byEdge index[EdgeItem, int]
 }
 
 // EdgeItem represents a single intermediate point on an edge
@@ -24,12 +25,7 @@ type EdgeItem struct {
 
 // GetItemsByEdgeID retrieves all intermediate points for a specific edge
 func (s *EdgeItemSection) GetItemsByEdgeID(edgeID int) []EdgeItem {
-	var result []EdgeItem
-	for _, item := range s.Items {
-		if item.EdgeID == edgeID {
-			result = append(result, item)
-		}
-	}
+	result := s.byEdge.find(s.Items, edgeID, func(item EdgeItem) int { return item.EdgeID })
 
 	// Sort by index to ensure correct order
 	sort.Slice(result, func(i, j int) bool {
@@ -39,6 +35,11 @@ func (s *EdgeItemSection) GetItemsByEdgeID(edgeID int) []EdgeItem {
 	return result
 }
 
+// Reindex drops the lookup index after edges of items have been changed in place, it is built again on next use
+func (s *EdgeItemSection) Reindex() {
+	s.byEdge.reset()
+}
+
 // GetEdgeGeometry returns the complete geometry of an edge as a series of coordinates
 func (s *EdgeItemSection) GetEdgeGeometry(edgeID int, data *PTVData) [][2]float64 {
 	// Get all intermediate points for this edge
@@ -94,49 +95,66 @@ func (s *EdgeItemSection) CalculateEdgeLength(edgeID int, data *PTVData) float64
 }
 
 // getEdgeItem extracts data from EDGEITEM section row
-func getEdgeItem(values []string) (EdgeItem, error) {
-	if len(values) < 4 {
-		return EdgeItem{}, fmt.Errorf("invalid EDGEITEM data (need EDGEID;INDEX;XCOORD;YCOORD): %v", values)
-	}
-
+func getEdgeItem(values []string, headers []string) (EdgeItem, error) {
 	var item EdgeItem
 	var err error
 
 	// Parse EDGEID (required field)
-	if values[0] == "" {
-		return EdgeItem{}, fmt.Errorf("missing required field EDGEID")
+	value := columnValue(values, headers, "EDGEID", 0)
+	if value == "" {
+		return EdgeItem{}, missingFieldError("EDGEID")
 	}
-	item.EdgeID, err = strconv.Atoi(values[0])
+	item.EdgeID, err = strconv.Atoi(value)
 	if err != nil {
-		return EdgeItem{}, fmt.Errorf("error parsing EDGEID: %w", err)
+		return EdgeItem{}, parseFieldError("EDGEID", err)
 	}
 
 	// Parse INDEX (required field)
-	if values[1] == "" {
-		return EdgeItem{}, fmt.Errorf("missing required field INDEX")
+	value = columnValue(values, headers, "INDEX", 1)
+	if value == "" {
+		return EdgeItem{}, missingFieldError("INDEX")
 	}
-	item.Index, err = strconv.Atoi(values[1])
+	item.Index, err = strconv.Atoi(value)
 	if err != nil {
-		return EdgeItem{}, fmt.Errorf("error parsing INDEX: %w", err)
+		return EdgeItem{}, parseFieldError("INDEX", err)
 	}
 
 	// Parse XCOORD (required field)
-	if values[2] == "" {
-		return EdgeItem{}, fmt.Errorf("missing required field XCOORD")
+	value = columnValue(values, headers, "XCOORD", 2)
+	if value == "" {
+		return EdgeItem{}, missingFieldError("XCOORD")
 	}
-	item.XCoord, err = strconv.ParseFloat(strings.Replace(values[2], ",", ".", -1), 64)
+	item.XCoord, err = strconv.ParseFloat(strings.Replace(value, ",", ".", -1), 64)
 	if err != nil {
-		return EdgeItem{}, fmt.Errorf("error parsing XCOORD: %w", err)
+		return EdgeItem{}, parseFieldError("XCOORD", err)
 	}
 
 	// Parse YCOORD (required field)
-	if values[3] == "" {
-		return EdgeItem{}, fmt.Errorf("missing required field YCOORD")
+	value = columnValue(values, headers, "YCOORD", 3)
+	if value == "" {
+		return EdgeItem{}, missingFieldError("YCOORD")
 	}
-	item.YCoord, err = strconv.ParseFloat(strings.Replace(values[3], ",", ".", -1), 64)
+	item.YCoord, err = strconv.ParseFloat(strings.Replace(value, ",", ".", -1), 64)
 	if err != nil {
-		return EdgeItem{}, fmt.Errorf("error parsing YCOORD: %w", err)
+		return EdgeItem{}, parseFieldError("YCOORD", err)
 	}
 
 	return item, nil
 }
+
+// edgeItemColumns are the columns written when the section has no headers
+var edgeItemColumns = []string{"EDGEID", "INDEX", "XCOORD", "YCOORD"}
+
+func (item EdgeItem) attribute(column string) (string, bool) {
+	switch column {
+	case "EDGEID":
+		return formatInt(item.EdgeID), true
+	case "INDEX":
+		return formatInt(item.Index), true
+	case "XCOORD":
+		return formatFloat(item.XCoord), true
+	case "YCOORD":
+		return formatFloat(item.YCoord), true
+	}
+	return "", false
+}
@@ -0,0 +1,158 @@
+package ptvvisum
+
+import (
+	"strconv"
+	"strings"
+)
+
+// LaneTurnSection represents $LANETURN section
+type LaneTurnSection struct {
+	BaseSection
+	LaneTurns []LaneTurn
+
+	byJunction index[LaneTurn, JunctionKey]
+}
+
+// LaneTurn represents a permitted movement from a lane of one link to a lane of another at a node or main node
+type LaneTurn struct {
+	NodeNo         int     // Node of the lane turn, 0 for lane turns of main nodes
+	MainNodeNo     int     // Main node of the lane turn, 0 for lane turns of nodes
+	FromLinkNo     int     // Link the movement comes from
+	FromLaneNo     int     // Lane of the link the movement comes from
+	ToLinkNo       int     // Link the movement goes to
+	ToLaneNo       int     // Lane of the link the movement goes to
+	TSysSet        string  // Transport systems allowed to make the movement
+	SBAMergeWeight float64 // Merge weight for simulation-based assignment
+}
+
+// JunctionKey returns the key of the junction the lane turn belongs to
+func (turn LaneTurn) JunctionKey() JunctionKey {
+	return JunctionKey{NodeNo: turn.NodeNo, MainNodeNo: turn.MainNodeNo}
+}
+
+// GetLaneTurnsByJunction retrieves all lane turns of a node or main node
+func (s *LaneTurnSection) GetLaneTurnsByJunction(key JunctionKey) []LaneTurn {
+	return s.byJunction.find(s.LaneTurns, key, LaneTurn.JunctionKey)
+}
+
+// GetLaneTurnsByTurn retrieves the lane turns of a node or main node making the movement from one link to another
+func (s *LaneTurnSection) GetLaneTurnsByTurn(key JunctionKey, fromLinkNo, toLinkNo int) []LaneTurn {
+	var result []LaneTurn
+	for _, i := range s.byJunction.lookup(s.LaneTurns, key, LaneTurn.JunctionKey) {
+		if s.LaneTurns[i].FromLinkNo == fromLinkNo && s.LaneTurns[i].ToLinkNo == toLinkNo {
+			result = append(result, s.LaneTurns[i])
+		}
+	}
+	return result
+}
+
+// GetTurn retrieves the turn a lane turn of a node belongs to, found through the links at the node.
+// Lane turns of main nodes have no turn
+func (s *LaneTurnSection) GetTurn(turn LaneTurn, data *PTVData) (Turn, bool) {
+	if turn.NodeNo == 0 || data.Link == nil || data.Turn == nil {
+		return Turn{}, false
+	}
+	fromNodeNo := 0
+	for _, link := range data.Link.GetLinksByToNode(turn.NodeNo) {
+		if link.No == turn.FromLinkNo {
+			fromNodeNo = link.FromNodeNo
+			break
+		}
+	}
+	toLink, ok := data.Link.GetLinkByFromNode(turn.ToLinkNo, turn.NodeNo)
+	if fromNodeNo == 0 || !ok {
+		return Turn{}, false
+	}
+	return data.Turn.GetTurn(fromNodeNo, turn.NodeNo, toLink.ToNodeNo)
+}
+
+// Reindex drops the lookup index after nodes of lane turns have been changed in place, it is built again on next use
+func (s *LaneTurnSection) Reindex() {
+	s.byJunction.reset()
+}
+
+// Count returns the number of lane turns in the section
+func (s *LaneTurnSection) Count() int {
+	return len(s.LaneTurns)
+}
+
+// getLaneTurn extracts data from LANETURN section row
+func getLaneTurn(values []string, headers []string) (LaneTurn, error) {
+	var turn LaneTurn
+	var err error
+
+	// Parse NODENO and MAINNODENO (optional, one of them identifies the junction)
+	if value := columnValue(values, headers, "NODENO", 0); value != "" {
+		turn.NodeNo, err = strconv.Atoi(value)
+		if err != nil {
+			return LaneTurn{}, parseFieldError("NODENO", err)
+		}
+	}
+	if value := columnValue(values, headers, "MAINNODENO", 1); value != "" {
+		turn.MainNodeNo, err = strconv.Atoi(value)
+		if err != nil {
+			return LaneTurn{}, parseFieldError("MAINNODENO", err)
+		}
+	}
+
+	// Parse FROMLINKNO, FROMLANENO, TOLINKNO and TOLANENO (required fields)
+	requiredFields := []struct {
+		index int
+		dest  *int
+		name  string
+	}{
+		{2, &turn.FromLinkNo, "FROMLINKNO"},
+		{3, &turn.FromLaneNo, "FROMLANENO"},
+		{4, &turn.ToLinkNo, "TOLINKNO"},
+		{5, &turn.ToLaneNo, "TOLANENO"},
+	}
+	for _, field := range requiredFields {
+		value := columnValue(values, headers, field.name, field.index)
+		if value == "" {
+			return LaneTurn{}, missingFieldError(field.name)
+		}
+		*field.dest, err = strconv.Atoi(value)
+		if err != nil {
+			return LaneTurn{}, parseFieldError(field.name, err)
+		}
+	}
+
+	turn.TSysSet = columnValue(values, headers, "TSYSSET", 6)
+
+	// Parse SBAMERGEWEIGHT (optional)
+	if value := columnValue(values, headers, "SBAMERGEWEIGHT", 7); value != "" {
+		turn.SBAMergeWeight, err = strconv.ParseFloat(strings.Replace(value, ",", ".", -1), 64)
+		if err != nil {
+			return LaneTurn{}, parseFieldError("SBAMERGEWEIGHT", err)
+		}
+	}
+
+	return turn, nil
+}
+
+// laneTurnColumns are the columns written when the section has no headers
+var laneTurnColumns = []string{
+	"NODENO", "MAINNODENO", "FROMLINKNO", "FROMLANENO", "TOLINKNO", "TOLANENO", "TSYSSET", "SBAMERGEWEIGHT",
+}
+
+func (turn LaneTurn) attribute(column string) (string, bool) {
+	switch column {
+	case "NODENO":
+		return formatInt(turn.NodeNo), true
+	case "MAINNODENO":
+		return formatInt(turn.MainNodeNo), true
+	case "FROMLINKNO":
+		return formatInt(turn.FromLinkNo), true
+	case "FROMLANENO":
+		return formatInt(turn.FromLaneNo), true
+	case "TOLINKNO":
+		return formatInt(turn.ToLinkNo), true
+	case "TOLANENO":
+		return formatInt(turn.ToLaneNo), true
+	case "TSYSSET":
+		return turn.TSysSet, true
+	case "SBAMERGEWEIGHT":
+		return formatFloat(turn.SBAMergeWeight), true
+	}
+	return "", false
+}
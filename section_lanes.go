@@ -0,0 +1,168 @@
+package ptvvisum
+
+import (
+	"strconv"
+	"strings"
+)
+
+// LaneSection represents $LANE section
+type LaneSection struct {
+	BaseSection
+	Lanes []Lane
+
+	byJunction index[Lane, JunctionKey]
+}
+
+// Lane represents a lane of a link at a node or main node
+type Lane struct {
+	NodeNo       int     // Node of the lane, 0 for lanes of main nodes
+	MainNodeNo   int     // Main node of the lane, 0 for lanes of nodes
+	LinkNo       int     // Link the lane belongs to
+	No           int     // Lane number, counted from the right
+	AppType      int     // Whether the lane leads towards (1) or away from (0) the junction
+	OriginLaneNo int     // Lane the lane branches off from, 0 for full lanes
+	Width        Length  // Lane width
+	Length       Length  // Length of pockets, 0 for full lanes
+	TSysSet      string  // Transport systems allowed on the lane
+	NumVehicles  float64 // Number of vehicles fitting on the lane
+}
+
+// JunctionKey returns the key of the junction the lane belongs to
+func (lane Lane) JunctionKey() JunctionKey {
+	return JunctionKey{NodeNo: lane.NodeNo, MainNodeNo: lane.MainNodeNo}
+}
+
+// GetLanesByJunction retrieves all lanes of a node or main node
+func (s *LaneSection) GetLanesByJunction(key JunctionKey) []Lane {
+	return s.byJunction.find(s.Lanes, key, Lane.JunctionKey)
+}
+
+// GetLane retrieves a lane of a link at a node or main node
+func (s *LaneSection) GetLane(key JunctionKey, linkNo, no int) (Lane, bool) {
+	for _, i := range s.byJunction.lookup(s.Lanes, key, Lane.JunctionKey) {
+		if s.Lanes[i].LinkNo == linkNo && s.Lanes[i].No == no {
+			return s.Lanes[i], true
+		}
+	}
+	return Lane{}, false
+}
+
+// Reindex drops the lookup index after nodes of lanes have been changed in place, it is built again on next use
+func (s *LaneSection) Reindex() {
+	s.byJunction.reset()
+}
+
+// Count returns the number of lanes in the section
+func (s *LaneSection) Count() int {
+	return len(s.Lanes)
+}
+
+// getLane extracts data from LANE section row.
+// Attributes of the intersection capacity analysis (ICA...) are kept untyped
+func getLane(values []string, headers []string) (Lane, error) {
+	var lane Lane
+	var err error
+
+	// Parse NODENO and MAINNODENO (optional, one of them identifies the junction)
+	if value := columnValue(values, headers, "NODENO", 0); value != "" {
+		lane.NodeNo, err = strconv.Atoi(value)
+		if err != nil {
+			return Lane{}, parseFieldError("NODENO", err)
+		}
+	}
+	if value := columnValue(values, headers, "MAINNODENO", 1); value != "" {
+		lane.MainNodeNo, err = strconv.Atoi(value)
+		if err != nil {
+			return Lane{}, parseFieldError("MAINNODENO", err)
+		}
+	}
+
+	// Parse LINKNO and NO (required fields)
+	value := columnValue(values, headers, "LINKNO", 2)
+	if value == "" {
+		return Lane{}, missingFieldError("LINKNO")
+	}
+	lane.LinkNo, err = strconv.Atoi(value)
+	if err != nil {
+		return Lane{}, parseFieldError("LINKNO", err)
+	}
+	value = columnValue(values, headers, "NO", 3)
+	if value == "" {
+		return Lane{}, missingFieldError("NO")
+	}
+	lane.No, err = strconv.Atoi(value)
+	if err != nil {
+		return Lane{}, parseFieldError("NO", err)
+	}
+
+	// Parse APPTYPE and ORIGINLANENO (optional)
+	if value := columnValue(values, headers, "APPTYPE", 4); value != "" {
+		lane.AppType, err = strconv.Atoi(value)
+		if err != nil {
+			return Lane{}, parseFieldError("APPTYPE", err)
+		}
+	}
+	if value := columnValue(values, headers, "ORIGINLANENO", 5); value != "" {
+		lane.OriginLaneNo, err = strconv.Atoi(value)
+		if err != nil {
+			return Lane{}, parseFieldError("ORIGINLANENO", err)
+		}
+	}
+
+	// Parse WIDTH and LENGTH (optional)
+	if value := columnValue(values, headers, "WIDTH", 6); value != "" {
+		lane.Width, err = parseLength(value)
+		if err != nil {
+			return Lane{}, parseFieldError("WIDTH", err)
+		}
+	}
+	if value := columnValue(values, headers, "LENGTH", 7); value != "" {
+		lane.Length, err = parseLength(value)
+		if err != nil {
+			return Lane{}, parseFieldError("LENGTH", err)
+		}
+	}
+
+	lane.TSysSet = columnValue(values, headers, "TSYSSET", 8)
+
+	// Parse NUMVEHICLES (optional)
+	if value := columnValue(values, headers, "NUMVEHICLES", 9); value != "" {
+		lane.NumVehicles, err = strconv.ParseFloat(strings.Replace(value, ",", ".", -1), 64)
+		if err != nil {
+			return Lane{}, parseFieldError("NUMVEHICLES", err)
+		}
+	}
+
+	return lane, nil
+}
+
+// laneColumns are the columns written when the section has no headers
+var laneColumns = []string{
+	"NODENO", "MAINNODENO", "LINKNO", "NO", "APPTYPE", "ORIGINLANENO", "WIDTH", "LENGTH", "TSYSSET", "NUMVEHICLES",
+}
+
+func (lane Lane) attribute(column string) (string, bool) {
+	switch column {
+	case "NODENO":
+		return formatInt(lane.NodeNo), true
+	case "MAINNODENO":
+		return formatInt(lane.MainNodeNo), true
+	case "LINKNO":
+		return formatInt(lane.LinkNo), true
+	case "NO":
+		return formatInt(lane.No), true
+	case "APPTYPE":
+		return formatInt(lane.AppType), true
+	case "ORIGINLANENO":
+		return formatInt(lane.OriginLaneNo), true
+	case "WIDTH":
+		return lane.Width.String(), true
+	case "LENGTH":
+		return lane.Length.String(), true
+	case "TSYSSET":
+		return lane.TSysSet, true
+	case "NUMVEHICLES":
+		return formatFloat(lane.NumVehicles), true
+	}
+	return "", false
+}
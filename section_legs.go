@@ -0,0 +1,156 @@
+package ptvvisum
+
+import "strconv"
+
+// LegSection represents $LEG section
+type LegSection struct {
+	BaseSection
+	Legs []Leg
+
+	byJunction index[Leg, JunctionKey]
+}
+
+// Leg represents an arm of a node or main node, identified by its orientation
+type Leg struct {
+	NodeNo             int    // Node of the leg, 0 for legs of main nodes
+	MainNodeNo         int    // Main node of the leg, 0 for legs of nodes
+	Orientation        string // Orientation of the leg, e.g. N or SE
+	StopLinePos        Length // Position of the stop line
+	HasCIsland         int    // Whether the leg has a central island (0/1)
+	CIslandWidth       Length // Width of the central island
+	CIslandLen         Length // Length of the central island
+	LateralOffset      Length // Lateral offset of the leg
+	IsChannelized      int    // Whether the leg has a channelized turn (0/1)
+	ChannTurnLen       Length // Length of the channelized turn
+	ChannelizedControl string // Control of the channelized turn, e.g. SIGNAL
+}
+
+// JunctionKey returns the key of the junction the leg belongs to
+func (leg Leg) JunctionKey() JunctionKey {
+	return JunctionKey{NodeNo: leg.NodeNo, MainNodeNo: leg.MainNodeNo}
+}
+
+// GetLegsByJunction retrieves all legs of a node or main node
+func (s *LegSection) GetLegsByJunction(key JunctionKey) []Leg {
+	return s.byJunction.find(s.Legs, key, Leg.JunctionKey)
+}
+
+// GetLeg retrieves the leg of a node or main node with the given orientation
+func (s *LegSection) GetLeg(key JunctionKey, orientation string) (Leg, bool) {
+	for _, i := range s.byJunction.lookup(s.Legs, key, Leg.JunctionKey) {
+		if s.Legs[i].Orientation == orientation {
+			return s.Legs[i], true
+		}
+	}
+	return Leg{}, false
+}
+
+// Reindex drops the lookup index after nodes of legs have been changed in place, it is built again on next use
+func (s *LegSection) Reindex() {
+	s.byJunction.reset()
+}
+
+// Count returns the number of legs in the section
+func (s *LegSection) Count() int {
+	return len(s.Legs)
+}
+
+// getLeg extracts data from LEG section row.
+// Attributes of the intersection capacity analysis (ICA...) and roundabouts are kept untyped
+func getLeg(values []string, headers []string) (Leg, error) {
+	var leg Leg
+	var err error
+
+	// Parse NODENO and MAINNODENO (optional, one of them identifies the junction)
+	if value := columnValue(values, headers, "NODENO", 0); value != "" {
+		leg.NodeNo, err = strconv.Atoi(value)
+		if err != nil {
+			return Leg{}, parseFieldError("NODENO", err)
+		}
+	}
+	if value := columnValue(values, headers, "MAINNODENO", 1); value != "" {
+		leg.MainNodeNo, err = strconv.Atoi(value)
+		if err != nil {
+			return Leg{}, parseFieldError("MAINNODENO", err)
+		}
+	}
+
+	// Parse ORIENTATION (required field)
+	leg.Orientation = columnValue(values, headers, "ORIENTATION", 2)
+	if leg.Orientation == "" {
+		return Leg{}, missingFieldError("ORIENTATION")
+	}
+
+	// Parse lengths (optional)
+	lengthFields := []struct {
+		index int
+		dest  *Length
+		name  string
+	}{
+		{3, &leg.StopLinePos, "STOPLINEPOS"},
+		{5, &leg.CIslandWidth, "CISLANDWIDTH"},
+		{6, &leg.CIslandLen, "CISLANDLEN"},
+		{7, &leg.LateralOffset, "LATERALOFFSET"},
+		{9, &leg.ChannTurnLen, "CHANNTURNLEN"},
+	}
+	for _, field := range lengthFields {
+		if value := columnValue(values, headers, field.name, field.index); value != "" {
+			*field.dest, err = parseLength(value)
+			if err != nil {
+				return Leg{}, parseFieldError(field.name, err)
+			}
+		}
+	}
+
+	// Parse HASCISLAND and ISCHANNELIZED (optional)
+	if value := columnValue(values, headers, "HASCISLAND", 4); value != "" {
+		leg.HasCIsland, err = strconv.Atoi(value)
+		if err != nil {
+			return Leg{}, parseFieldError("HASCISLAND", err)
+		}
+	}
+	if value := columnValue(values, headers, "ISCHANNELIZED", 8); value != "" {
+		leg.IsChannelized, err = strconv.Atoi(value)
+		if err != nil {
+			return Leg{}, parseFieldError("ISCHANNELIZED", err)
+		}
+	}
+
+	leg.ChannelizedControl = columnValue(values, headers, "CHANNELIZEDCONTROL", 10)
+
+	return leg, nil
+}
+
+// legColumns are the columns written when the section has no headers
+var legColumns = []string{
+	"NODENO", "MAINNODENO", "ORIENTATION", "STOPLINEPOS", "HASCISLAND", "CISLANDWIDTH", "CISLANDLEN", "LATERALOFFSET",
+	"ISCHANNELIZED", "CHANNTURNLEN", "CHANNELIZEDCONTROL",
+}
+
+func (leg Leg) attribute(column string) (string, bool) {
+	switch column {
+	case "NODENO":
+		return formatInt(leg.NodeNo), true
+	case "MAINNODENO":
+		return formatInt(leg.MainNodeNo), true
+	case "ORIENTATION":
+		return leg.Orientation, true
+	case "STOPLINEPOS":
+		return leg.StopLinePos.String(), true
+	case "HASCISLAND":
+		return formatInt(leg.HasCIsland), true
+	case "CISLANDWIDTH":
+		return leg.CIslandWidth.String(), true
+	case "CISLANDLEN":
+		return leg.CIslandLen.String(), true
+	case "LATERALOFFSET":
+		return leg.LateralOffset.String(), true
+	case "ISCHANNELIZED":
+		return formatInt(leg.IsChannelized), true
+	case "CHANNTURNLEN":
+		return leg.ChannTurnLen.String(), true
+	case "CHANNELIZEDCONTROL":
+		return leg.ChannelizedControl, true
+	}
+	return "", false
+}
@@ -0,0 +1,212 @@
+package ptvvisum
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+)
+
+// LineRouteItemSection represents $LINEROUTEITEM section
+type LineRouteItemSection struct {
+	BaseSection
+	Items []LineRouteItem
+
+	byLineRoute index[LineRouteItem, LineRouteKey]
+}
+
+// LineRouteItem represents a node or a stop point passed by a line route
+type LineRouteItem struct {
+	LineName      string // Name of the line
+	LineRouteName string // Name of the line route
+	DirectionCode string // Code of the direction of the line route
+	Index         int    // Position of the item in the route
+	IsRoutePoint  int    // Whether the item is a route point (0/1), time profiles refer to route points only
+	NodeNo        int    // Node passed, 0 for items on link-based stop points
+	StopPointNo   int    // Stop point passed, 0 if the item is not a stop point
+	PostLength    Length // Distance to the next item
+	AddVal        int    // Additional value
+}
+
+// LineRouteKey returns the key of the line route the item belongs to
+func (item LineRouteItem) LineRouteKey() LineRouteKey {
+	return LineRouteKey{LineName: item.LineName, LineRouteName: item.LineRouteName, DirectionCode: item.DirectionCode}
+}
+
+// GetItemsByLineRoute retrieves all items of a line route in route order
+func (s *LineRouteItemSection) GetItemsByLineRoute(key LineRouteKey) []LineRouteItem {
+	result := s.byLineRoute.find(s.Items, key, LineRouteItem.LineRouteKey)
+
+	// Sort by index to ensure correct order
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Index < result[j].Index
+	})
+
+	return result
+}
+
+// GetItem retrieves the item of a line route at the given index
+func (s *LineRouteItemSection) GetItem(key LineRouteKey, index int) (LineRouteItem, bool) {
+	for _, i := range s.byLineRoute.lookup(s.Items, key, LineRouteItem.LineRouteKey) {
+		if s.Items[i].Index == index {
+			return s.Items[i], true
+		}
+	}
+	return LineRouteItem{}, false
+}
+
+// GetNodeSequence returns the nodes passed by a line route in route order
+func (s *LineRouteItemSection) GetNodeSequence(key LineRouteKey) []int {
+	var nodes []int
+	for _, item := range s.GetItemsByLineRoute(key) {
+		if item.NodeNo != 0 {
+			nodes = append(nodes, item.NodeNo)
+		}
+	}
+	return nodes
+}
+
+// GetStopPointSequence returns the stop points passed by a line route in route order
+func (s *LineRouteItemSection) GetStopPointSequence(key LineRouteKey) []int {
+	var stopPoints []int
+	for _, item := range s.GetItemsByLineRoute(key) {
+		if item.StopPointNo != 0 {
+			stopPoints = append(stopPoints, item.StopPointNo)
+		}
+	}
+	return stopPoints
+}
+
+// GetLinks returns the links a line route travels over in route order.
+// An error is returned when two consecutive nodes of the route are not connected by a link
+func (s *LineRouteItemSection) GetLinks(key LineRouteKey, data *PTVData) ([]Link, error) {
+	if data.Link == nil {
+		return nil, fmt.Errorf("no links found in the data")
+	}
+	nodes := s.GetNodeSequence(key)
+	var links []Link
+	for i := 1; i < len(nodes); i++ {
+		found := false
+		for _, link := range data.Link.GetLinksByFromNode(nodes[i-1]) {
+			if link.ToNodeNo == nodes[i] {
+				links = append(links, link)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("no link from node %d to node %d for line route %s %s %s", nodes[i-1], nodes[i], key.LineName, key.LineRouteName, key.DirectionCode)
+		}
+	}
+	return links, nil
+}
+
+// Reindex drops the lookup index after line routes of items have been changed in place, it is built again on next use
+func (s *LineRouteItemSection) Reindex() {
+	s.byLineRoute.reset()
+}
+
+// Count returns the number of line route items in the section
+func (s *LineRouteItemSection) Count() int {
+	return len(s.Items)
+}
+
+// getLineRouteItem extracts data from LINEROUTEITEM section row
+func getLineRouteItem(values []string, headers []string) (LineRouteItem, error) {
+	var item LineRouteItem
+	var err error
+
+	// Parse LINENAME, LINEROUTENAME and DIRECTIONCODE (required fields)
+	item.LineName = columnValue(values, headers, "LINENAME", 0)
+	if item.LineName == "" {
+		return LineRouteItem{}, missingFieldError("LINENAME")
+	}
+	item.LineRouteName = columnValue(values, headers, "LINEROUTENAME", 1)
+	if item.LineRouteName == "" {
+		return LineRouteItem{}, missingFieldError("LINEROUTENAME")
+	}
+	item.DirectionCode = columnValue(values, headers, "DIRECTIONCODE", 2)
+	if item.DirectionCode == "" {
+		return LineRouteItem{}, missingFieldError("DIRECTIONCODE")
+	}
+
+	// Parse INDEX (required field)
+	value := columnValue(values, headers, "INDEX", 3)
+	if value == "" {
+		return LineRouteItem{}, missingFieldError("INDEX")
+	}
+	item.Index, err = strconv.Atoi(value)
+	if err != nil {
+		return LineRouteItem{}, parseFieldError("INDEX", err)
+	}
+
+	// Parse ISROUTEPOINT (optional)
+	if value := columnValue(values, headers, "ISROUTEPOINT", 4); value != "" {
+		item.IsRoutePoint, err = strconv.Atoi(value)
+		if err != nil {
+			return LineRouteItem{}, parseFieldError("ISROUTEPOINT", err)
+		}
+	}
+
+	// Parse NODENO (optional)
+	if value := columnValue(values, headers, "NODENO", 5); value != "" {
+		item.NodeNo, err = strconv.Atoi(value)
+		if err != nil {
+			return LineRouteItem{}, parseFieldError("NODENO", err)
+		}
+	}
+
+	// Parse STOPPOINTNO (optional)
+	if value := columnValue(values, headers, "STOPPOINTNO", 6); value != "" {
+		item.StopPointNo, err = strconv.Atoi(value)
+		if err != nil {
+			return LineRouteItem{}, parseFieldError("STOPPOINTNO", err)
+		}
+	}
+
+	// Parse POSTLENGTH (optional)
+	if value := columnValue(values, headers, "POSTLENGTH", 7); value != "" {
+		item.PostLength, err = parseLength(value)
+		if err != nil {
+			return LineRouteItem{}, parseFieldError("POSTLENGTH", err)
+		}
+	}
+
+	// Parse ADDVAL (optional)
+	if value := columnValue(values, headers, "ADDVAL", 8); value != "" {
+		item.AddVal, err = strconv.Atoi(value)
+		if err != nil {
+			return LineRouteItem{}, parseFieldError("ADDVAL", err)
+		}
+	}
+
+	return item, nil
+}
+
+// lineRouteItemColumns are the columns written when the section has no headers
+var lineRouteItemColumns = []string{
+	"LINENAME", "LINEROUTENAME", "DIRECTIONCODE", "INDEX", "ISROUTEPOINT", "NODENO", "STOPPOINTNO", "POSTLENGTH", "ADDVAL",
+}
+
+func (item LineRouteItem) attribute(column string) (string, bool) {
+	switch column {
+	case "LINENAME":
+		return item.LineName, true
+	case "LINEROUTENAME":
+		return item.LineRouteName, true
+	case "DIRECTIONCODE":
+		return item.DirectionCode, true
+	case "INDEX":
+		return formatInt(item.Index), true
+	case "ISROUTEPOINT":
+		return formatInt(item.IsRoutePoint), true
+	case "NODENO":
+		return formatOptionalInt(item.NodeNo), true
+	case "STOPPOINTNO":
+		return formatOptionalInt(item.StopPointNo), true
+	case "POSTLENGTH":
+		return item.PostLength.String(), true
+	case "ADDVAL":
+		return formatInt(item.AddVal), true
+	}
+	return "", false
+}
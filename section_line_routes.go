@@ -0,0 +1,135 @@
+package ptvvisum
+
+import "strconv"
+
+// LineRouteSection represents $LINEROUTE section
+type LineRouteSection struct {
+	BaseSection
+	LineRoutes []LineRoute
+
+	byKey  index[LineRoute, LineRouteKey]
+	byLine index[LineRoute, string]
+}
+
+// LineRouteKey identifies a line route, it is referenced by line route items, time profiles and vehicle journeys
+type LineRouteKey struct {
+	LineName      string // Name of the line
+	LineRouteName string // Name of the line route
+	DirectionCode string // Code of the direction, e.g. ">" or "<"
+}
+
+// LineRoute represents a course of a line in one direction
+type LineRoute struct {
+	LineName      string // Name of the line the route belongs to
+	Name          string // Line route name
+	DirectionCode string // Code of the direction, e.g. ">" or "<"
+	IsCircleLine  int    // Whether the route is a circle line (0/1)
+	AddVal        [3]int // Additional values 1-3
+}
+
+// Key returns the key of the line route
+func (route LineRoute) Key() LineRouteKey {
+	return LineRouteKey{LineName: route.LineName, LineRouteName: route.Name, DirectionCode: route.DirectionCode}
+}
+
+// GetLineRoute retrieves a line route by its key
+func (s *LineRouteSection) GetLineRoute(key LineRouteKey) (LineRoute, bool) {
+	return s.byKey.findFirst(s.LineRoutes, key, LineRoute.Key)
+}
+
+// GetLineRoutesByLine retrieves all routes of a line
+func (s *LineRouteSection) GetLineRoutesByLine(lineName string) []LineRoute {
+	return s.byLine.find(s.LineRoutes, lineName, func(route LineRoute) string { return route.LineName })
+}
+
+// GetLine retrieves the line a line route belongs to
+func (s *LineRouteSection) GetLine(key LineRouteKey, data *PTVData) (Line, bool) {
+	route, found := s.GetLineRoute(key)
+	if !found || data.Line == nil {
+		return Line{}, false
+	}
+	return data.Line.GetLineByName(route.LineName)
+}
+
+// GetDirection retrieves the direction of a line route
+func (s *LineRouteSection) GetDirection(key LineRouteKey, data *PTVData) (Direction, bool) {
+	route, found := s.GetLineRoute(key)
+	if !found || data.Direction == nil {
+		return Direction{}, false
+	}
+	return data.Direction.GetDirectionByCode(route.DirectionCode)
+}
+
+// Reindex drops the lookup indexes after keys of line routes have been changed in place, they are built again on next use
+func (s *LineRouteSection) Reindex() {
+	s.byKey.reset()
+	s.byLine.reset()
+}
+
+// Count returns the number of line routes in the section
+func (s *LineRouteSection) Count() int {
+	return len(s.LineRoutes)
+}
+
+// getLineRoute extracts data from LINEROUTE section row
+func getLineRoute(values []string, headers []string) (LineRoute, error) {
+	var route LineRoute
+	var err error
+
+	// Parse LINENAME, NAME and DIRECTIONCODE (required fields)
+	route.LineName = columnValue(values, headers, "LINENAME", 0)
+	if route.LineName == "" {
+		return LineRoute{}, missingFieldError("LINENAME")
+	}
+	route.Name = columnValue(values, headers, "NAME", 1)
+	if route.Name == "" {
+		return LineRoute{}, missingFieldError("NAME")
+	}
+	route.DirectionCode = columnValue(values, headers, "DIRECTIONCODE", 2)
+	if route.DirectionCode == "" {
+		return LineRoute{}, missingFieldError("DIRECTIONCODE")
+	}
+
+	// Parse ISCIRCLELINE (optional)
+	if value := columnValue(values, headers, "ISCIRCLELINE", 3); value != "" {
+		route.IsCircleLine, err = strconv.Atoi(value)
+		if err != nil {
+			return LineRoute{}, parseFieldError("ISCIRCLELINE", err)
+		}
+	}
+
+	// Parse ADDVAL1, ADDVAL2, ADDVAL3 (optional)
+	for i, column := range []string{"ADDVAL1", "ADDVAL2", "ADDVAL3"} {
+		if value := columnValue(values, headers, column, 4+i); value != "" {
+			route.AddVal[i], err = strconv.Atoi(value)
+			if err != nil {
+				return LineRoute{}, parseFieldError(column, err)
+			}
+		}
+	}
+
+	return route, nil
+}
+
+// lineRouteColumns are the columns written when the section has no headers
+var lineRouteColumns = []string{"LINENAME", "NAME", "DIRECTIONCODE", "ISCIRCLELINE", "ADDVAL1", "ADDVAL2", "ADDVAL3"}
+
+func (route LineRoute) attribute(column string) (string, bool) {
+	switch column {
+	case "LINENAME":
+		return route.LineName, true
+	case "NAME":
+		return route.Name, true
+	case "DIRECTIONCODE":
+		return route.DirectionCode, true
+	case "ISCIRCLELINE":
+		return formatInt(route.IsCircleLine), true
+	case "ADDVAL1":
+		return formatInt(route.AddVal[0]), true
+	case "ADDVAL2":
+		return formatInt(route.AddVal[1]), true
+	case "ADDVAL3":
+		return formatInt(route.AddVal[2]), true
+	}
+	return "", false
+}
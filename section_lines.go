@@ -0,0 +1,141 @@
+package ptvvisum
+
+import "strconv"
+
+// LineSection represents $LINE section
+type LineSection struct {
+	BaseSection
+	Lines []Line
+
+	byName index[Line, string]
+}
+
+// Line represents a public transport line, the top level of the line hierarchy (line → line route → line route item)
+type Line struct {
+	Name          string // Line name, the key of the line
+	TSysCode      string // Transport system of the line
+	VehCombNo     int    // Default vehicle combination, 0 if not set
+	FareSystemSet string // Fare systems of the line
+	OperatorNo    int    // Operator number, 0 if not set
+	MainLineName  string // Main line the line belongs to
+	AddVal        [3]int // Additional values 1-3
+}
+
+// GetLineByName retrieves a line by its name
+func (s *LineSection) GetLineByName(name string) (Line, bool) {
+	return s.byName.findFirst(s.Lines, name, func(line Line) string { return line.Name })
+}
+
+// GetLinesByTransportSystem retrieves all lines of a specific transport system
+func (s *LineSection) GetLinesByTransportSystem(tsys string) []Line {
+	var result []Line
+	for _, line := range s.Lines {
+		if line.TSysCode == tsys {
+			result = append(result, line)
+		}
+	}
+	return result
+}
+
+// GetTransportSystem retrieves the transport system of a line
+func (s *LineSection) GetTransportSystem(lineName string, data *PTVData) (TransportSystem, bool) {
+	line, found := s.GetLineByName(lineName)
+	if !found || data.TSys == nil {
+		return TransportSystem{}, false
+	}
+	return data.TSys.GetTransportSystemByCode(line.TSysCode)
+}
+
+// GetVehicleCombination retrieves the default vehicle combination of a line
+func (s *LineSection) GetVehicleCombination(lineName string, data *PTVData) (VehicleCombination, bool) {
+	line, found := s.GetLineByName(lineName)
+	if !found || data.VehComb == nil {
+		return VehicleCombination{}, false
+	}
+	return data.VehComb.GetVehicleCombinationByID(line.VehCombNo)
+}
+
+// Reindex drops the lookup index after names of lines have been changed in place, it is built again on next use
+func (s *LineSection) Reindex() {
+	s.byName.reset()
+}
+
+// Count returns the number of lines in the section
+func (s *LineSection) Count() int {
+	return len(s.Lines)
+}
+
+// getLine extracts data from LINE section row
+func getLine(values []string, headers []string) (Line, error) {
+	var line Line
+	var err error
+
+	// Parse NAME (required field)
+	line.Name = columnValue(values, headers, "NAME", 0)
+	if line.Name == "" {
+		return Line{}, missingFieldError("NAME")
+	}
+
+	line.TSysCode = columnValue(values, headers, "TSYSCODE", 1)
+
+	// Parse VEHCOMBNO (optional)
+	if value := columnValue(values, headers, "VEHCOMBNO", 2); value != "" {
+		line.VehCombNo, err = strconv.Atoi(value)
+		if err != nil {
+			return Line{}, parseFieldError("VEHCOMBNO", err)
+		}
+	}
+
+	line.FareSystemSet = columnValue(values, headers, "FARESYSTEMSET", 3)
+
+	// Parse OPERATORNO (optional)
+	if value := columnValue(values, headers, "OPERATORNO", 4); value != "" {
+		line.OperatorNo, err = strconv.Atoi(value)
+		if err != nil {
+			return Line{}, parseFieldError("OPERATORNO", err)
+		}
+	}
+
+	line.MainLineName = columnValue(values, headers, "MAINLINENAME", 5)
+
+	// Parse ADDVAL1, ADDVAL2, ADDVAL3 (optional)
+	for i, column := range []string{"ADDVAL1", "ADDVAL2", "ADDVAL3"} {
+		if value := columnValue(values, headers, column, 6+i); value != "" {
+			line.AddVal[i], err = strconv.Atoi(value)
+			if err != nil {
+				return Line{}, parseFieldError(column, err)
+			}
+		}
+	}
+
+	return line, nil
+}
+
+// lineColumns are the columns written when the section has no headers
+var lineColumns = []string{
+	"NAME", "TSYSCODE", "VEHCOMBNO", "FARESYSTEMSET", "OPERATORNO", "MAINLINENAME", "ADDVAL1", "ADDVAL2", "ADDVAL3",
+}
+
+func (line Line) attribute(column string) (string, bool) {
+	switch column {
+	case "NAME":
+		return line.Name, true
+	case "TSYSCODE":
+		return line.TSysCode, true
+	case "VEHCOMBNO":
+		return formatOptionalInt(line.VehCombNo), true
+	case "FARESYSTEMSET":
+		return line.FareSystemSet, true
+	case "OPERATORNO":
+		return formatOptionalInt(line.OperatorNo), true
+	case "MAINLINENAME":
+		return line.MainLineName, true
+	case "ADDVAL1":
+		return formatInt(line.AddVal[0]), true
+	case "ADDVAL2":
+		return formatInt(line.AddVal[1]), true
+	case "ADDVAL3":
+		return formatInt(line.AddVal[2]), true
+	}
+	return "", false
+}
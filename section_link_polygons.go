@@ -1,7 +1,6 @@
 package ptvvisum
 
 import (
-	"fmt"
 	"math"
 	"sort"
 	"strconv"
@@ -12,6 +11,8 @@ import (
 type LinkPolySection struct {
 	BaseSection
 	Points []LinkPolyPoint
+
+	byLink index[LinkPolyPoint, [2]int]
 }
 
 // LinkPolyPoint represents a single point in a link polygon
@@ -26,12 +27,7 @@ type LinkPolyPoint struct {
 
 // GetPointsByLink retrieves all points for a specific link (from node to node)
 func (s *LinkPolySection) GetPointsByLink(fromNodeNo, toNodeNo int) []LinkPolyPoint {
-	var result []LinkPolyPoint
-	for _, point := range s.Points {
-		if point.FromNodeNo == fromNodeNo && point.ToNodeNo == toNodeNo {
-			result = append(result, point)
-		}
-	}
+	result := s.byLink.find(s.Points, [2]int{fromNodeNo, toNodeNo}, linkPolyLink)
 
 	// Sort by index to ensure proper order
 	sort.Slice(result, func(i, j int) bool {
@@ -94,12 +90,7 @@ func (s *LinkPolySection) GetAllLinks() [][2]int {
 
 // HasLinkGeometry checks if detailed geometry exists for a specific link
 func (s *LinkPolySection) HasLinkGeometry(fromNodeNo, toNodeNo int) bool {
-	for _, point := range s.Points {
-		if point.FromNodeNo == fromNodeNo && point.ToNodeNo == toNodeNo {
-			return true
-		}
-	}
-	return false
+	return len(s.byLink.lookup(s.Points, [2]int{fromNodeNo, toNodeNo}, linkPolyLink)) > 0
 }
 
 // GetBoundingBox returns the min/max coordinates of all link polygon points
@@ -139,6 +130,13 @@ func (s *LinkPolySection) GetBoundingBox() (minX, minY, minZ, maxX, maxY, maxZ f
 	return minX, minY, minZ, maxX, maxY, maxZ
 }
 
+// Reindex drops the lookup index after nodes of points have been changed in place, it is built again on next use
+func (s *LinkPolySection) Reindex() {
+	s.byLink.reset()
+}
+
+func linkPolyLink(point LinkPolyPoint) [2]int { return [2]int{point.FromNodeNo, point.ToNodeNo} }
+
 // Count returns the number of link polygon points in the section
 func (s *LinkPolySection) Count() int {
 	return len(s.Points)
@@ -150,67 +148,88 @@ func (s *LinkPolySection) CountLinks() int {
 }
 
 // getLinkPolyPoint extracts data from LINKPOLY section row
-func getLinkPolyPoint(values []string) (LinkPolyPoint, error) {
-	if len(values) < 6 {
-		return LinkPolyPoint{}, fmt.Errorf("invalid LINKPOLY data (insufficient fields): %v", values)
-	}
-
+func getLinkPolyPoint(values []string, headers []string) (LinkPolyPoint, error) {
 	var point LinkPolyPoint
 	var err error
 
 	// Parse FROMNODENO (required field)
-	if values[0] == "" {
-		return LinkPolyPoint{}, fmt.Errorf("missing required field FROMNODENO")
+	value := columnValue(values, headers, "FROMNODENO", 0)
+	if value == "" {
+		return LinkPolyPoint{}, missingFieldError("FROMNODENO")
 	}
-	point.FromNodeNo, err = strconv.Atoi(values[0])
+	point.FromNodeNo, err = strconv.Atoi(value)
 	if err != nil {
-		return LinkPolyPoint{}, fmt.Errorf("error parsing FROMNODENO: %w", err)
+		return LinkPolyPoint{}, parseFieldError("FROMNODENO", err)
 	}
 
 	// Parse TONODENO (required field)
-	if values[1] == "" {
-		return LinkPolyPoint{}, fmt.Errorf("missing required field TONODENO")
+	value = columnValue(values, headers, "TONODENO", 1)
+	if value == "" {
+		return LinkPolyPoint{}, missingFieldError("TONODENO")
 	}
-	point.ToNodeNo, err = strconv.Atoi(values[1])
+	point.ToNodeNo, err = strconv.Atoi(value)
 	if err != nil {
-		return LinkPolyPoint{}, fmt.Errorf("error parsing TONODENO: %w", err)
+		return LinkPolyPoint{}, parseFieldError("TONODENO", err)
 	}
 
 	// Parse INDEX (required field)
-	if values[2] == "" {
-		return LinkPolyPoint{}, fmt.Errorf("missing required field INDEX")
+	value = columnValue(values, headers, "INDEX", 2)
+	if value == "" {
+		return LinkPolyPoint{}, missingFieldError("INDEX")
 	}
-	point.Index, err = strconv.Atoi(values[2])
+	point.Index, err = strconv.Atoi(value)
 	if err != nil {
-		return LinkPolyPoint{}, fmt.Errorf("error parsing INDEX: %w", err)
+		return LinkPolyPoint{}, parseFieldError("INDEX", err)
 	}
 
 	// Parse XCOORD (required field)
-	if values[3] == "" {
-		return LinkPolyPoint{}, fmt.Errorf("missing required field XCOORD")
+	value = columnValue(values, headers, "XCOORD", 3)
+	if value == "" {
+		return LinkPolyPoint{}, missingFieldError("XCOORD")
 	}
-	point.XCoord, err = strconv.ParseFloat(strings.Replace(values[3], ",", ".", -1), 64)
+	point.XCoord, err = strconv.ParseFloat(strings.Replace(value, ",", ".", -1), 64)
 	if err != nil {
-		return LinkPolyPoint{}, fmt.Errorf("error parsing XCOORD: %w", err)
+		return LinkPolyPoint{}, parseFieldError("XCOORD", err)
 	}
 
 	// Parse YCOORD (required field)
-	if values[4] == "" {
-		return LinkPolyPoint{}, fmt.Errorf("missing required field YCOORD")
+	value = columnValue(values, headers, "YCOORD", 4)
+	if value == "" {
+		return LinkPolyPoint{}, missingFieldError("YCOORD")
 	}
-	point.YCoord, err = strconv.ParseFloat(strings.Replace(values[4], ",", ".", -1), 64)
+	point.YCoord, err = strconv.ParseFloat(strings.Replace(value, ",", ".", -1), 64)
 	if err != nil {
-		return LinkPolyPoint{}, fmt.Errorf("error parsing YCOORD: %w", err)
+		return LinkPolyPoint{}, parseFieldError("YCOORD", err)
 	}
 
-	// Parse ZCOORD (required field)
-	if values[5] == "" {
-		return LinkPolyPoint{}, fmt.Errorf("missing required field ZCOORD")
-	}
-	point.ZCoord, err = strconv.ParseFloat(strings.Replace(values[5], ",", ".", -1), 64)
-	if err != nil {
-		return LinkPolyPoint{}, fmt.Errorf("error parsing ZCOORD: %w", err)
+	// Parse ZCOORD (optional, not exported for 2D networks)
+	if value := columnValue(values, headers, "ZCOORD", 5); value != "" {
+		point.ZCoord, err = strconv.ParseFloat(strings.Replace(value, ",", ".", -1), 64)
+		if err != nil {
+			return LinkPolyPoint{}, parseFieldError("ZCOORD", err)
+		}
 	}
 
 	return point, nil
 }
+
+// linkPolyColumns are the columns written when the section has no headers
+var linkPolyColumns = []string{"FROMNODENO", "TONODENO", "INDEX", "XCOORD", "YCOORD", "ZCOORD"}
+
+func (point LinkPolyPoint) attribute(column string) (string, bool) {
+	switch column {
+	case "FROMNODENO":
+		return formatInt(point.FromNodeNo), true
+	case "TONODENO":
+		return formatInt(point.ToNodeNo), true
+	case "INDEX":
+		return formatInt(point.Index), true
+	case "XCOORD":
+		return formatFloat(point.XCoord), true
+	case "YCOORD":
+		return formatFloat(point.YCoord), true
+	case "ZCOORD":
+		return formatFloat(point.ZCoord), true
+	}
+	return "", false
+}
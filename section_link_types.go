@@ -10,6 +10,8 @@ import (
 type LinkTypeSection struct {
 	BaseSection
 	LinkTypes []LinkType
+
+	byID index[LinkType, int]
 }
 
 // LinkType represents a single link type in the network
@@ -22,14 +24,14 @@ type LinkType struct {
 	TSysSet                 string             // Set of transport systems allowed
 	NumLanes                int                // Number of lanes
 	CapPRT                  int                // Capacity for private transport
-	V0PRT                   string             // Default speed for private transport
-	VMinPRT                 string             // Minimum speed for private transport
+	V0PRT                   Speed              // Default speed for private transport
+	VMinPRT                 Speed              // Minimum speed for private transport
 	CostRate1PUTSys         map[string]float64 // Cost rate 1 by public transport system
 	CostRate2PUTSys         map[string]float64 // Cost rate 2 by public transport system
 	CostRate3PUTSys         map[string]float64 // Cost rate 3 by public transport system
 	HBEFARoadType           string             // Road type for emissions calculations
-	VMaxPRTSys              map[string]string  // Maximum speed by private transport system
-	VDefPUTSys              map[string]string  // Default speed by public transport system
+	VMaxPRTSys              map[string]Speed   // Maximum speed by private transport system
+	VDefPUTSys              map[string]Speed   // Default speed by public transport system
 	SBAUseOnlyOutermostLane map[string]int     // Lane use restrictions by system
 	CapDay                  int                // Daily capacity
 	CapHour                 int                // Hourly capacity
@@ -38,12 +40,7 @@ type LinkType struct {
 
 // GetLinkTypeByID retrieves a link type by its ID
 func (s *LinkTypeSection) GetLinkTypeByID(id int) (LinkType, bool) {
-	for _, linkType := range s.LinkTypes {
-		if linkType.No == id {
-			return linkType, true
-		}
-	}
-	return LinkType{}, false
+	return s.byID.findFirst(s.LinkTypes, id, func(linkType LinkType) int { return linkType.No })
 }
 
 // GetLinkTypesByGroupType retrieves all link types of a specified group
@@ -94,6 +91,11 @@ func (s *LinkTypeSection) GetLinkTypesAllowingTSys(tsys string) []LinkType {
 	return result
 }
 
+// Reindex drops the lookup index after numbers of link types have been changed in place, it is built again on next use
+func (s *LinkTypeSection) Reindex() {
+	s.byID.reset()
+}
+
 // Count returns the number of link types in the section
 func (s *LinkTypeSection) Count() int {
 	return len(s.LinkTypes)
@@ -101,10 +103,6 @@ func (s *LinkTypeSection) Count() int {
 
 // getLinkType extracts data from LINKTYPE section row
 func getLinkType(values []string, headers []string) (LinkType, error) {
-	if len(values) < 8 {
-		return LinkType{}, fmt.Errorf("invalid LINKTYPE data (insufficient fields): %v", values)
-	}
-
 	var linkType LinkType
 	var err error
 
@@ -112,73 +110,80 @@ func getLinkType(values []string, headers []string) (LinkType, error) {
 	linkType.CostRate1PUTSys = make(map[string]float64)
 	linkType.CostRate2PUTSys = make(map[string]float64)
 	linkType.CostRate3PUTSys = make(map[string]float64)
-	linkType.VMaxPRTSys = make(map[string]string)
-	linkType.VDefPUTSys = make(map[string]string)
+	linkType.VMaxPRTSys = make(map[string]Speed)
+	linkType.VDefPUTSys = make(map[string]Speed)
 	linkType.SBAUseOnlyOutermostLane = make(map[string]int)
 
 	// Parse NO (required field)
-	if values[0] == "" {
-		return LinkType{}, fmt.Errorf("missing required field NO")
+	value := columnValue(values, headers, "NO", 0)
+	if value == "" {
+		return LinkType{}, missingFieldError("NO")
 	}
-	linkType.No, err = strconv.Atoi(values[0])
+	linkType.No, err = strconv.Atoi(value)
 	if err != nil {
-		return LinkType{}, fmt.Errorf("error parsing NO: %w", err)
+		return LinkType{}, parseFieldError("NO", err)
 	}
 
 	// Parse GTYPE (required field)
-	if values[1] != "" {
-		linkType.GroupType, err = strconv.Atoi(values[1])
+	if value := columnValue(values, headers, "GTYPE", 1); value != "" {
+		linkType.GroupType, err = strconv.Atoi(value)
 		if err != nil {
-			return LinkType{}, fmt.Errorf("error parsing GTYPE: %w", err)
+			return LinkType{}, parseFieldError("GTYPE", err)
 		}
 	}
 
 	// Parse NAME (optional)
-	linkType.Name = values[2]
+	linkType.Name = columnValue(values, headers, "NAME", 2)
 
 	// Parse STRICT (required field)
-	if values[3] != "" {
-		linkType.Strict, err = strconv.Atoi(values[3])
+	if value := columnValue(values, headers, "STRICT", 3); value != "" {
+		linkType.Strict, err = strconv.Atoi(value)
 		if err != nil {
-			return LinkType{}, fmt.Errorf("error parsing STRICT: %w", err)
+			return LinkType{}, parseFieldError("STRICT", err)
 		}
 	}
 
 	// Parse RANK (required field)
-	if values[4] != "" {
-		linkType.Rank, err = strconv.Atoi(values[4])
+	if value := columnValue(values, headers, "RANK", 4); value != "" {
+		linkType.Rank, err = strconv.Atoi(value)
 		if err != nil {
-			return LinkType{}, fmt.Errorf("error parsing RANK: %w", err)
+			return LinkType{}, parseFieldError("RANK", err)
 		}
 	}
 
 	// Parse TSYSSET (optional)
-	linkType.TSysSet = values[5]
+	linkType.TSysSet = columnValue(values, headers, "TSYSSET", 5)
 
 	// Parse NUMLANES (required field)
-	if values[6] != "" {
-		linkType.NumLanes, err = strconv.Atoi(values[6])
+	if value := columnValue(values, headers, "NUMLANES", 6); value != "" {
+		linkType.NumLanes, err = strconv.Atoi(value)
 		if err != nil {
-			return LinkType{}, fmt.Errorf("error parsing NUMLANES: %w", err)
+			return LinkType{}, parseFieldError("NUMLANES", err)
 		}
 	}
 
 	// Parse CAPPRT (required field)
-	if values[7] != "" {
-		linkType.CapPRT, err = strconv.Atoi(values[7])
+	if value := columnValue(values, headers, "CAPPRT", 7); value != "" {
+		linkType.CapPRT, err = strconv.Atoi(value)
 		if err != nil {
-			return LinkType{}, fmt.Errorf("error parsing CAPPRT: %w", err)
+			return LinkType{}, parseFieldError("CAPPRT", err)
 		}
 	}
 
 	// Parse V0PRT (required field)
-	if len(values) > 8 {
-		linkType.V0PRT = values[8]
+	if value := columnValue(values, headers, "V0PRT", 8); value != "" {
+		linkType.V0PRT, err = parseSpeed(value)
+		if err != nil {
+			return LinkType{}, parseFieldError("V0PRT", err)
+		}
 	}
 
 	// Parse VMINPRT (optional)
-	if len(values) > 9 {
-		linkType.VMinPRT = values[9]
+	if value := columnValue(values, headers, "VMINPRT", 9); value != "" {
+		linkType.VMinPRT, err = parseSpeed(value)
+		if err != nil {
+			return LinkType{}, parseFieldError("VMINPRT", err)
+		}
 	}
 
 	// Parse COSTRATE fields for different PUTSYSs
@@ -191,7 +196,7 @@ func getLinkType(values []string, headers []string) (LinkType, error) {
 		if strings.HasPrefix(header, "COSTRATE1_PUTSYS(") {
 			system := extractTransportSystem(header)
 			if system != "" && values[i] != "" {
-				rate, err := strconv.ParseFloat(values[i], 64)
+				rate, err := strconv.ParseFloat(strings.Replace(values[i], ",", ".", -1), 64)
 				if err == nil {
 					linkType.CostRate1PUTSys[system] = rate
 				}
@@ -202,7 +207,7 @@ func getLinkType(values []string, headers []string) (LinkType, error) {
 		if strings.HasPrefix(header, "COSTRATE2_PUTSYS(") {
 			system := extractTransportSystem(header)
 			if system != "" && values[i] != "" {
-				rate, err := strconv.ParseFloat(values[i], 64)
+				rate, err := strconv.ParseFloat(strings.Replace(values[i], ",", ".", -1), 64)
 				if err == nil {
 					linkType.CostRate2PUTSys[system] = rate
 				}
@@ -213,7 +218,7 @@ func getLinkType(values []string, headers []string) (LinkType, error) {
 		if strings.HasPrefix(header, "COSTRATE3_PUTSYS(") {
 			system := extractTransportSystem(header)
 			if system != "" && values[i] != "" {
-				rate, err := strconv.ParseFloat(values[i], 64)
+				rate, err := strconv.ParseFloat(strings.Replace(values[i], ",", ".", -1), 64)
 				if err == nil {
 					linkType.CostRate3PUTSys[system] = rate
 				}
@@ -224,7 +229,10 @@ func getLinkType(values []string, headers []string) (LinkType, error) {
 		if strings.HasPrefix(header, "VMAX_PRTSYS(") {
 			system := extractTransportSystem(header)
 			if system != "" && values[i] != "" {
-				linkType.VMaxPRTSys[system] = values[i]
+				linkType.VMaxPRTSys[system], err = parseSpeed(values[i])
+				if err != nil {
+					return LinkType{}, parseFieldError(header, err)
+				}
 			}
 		}
 
@@ -232,7 +240,10 @@ func getLinkType(values []string, headers []string) (LinkType, error) {
 		if strings.HasPrefix(header, "VDEF_PUTSYS(") {
 			system := extractTransportSystem(header)
 			if system != "" && values[i] != "" {
-				linkType.VDefPUTSys[system] = values[i]
+				linkType.VDefPUTSys[system], err = parseSpeed(values[i])
+				if err != nil {
+					return LinkType{}, parseFieldError(header, err)
+				}
 			}
 		}
 
@@ -280,3 +291,83 @@ func extractTransportSystem(header string) string {
 	}
 	return ""
 }
+
+// defaultColumns returns the columns written for link types built in code
+func (s *LinkTypeSection) defaultColumns() []string {
+	costRates := [3][]map[string]float64{}
+	vMaxPRTSys := make([]map[string]Speed, 0, len(s.LinkTypes))
+	vDefPUTSys := make([]map[string]Speed, 0, len(s.LinkTypes))
+	outermostLane := make([]map[string]int, 0, len(s.LinkTypes))
+	for _, linkType := range s.LinkTypes {
+		costRates[0] = append(costRates[0], linkType.CostRate1PUTSys)
+		costRates[1] = append(costRates[1], linkType.CostRate2PUTSys)
+		costRates[2] = append(costRates[2], linkType.CostRate3PUTSys)
+		vMaxPRTSys = append(vMaxPRTSys, linkType.VMaxPRTSys)
+		vDefPUTSys = append(vDefPUTSys, linkType.VDefPUTSys)
+		outermostLane = append(outermostLane, linkType.SBAUseOnlyOutermostLane)
+	}
+
+	columns := []string{"NO", "GTYPE", "NAME", "STRICT", "RANK", "TSYSSET", "NUMLANES", "CAPPRT", "V0PRT", "VMINPRT"}
+	for i, rates := range costRates {
+		columns = append(columns, systemColumns(fmt.Sprintf("COSTRATE%d_PUTSYS", i+1), rates...)...)
+	}
+	columns = append(columns, "HBEFA_ROADTYPE")
+	columns = append(columns, systemColumns("VMAX_PRTSYS", vMaxPRTSys...)...)
+	columns = append(columns, systemColumns("VDEF_PUTSYS", vDefPUTSys...)...)
+	columns = append(columns, systemColumns("SBAUSEONLYOUTERMOSTLANE", outermostLane...)...)
+	return append(columns, "CAPDAY", "CAPHOUR", "ROADCLASS")
+}
+
+func (linkType LinkType) attribute(column string) (string, bool) {
+	switch column {
+	case "NO":
+		return formatInt(linkType.No), true
+	case "GTYPE":
+		return formatInt(linkType.GroupType), true
+	case "NAME":
+		return linkType.Name, true
+	case "STRICT":
+		return formatInt(linkType.Strict), true
+	case "RANK":
+		return formatInt(linkType.Rank), true
+	case "TSYSSET":
+		return linkType.TSysSet, true
+	case "NUMLANES":
+		return formatInt(linkType.NumLanes), true
+	case "CAPPRT":
+		return formatInt(linkType.CapPRT), true
+	case "V0PRT":
+		return linkType.V0PRT.String(), true
+	case "VMINPRT":
+		return linkType.VMinPRT.String(), true
+	case "HBEFA_ROADTYPE":
+		return linkType.HBEFARoadType, true
+	case "CAPDAY":
+		return formatInt(linkType.CapDay), true
+	case "CAPHOUR":
+		return formatInt(linkType.CapHour), true
+	case "ROADCLASS":
+		return formatInt(linkType.RoadClass), true
+	}
+
+	// Transport system dependent columns are only written for the systems a link type has values for
+	for i, rates := range []map[string]float64{linkType.CostRate1PUTSys, linkType.CostRate2PUTSys, linkType.CostRate3PUTSys} {
+		if system, ok := systemColumn(column, fmt.Sprintf("COSTRATE%d_PUTSYS", i+1)); ok {
+			value, found := rates[system]
+			return formatFloat(value), found
+		}
+	}
+	if system, ok := systemColumn(column, "VMAX_PRTSYS"); ok {
+		value, found := linkType.VMaxPRTSys[system]
+		return value.String(), found
+	}
+	if system, ok := systemColumn(column, "VDEF_PUTSYS"); ok {
+		value, found := linkType.VDefPUTSys[system]
+		return value.String(), found
+	}
+	if system, ok := systemColumn(column, "SBAUSEONLYOUTERMOSTLANE"); ok {
+		value, found := linkType.SBAUseOnlyOutermostLane[system]
+		return formatInt(value), found
+	}
+	return "", false
+}
@@ -4,14 +4,16 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
-
-	"github.com/lddl/go-ptv-visum/utils"
 )
 
 // LinkSection represents $LINK section
 type LinkSection struct {
 	BaseSection
 	Links []Link
+
+	byID       index[Link, int]
+	byFromNode index[Link, int]
+	byToNode   index[Link, int]
 }
 
 // Link represents a single link in the transportation network
@@ -23,12 +25,12 @@ type Link struct {
 	TypeNo                  int                        // Link type ID
 	TSysSet                 string                     // Transport systems allowed on this link
 	UserDirection           int                        // Direction restriction (0=both directions, 1=from→to, 2=to→from)
-	Length                  string                     // Length with unit (e.g., "0.081km")
+	Length                  Length                     // Length (e.g., 0.081km)
 	NumLanes                int                        // Number of lanes
 	PlanNo                  int                        // Plan number
 	CapPRT                  int                        // Capacity for private transport
-	V0PRT                   string                     // Default speed for private transport
-	TPuTSys                 map[string]string          // Travel time by public transport system
+	V0PRT                   Speed                      // Default speed for private transport
+	TPuTSys                 map[string]Duration        // Travel time by public transport system
 	TModelSpecial           int                        // Special travel time model flag
 	TModelMainNodeSpecial   int                        // Special travel time model for main node
 	AddVal                  [3]int                     // Additional values 1-3
@@ -61,12 +63,7 @@ type Link struct {
 
 // GetLinkByID retrieves a link by its ID
 func (s *LinkSection) GetLinkByID(id int) (Link, bool) {
-	for _, link := range s.Links {
-		if link.No == id {
-			return link, true
-		}
-	}
-	return Link{}, false
+	return s.byID.findFirst(s.Links, id, func(link Link) int { return link.No })
 }
 
 // GetLinksByType retrieves all links of a specified link type
@@ -82,36 +79,35 @@ func (s *LinkSection) GetLinksByType(typeNo int) []Link {
 
 // GetLinksByFromNode retrieves all links originating from a specific node
 func (s *LinkSection) GetLinksByFromNode(nodeNo int) []Link {
-	var result []Link
-	for _, link := range s.Links {
-		if link.FromNodeNo == nodeNo {
-			result = append(result, link)
-		}
-	}
-	return result
+	return s.byFromNode.find(s.Links, nodeNo, linkFromNode)
 }
 
 // GetLinksByToNode retrieves all links ending at a specific node
 func (s *LinkSection) GetLinksByToNode(nodeNo int) []Link {
-	var result []Link
-	for _, link := range s.Links {
-		if link.ToNodeNo == nodeNo {
-			result = append(result, link)
-		}
-	}
-	return result
+	return s.byToNode.find(s.Links, nodeNo, linkToNode)
 }
 
 // GetLinksBetweenNodes retrieves all links connecting two specific nodes
 func (s *LinkSection) GetLinksBetweenNodes(fromNodeNo, toNodeNo int) []Link {
-	var result []Link
-	for _, link := range s.Links {
+	var positions []int
+	for _, i := range mergePositions(s.byFromNode.lookup(s.Links, fromNodeNo, linkFromNode), s.byFromNode.lookup(s.Links, toNodeNo, linkFromNode)) {
+		link := s.Links[i]
 		if (link.FromNodeNo == fromNodeNo && link.ToNodeNo == toNodeNo) ||
 			(link.FromNodeNo == toNodeNo && link.ToNodeNo == fromNodeNo) {
-			result = append(result, link)
+			positions = append(positions, i)
 		}
 	}
-	return result
+	return pick(s.Links, positions)
+}
+
+// GetLinkByFromNode retrieves the direction of a link starting at the given node
+func (s *LinkSection) GetLinkByFromNode(no, fromNodeNo int) (Link, bool) {
+	for _, i := range s.byFromNode.lookup(s.Links, fromNodeNo, linkFromNode) {
+		if s.Links[i].No == no {
+			return s.Links[i], true
+		}
+	}
+	return Link{}, false
 }
 
 // GetLinksByName retrieves all links with a specific name
@@ -177,6 +173,16 @@ func (s *LinkSection) GetAverageSpeed() float64 {
 	return 0
 }
 
+// Reindex drops the lookup indexes after numbers or nodes of links have been changed in place, they are built again on next use
+func (s *LinkSection) Reindex() {
+	s.byID.reset()
+	s.byFromNode.reset()
+	s.byToNode.reset()
+}
+
+func linkFromNode(link Link) int { return link.FromNodeNo }
+func linkToNode(link Link) int   { return link.ToNodeNo }
+
 // Count returns the number of links in the section
 func (s *LinkSection) Count() int {
 	return len(s.Links)
@@ -195,13 +201,11 @@ func (s *LinkSection) GetConnectedNodes() map[int]bool {
 // GetAdjacentNodes returns all nodes directly connected to the given node
 func (s *LinkSection) GetAdjacentNodes(nodeNo int) []int {
 	nodeSet := make(map[int]bool)
-	for _, link := range s.Links {
-		if link.FromNodeNo == nodeNo {
-			nodeSet[link.ToNodeNo] = true
-		}
-		if link.ToNodeNo == nodeNo {
-			nodeSet[link.FromNodeNo] = true
-		}
+	for _, i := range s.byFromNode.lookup(s.Links, nodeNo, linkFromNode) {
+		nodeSet[s.Links[i].ToNodeNo] = true
+	}
+	for _, i := range s.byToNode.lookup(s.Links, nodeNo, linkToNode) {
+		nodeSet[s.Links[i].FromNodeNo] = true
 	}
 
 	nodes := make([]int, 0, len(nodeSet))
@@ -211,101 +215,137 @@ func (s *LinkSection) GetAdjacentNodes(nodeNo int) []int {
 	return nodes
 }
 
-// getLink extracts data from LINK section row
-func getLink(values []string, headers []string) (Link, error) {
-	if len(values) < 12 {
-		return Link{}, fmt.Errorf("invalid LINK data (insufficient fields): %v", values)
+// linkGeometry returns the course of a link from its from-node to its to-node including the points of $LINKPOLY.
+// Polygons may be stored for the opposite direction of the link only, they are reversed then
+func linkGeometry(link Link, data *PTVData) ([][2]float64, bool) {
+	if data.Node == nil {
+		return nil, false
+	}
+	fromNode, foundFrom := data.Node.GetNodeByID(link.FromNodeNo)
+	toNode, foundTo := data.Node.GetNodeByID(link.ToNodeNo)
+	if !foundFrom || !foundTo {
+		return nil, false
+	}
+
+	geometry := [][2]float64{{fromNode.XCoord, fromNode.YCoord}}
+	if data.LinkPoly != nil {
+		if points := data.LinkPoly.GetLinkGeometry(link.FromNodeNo, link.ToNodeNo); len(points) > 0 {
+			for _, p := range points {
+				geometry = append(geometry, [2]float64{p[0], p[1]})
+			}
+		} else {
+			points := data.LinkPoly.GetLinkGeometry(link.ToNodeNo, link.FromNodeNo)
+			for i := len(points) - 1; i >= 0; i-- {
+				geometry = append(geometry, [2]float64{points[i][0], points[i][1]})
+			}
+		}
 	}
+	return append(geometry, [2]float64{toNode.XCoord, toNode.YCoord}), true
+}
 
+// getLink extracts data from LINK section row
+func getLink(values []string, headers []string) (Link, error) {
 	var link Link
 	var err error
 
 	// Initialize maps
-	link.TPuTSys = make(map[string]string)
+	link.TPuTSys = make(map[string]Duration)
 	link.AddValTSys = make(map[string]int)
 	link.TollPRTSys = make(map[string]float64)
 	link.CostRatePUTSys = make(map[string]map[int]float64)
 	link.NumFarePointsTSys = make(map[string]int)
 
 	// Parse NO (required field)
-	if values[0] == "" {
-		return Link{}, fmt.Errorf("missing required field NO")
+	value := columnValue(values, headers, "NO", 0)
+	if value == "" {
+		return Link{}, missingFieldError("NO")
 	}
-	link.No, err = strconv.Atoi(values[0])
+	link.No, err = strconv.Atoi(value)
 	if err != nil {
-		return Link{}, fmt.Errorf("error parsing NO: %w", err)
+		return Link{}, parseFieldError("NO", err)
 	}
 
 	// Parse FROMNODENO (required field)
-	if values[1] == "" {
-		return Link{}, fmt.Errorf("missing required field FROMNODENO")
+	value = columnValue(values, headers, "FROMNODENO", 1)
+	if value == "" {
+		return Link{}, missingFieldError("FROMNODENO")
 	}
-	link.FromNodeNo, err = strconv.Atoi(values[1])
+	link.FromNodeNo, err = strconv.Atoi(value)
 	if err != nil {
-		return Link{}, fmt.Errorf("error parsing FROMNODENO: %w", err)
+		return Link{}, parseFieldError("FROMNODENO", err)
 	}
 
 	// Parse TONODENO (required field)
-	if values[2] == "" {
-		return Link{}, fmt.Errorf("missing required field TONODENO")
+	value = columnValue(values, headers, "TONODENO", 2)
+	if value == "" {
+		return Link{}, missingFieldError("TONODENO")
 	}
-	link.ToNodeNo, err = strconv.Atoi(values[2])
+	link.ToNodeNo, err = strconv.Atoi(value)
 	if err != nil {
-		return Link{}, fmt.Errorf("error parsing TONODENO: %w", err)
+		return Link{}, parseFieldError("TONODENO", err)
 	}
 
 	// Parse NAME (optional)
-	link.Name = values[3]
+	link.Name = columnValue(values, headers, "NAME", 3)
 
-	// Parse TYPENO (required field)
-	if values[4] == "" {
-		return Link{}, fmt.Errorf("missing required field TYPENO")
-	}
-	link.TypeNo, err = strconv.Atoi(values[4])
-	if err != nil {
-		return Link{}, fmt.Errorf("error parsing TYPENO: %w", err)
+	// Parse TYPENO (optional)
+	if value := columnValue(values, headers, "TYPENO", 4); value != "" {
+		link.TypeNo, err = strconv.Atoi(value)
+		if err != nil {
+			return Link{}, parseFieldError("TYPENO", err)
+		}
 	}
 
 	// Parse TSYSSET (optional but usually present)
-	link.TSysSet = values[5]
+	link.TSysSet = columnValue(values, headers, "TSYSSET", 5)
 
 	// Parse USERDIRECTION (optional)
-	if values[6] != "" {
-		link.UserDirection, err = strconv.Atoi(values[6])
+	if value := columnValue(values, headers, "USERDIRECTION", 6); value != "" {
+		link.UserDirection, err = strconv.Atoi(value)
 		if err != nil {
-			return Link{}, fmt.Errorf("error parsing USERDIRECTION: %w", err)
+			return Link{}, parseFieldError("USERDIRECTION", err)
 		}
 	}
 
 	// Parse LENGTH (required field)
-	link.Length = values[7]
+	if value := columnValue(values, headers, "LENGTH", 7); value != "" {
+		link.Length, err = parseLength(value)
+		if err != nil {
+			return Link{}, parseFieldError("LENGTH", err)
+		}
+	}
 
 	// Parse NUMLANES (required field)
-	if values[8] != "" {
-		link.NumLanes, err = strconv.Atoi(values[8])
+	if value := columnValue(values, headers, "NUMLANES", 8); value != "" {
+		link.NumLanes, err = strconv.Atoi(value)
 		if err != nil {
-			return Link{}, fmt.Errorf("error parsing NUMLANES: %w", err)
+			return Link{}, parseFieldError("NUMLANES", err)
 		}
 	}
 
 	// Parse PLANNO (optional)
-	if values[9] != "" {
-		link.PlanNo, err = strconv.Atoi(values[9])
+	if value := columnValue(values, headers, "PLANNO", 9); value != "" {
+		link.PlanNo, err = strconv.Atoi(value)
 		if err != nil {
-			return Link{}, fmt.Errorf("error parsing PLANNO: %w", err)
+			return Link{}, parseFieldError("PLANNO", err)
 		}
 	}
 
 	// Parse CAPPRT (required field)
-	if values[10] != "" {
-		link.CapPRT, err = strconv.Atoi(values[10])
+	if value := columnValue(values, headers, "CAPPRT", 10); value != "" {
+		link.CapPRT, err = strconv.Atoi(value)
 		if err != nil {
-			return Link{}, fmt.Errorf("error parsing CAPPRT: %w", err)
+			return Link{}, parseFieldError("CAPPRT", err)
 		}
 	}
 
 	// Parse V0PRT (required field)
-	link.V0PRT = values[11]
+	if value := columnValue(values, headers, "V0PRT", 11); value != "" {
+		link.V0PRT, err = parseSpeed(value)
+		if err != nil {
+			return Link{}, parseFieldError("V0PRT", err)
+		}
+	}
 
 	// Process remaining fields based on headers
 	for i := 0; i < len(headers) && i < len(values); i++ {
@@ -320,7 +360,10 @@ func getLink(values []string, headers []string) (Link, error) {
 		if strings.HasPrefix(headerName, "T_PUTSYS(") {
 			tsys := extractSystemName(headerName)
 			if tsys != "" {
-				link.TPuTSys[tsys] = value
+				link.TPuTSys[tsys], err = parseDuration(value)
+				if err != nil {
+					return Link{}, parseFieldError(headerName, err)
+				}
 			}
 		}
 
@@ -365,7 +408,7 @@ func getLink(values []string, headers []string) (Link, error) {
 		if strings.HasPrefix(headerName, "TOLL_PRTSYS(") {
 			tsys := extractSystemName(headerName)
 			if tsys != "" {
-				val, err := strconv.ParseFloat(value, 64)
+				val, err := strconv.ParseFloat(strings.Replace(value, ",", ".", -1), 64)
 				if err == nil {
 					link.TollPRTSys[tsys] = val
 				}
@@ -382,7 +425,7 @@ func getLink(values []string, headers []string) (Link, error) {
 				if err == nil && parts[1] != "" {
 					tsys := extractSystemName(parts[1])
 					if tsys != "" {
-						rate, err := strconv.ParseFloat(value, 64)
+						rate, err := strconv.ParseFloat(strings.Replace(value, ",", ".", -1), 64)
 						if err == nil {
 							if link.CostRatePUTSys[tsys] == nil {
 								link.CostRatePUTSys[tsys] = make(map[int]float64)
@@ -441,12 +484,12 @@ func getLink(values []string, headers []string) (Link, error) {
 
 		// Process SHAREHGV
 		if headerName == "SHAREHGV" {
-			link.ShareHGV, _ = strconv.ParseFloat(value, 64)
+			link.ShareHGV, _ = strconv.ParseFloat(strings.Replace(value, ",", ".", -1), 64)
 		}
 
 		// Process SLOPE
 		if headerName == "SLOPE" {
-			link.Slope, _ = strconv.ParseFloat(value, 64)
+			link.Slope, _ = strconv.ParseFloat(strings.Replace(value, ",", ".", -1), 64)
 		}
 
 		// Process SHOWBARTEXT
@@ -456,22 +499,22 @@ func getLink(values []string, headers []string) (Link, error) {
 
 		// Process BARTEXTRELPOS
 		if headerName == "BARTEXTRELPOS" {
-			link.BarTextRelPos, _ = strconv.ParseFloat(value, 64)
+			link.BarTextRelPos, _ = strconv.ParseFloat(strings.Replace(value, ",", ".", -1), 64)
 		}
 
 		// Process LABELPOSRELX
 		if headerName == "LABELPOSRELX" {
-			link.LabelPosRelX, _ = strconv.ParseFloat(value, 64)
+			link.LabelPosRelX, _ = strconv.ParseFloat(strings.Replace(value, ",", ".", -1), 64)
 		}
 
 		// Process LABELPOSRELY
 		if headerName == "LABELPOSRELY" {
-			link.LabelPosRelY, _ = strconv.ParseFloat(value, 64)
+			link.LabelPosRelY, _ = strconv.ParseFloat(strings.Replace(value, ",", ".", -1), 64)
 		}
 
 		// Process SPACEPERPCU
 		if headerName == "SPACEPERPCU" {
-			link.SpacePerkPCU, _ = strconv.ParseFloat(value, 64)
+			link.SpacePerkPCU, _ = strconv.ParseFloat(strings.Replace(value, "m", "", 1), 64)
 		}
 
 		// Process DUEVWAVE
@@ -513,68 +556,14 @@ func extractSystemName(header string) string {
 	return ""
 }
 
-// GetLengthInKm parses the Length string and returns the value in kilometers
+// GetLengthInKm returns the length in kilometers
 func (l *Link) GetLengthInKm() float64 {
-	if l.Length == "" {
-		return 0
-	}
-
-	// Extract numeric part
-	numStr := l.Length
-	unit := ""
-
-	// Find where the numeric part ends
-	for i, c := range l.Length {
-		if !utils.IsDigit(c) && c != '.' && c != ',' {
-			numStr = l.Length[:i]
-			unit = strings.ToLower(l.Length[i:])
-			break
-		}
-	}
-
-	// Convert to float
-	numStr = strings.Replace(numStr, ",", ".", 1)
-	value, err := strconv.ParseFloat(numStr, 64)
-	if err != nil {
-		return 0
-	}
-
-	// Convert to km based on unit
-	switch {
-	case strings.Contains(unit, "km"):
-		return value
-	case strings.Contains(unit, "m"):
-		return value / 1000
-	default:
-		return value // Assume km if no unit specified
-	}
+	return l.Length.Kilometers()
 }
 
-// GetSpeedInKmh parses the V0PRT string and returns the value in km/h
+// GetSpeedInKmh returns the V0PRT speed in km/h
 func (l *Link) GetSpeedInKmh() float64 {
-	if l.V0PRT == "" {
-		return 0
-	}
-
-	// Extract numeric part
-	numStr := l.V0PRT
-
-	// Find where the numeric part ends
-	for i, c := range l.V0PRT {
-		if !utils.IsDigit(c) && c != '.' && c != ',' {
-			numStr = l.V0PRT[:i]
-			break
-		}
-	}
-
-	// Convert to float
-	numStr = strings.Replace(numStr, ",", ".", 1)
-	value, err := strconv.ParseFloat(numStr, 64)
-	if err != nil {
-		return 0
-	}
-
-	return value
+	return l.V0PRT.KmH()
 }
 
 // IsBidirectional checks if the link allows travel in both directions
@@ -592,3 +581,150 @@ func (l *Link) AllowsTransportSystem(tsys string) bool {
 	}
 	return false
 }
+
+// linkColumns are the fixed columns written when the section has no headers
+var linkColumns = []string{
+	"NO", "FROMNODENO", "TONODENO", "NAME", "TYPENO", "TSYSSET", "USERDIRECTION", "LENGTH", "NUMLANES", "PLANNO",
+	"CAPPRT", "V0PRT",
+}
+
+// linkTailColumns follow the transport system dependent columns when the section has no headers
+var linkTailColumns = []string{
+	"FROMNODEORIENTATION", "TONODEORIENTATION", "FROMMAINNODEORIENTATION", "TOMAINNODEORIENTATION", "EWSTYPE",
+	"EWSCLASS", "SURFACETYPE", "NOISEIMMISHEIGHT", "SHAREHGV", "SLOPE", "SHOWBARTEXT", "BARTEXTRELPOS",
+	"LABELPOSRELX", "LABELPOSRELY", "DUEVWAVE", "SPACEPERPCU", "URBAN", "SPEEDLIMIT", "BRIDGE", "OVERPASS",
+}
+
+// defaultColumns returns the columns written for links built in code
+func (s *LinkSection) defaultColumns() []string {
+	tPuTSys := make([]map[string]Duration, 0, len(s.Links))
+	addValTSys := make([]map[string]int, 0, len(s.Links))
+	tollPRTSys := make([]map[string]float64, 0, len(s.Links))
+	costRatePUTSys := make([]map[string]map[int]float64, 0, len(s.Links))
+	numFarePointsTSys := make([]map[string]int, 0, len(s.Links))
+	for _, link := range s.Links {
+		tPuTSys = append(tPuTSys, link.TPuTSys)
+		addValTSys = append(addValTSys, link.AddValTSys)
+		tollPRTSys = append(tollPRTSys, link.TollPRTSys)
+		costRatePUTSys = append(costRatePUTSys, link.CostRatePUTSys)
+		numFarePointsTSys = append(numFarePointsTSys, link.NumFarePointsTSys)
+	}
+
+	columns := append([]string{}, linkColumns...)
+	columns = append(columns, systemColumns("T_PUTSYS", tPuTSys...)...)
+	columns = append(columns, "TMODELSPECIAL", "TMODELMAINNODESPECIAL", "ADDVAL1", "ADDVAL2", "ADDVAL3")
+	columns = append(columns, systemColumns("ADDVAL_TSYS", addValTSys...)...)
+	columns = append(columns, "RESTRTRAFAREASET")
+	columns = append(columns, systemColumns("TOLL_PRTSYS", tollPRTSys...)...)
+	for rate := 1; rate <= 3; rate++ {
+		columns = append(columns, systemColumns(fmt.Sprintf("COSTRATE%d_PUTSYS", rate), costRatePUTSys...)...)
+	}
+	columns = append(columns, systemColumns("NUMFAREPOINTS_TSYS", numFarePointsTSys...)...)
+	return append(columns, linkTailColumns...)
+}
+
+func (l Link) attribute(column string) (string, bool) {
+	switch column {
+	case "NO":
+		return formatInt(l.No), true
+	case "FROMNODENO":
+		return formatInt(l.FromNodeNo), true
+	case "TONODENO":
+		return formatInt(l.ToNodeNo), true
+	case "NAME":
+		return l.Name, true
+	case "TYPENO":
+		return formatInt(l.TypeNo), true
+	case "TSYSSET":
+		return l.TSysSet, true
+	case "USERDIRECTION":
+		return formatInt(l.UserDirection), true
+	case "LENGTH":
+		return l.Length.String(), true
+	case "NUMLANES":
+		return formatInt(l.NumLanes), true
+	case "PLANNO":
+		return formatInt(l.PlanNo), true
+	case "CAPPRT":
+		return formatInt(l.CapPRT), true
+	case "V0PRT":
+		return l.V0PRT.String(), true
+	case "TMODELSPECIAL":
+		return formatInt(l.TModelSpecial), true
+	case "TMODELMAINNODESPECIAL":
+		return formatInt(l.TModelMainNodeSpecial), true
+	case "ADDVAL1":
+		return formatInt(l.AddVal[0]), true
+	case "ADDVAL2":
+		return formatInt(l.AddVal[1]), true
+	case "ADDVAL3":
+		return formatInt(l.AddVal[2]), true
+	case "RESTRTRAFAREASET":
+		return l.RestrTrafAreaSet, true
+	case "FROMNODEORIENTATION":
+		return l.FromNodeOrientation, true
+	case "TONODEORIENTATION":
+		return l.ToNodeOrientation, true
+	case "FROMMAINNODEORIENTATION":
+		return l.FromMainNodeOrientation, true
+	case "TOMAINNODEORIENTATION":
+		return l.ToMainNodeOrientation, true
+	case "EWSTYPE":
+		return formatInt(l.EWSType), true
+	case "EWSCLASS":
+		return formatInt(l.EWSClass), true
+	case "SURFACETYPE":
+		return formatInt(l.SurfaceType), true
+	case "NOISEIMMISHEIGHT":
+		return formatFloat(l.NoiseImmisHeight) + "m", true
+	case "SHAREHGV":
+		return formatFloat(l.ShareHGV), true
+	case "SLOPE":
+		return formatFloat(l.Slope), true
+	case "SHOWBARTEXT":
+		return formatInt(l.ShowBarText), true
+	case "BARTEXTRELPOS":
+		return formatFloat(l.BarTextRelPos), true
+	case "LABELPOSRELX":
+		return formatFloat(l.LabelPosRelX), true
+	case "LABELPOSRELY":
+		return formatFloat(l.LabelPosRelY), true
+	case "SPACEPERPCU":
+		return formatFloat(l.SpacePerkPCU) + "m", true
+	case "DUEVWAVE":
+		return l.DUEvWave, true
+	case "URBAN":
+		return formatInt(l.Urban), true
+	case "SPEEDLIMIT":
+		return formatInt(l.SpeedLimit), true
+	case "BRIDGE":
+		return formatInt(l.Bridge), true
+	case "OVERPASS":
+		return formatInt(l.Overpass), true
+	}
+
+	// Transport system dependent columns are only written for the systems a link has values for
+	if tsys, ok := systemColumn(column, "T_PUTSYS"); ok {
+		value, found := l.TPuTSys[tsys]
+		return value.String(), found
+	}
+	if tsys, ok := systemColumn(column, "ADDVAL_TSYS"); ok {
+		value, found := l.AddValTSys[tsys]
+		return formatInt(value), found
+	}
+	if tsys, ok := systemColumn(column, "TOLL_PRTSYS"); ok {
+		value, found := l.TollPRTSys[tsys]
+		return formatFloat(value), found
+	}
+	if tsys, ok := systemColumn(column, "NUMFAREPOINTS_TSYS"); ok {
+		value, found := l.NumFarePointsTSys[tsys]
+		return formatInt(value), found
+	}
+	for rate := 1; rate <= 3; rate++ {
+		if tsys, ok := systemColumn(column, fmt.Sprintf("COSTRATE%d_PUTSYS", rate)); ok {
+			value, found := l.CostRatePUTSys[tsys][rate]
+			return formatFloat(value), found
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,181 @@
+package ptvvisum
+
+import (
+	"strconv"
+	"strings"
+)
+
+// MainNodeSection represents $MAINNODE section
+type MainNodeSection struct {
+	BaseSection
+	MainNodes []MainNode
+
+	byID index[MainNode, int]
+}
+
+// MainNode represents a complex intersection made of several member nodes, e.g. a roundabout
+// or a junction of dual carriageways. Member nodes refer to it by Node.MainNodeNo
+type MainNode struct {
+	No          int     // Main node number
+	Code        string  // Main node code
+	Name        string  // Main node name
+	TypeNo      int     // Main node type number
+	ControlType int     // Control type (0=uncontrolled, 1=priority, 2=signalized, etc.)
+	XCoord      float64 // X-coordinate
+	YCoord      float64 // Y-coordinate
+}
+
+// GetMainNodeByID retrieves a main node by its number
+func (s *MainNodeSection) GetMainNodeByID(no int) (MainNode, bool) {
+	return s.byID.findFirst(s.MainNodes, no, func(mainNode MainNode) int { return mainNode.No })
+}
+
+// GetNodes retrieves the member nodes of a main node
+func (s *MainNodeSection) GetNodes(no int, data *PTVData) []Node {
+	if data.Node == nil {
+		return nil
+	}
+	return data.Node.GetNodesByMainNode(no)
+}
+
+// GetInternalLinks retrieves the links with both ends inside a main node, each direction separately
+func (s *MainNodeSection) GetInternalLinks(no int, data *PTVData) []Link {
+	members := mainNodeMembers(no, data)
+	if data.Link == nil || len(members) == 0 {
+		return nil
+	}
+	var result []Link
+	for _, node := range data.Node.GetNodesByMainNode(no) {
+		for _, link := range data.Link.GetLinksByFromNode(node.ID) {
+			if members[link.ToNodeNo] {
+				result = append(result, link)
+			}
+		}
+	}
+	return result
+}
+
+// GetCordonLinks retrieves the links crossing the border of a main node, each direction separately.
+// Links entering the main node come first, followed by the links leaving it
+func (s *MainNodeSection) GetCordonLinks(no int, data *PTVData) []Link {
+	members := mainNodeMembers(no, data)
+	if data.Link == nil || len(members) == 0 {
+		return nil
+	}
+	var entering, leaving []Link
+	for _, node := range data.Node.GetNodesByMainNode(no) {
+		for _, link := range data.Link.GetLinksByToNode(node.ID) {
+			if !members[link.FromNodeNo] {
+				entering = append(entering, link)
+			}
+		}
+		for _, link := range data.Link.GetLinksByFromNode(node.ID) {
+			if !members[link.ToNodeNo] {
+				leaving = append(leaving, link)
+			}
+		}
+	}
+	return append(entering, leaving...)
+}
+
+// GetMainTurns retrieves the main turns of a main node
+func (s *MainNodeSection) GetMainTurns(no int, data *PTVData) []MainTurn {
+	if data.MainTurn == nil {
+		return nil
+	}
+	return data.MainTurn.GetMainTurnsByMainNode(no)
+}
+
+// mainNodeMembers returns the set of member nodes of a main node
+func mainNodeMembers(no int, data *PTVData) map[int]bool {
+	if data.Node == nil {
+		return nil
+	}
+	members := make(map[int]bool)
+	for _, node := range data.Node.GetNodesByMainNode(no) {
+		members[node.ID] = true
+	}
+	return members
+}
+
+// Reindex drops the lookup index after numbers of main nodes have been changed in place, it is built again on next use
+func (s *MainNodeSection) Reindex() {
+	s.byID.reset()
+}
+
+// Count returns the number of main nodes in the section
+func (s *MainNodeSection) Count() int {
+	return len(s.MainNodes)
+}
+
+// getMainNode extracts data from MAINNODE section row
+func getMainNode(values []string, headers []string) (MainNode, error) {
+	var mainNode MainNode
+	var err error
+
+	// Parse NO (required field)
+	value := columnValue(values, headers, "NO", 0)
+	if value == "" {
+		return MainNode{}, missingFieldError("NO")
+	}
+	mainNode.No, err = strconv.Atoi(value)
+	if err != nil {
+		return MainNode{}, parseFieldError("NO", err)
+	}
+
+	mainNode.Code = columnValue(values, headers, "CODE", 1)
+	mainNode.Name = columnValue(values, headers, "NAME", 2)
+
+	// Parse TYPENO and CONTROLTYPE (optional)
+	if value := columnValue(values, headers, "TYPENO", 3); value != "" {
+		mainNode.TypeNo, err = strconv.Atoi(value)
+		if err != nil {
+			return MainNode{}, parseFieldError("TYPENO", err)
+		}
+	}
+	if value := columnValue(values, headers, "CONTROLTYPE", 4); value != "" {
+		mainNode.ControlType, err = strconv.Atoi(value)
+		if err != nil {
+			return MainNode{}, parseFieldError("CONTROLTYPE", err)
+		}
+	}
+
+	// Parse XCOORD and YCOORD (optional)
+	if value := columnValue(values, headers, "XCOORD", 5); value != "" {
+		mainNode.XCoord, err = strconv.ParseFloat(strings.Replace(value, ",", ".", -1), 64)
+		if err != nil {
+			return MainNode{}, parseFieldError("XCOORD", err)
+		}
+	}
+	if value := columnValue(values, headers, "YCOORD", 6); value != "" {
+		mainNode.YCoord, err = strconv.ParseFloat(strings.Replace(value, ",", ".", -1), 64)
+		if err != nil {
+			return MainNode{}, parseFieldError("YCOORD", err)
+		}
+	}
+
+	return mainNode, nil
+}
+
+// mainNodeColumns are the columns written when the section has no headers
+var mainNodeColumns = []string{"NO", "CODE", "NAME", "TYPENO", "CONTROLTYPE", "XCOORD", "YCOORD"}
+
+func (mainNode MainNode) attribute(column string) (string, bool) {
+	switch column {
+	case "NO":
+		return formatInt(mainNode.No), true
+	case "CODE":
+		return mainNode.Code, true
+	case "NAME":
+		return mainNode.Name, true
+	case "TYPENO":
+		return formatInt(mainNode.TypeNo), true
+	case "CONTROLTYPE":
+		return formatInt(mainNode.ControlType), true
+	case "XCOORD":
+		return formatFloat(mainNode.XCoord), true
+	case "YCOORD":
+		return formatFloat(mainNode.YCoord), true
+	}
+	return "", false
+}
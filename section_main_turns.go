@@ -0,0 +1,206 @@
+package ptvvisum
+
+import (
+	"math"
+	"strconv"
+)
+
+// MainTurnSection represents $MAINTURN section
+type MainTurnSection struct {
+	BaseSection
+	MainTurns []MainTurn
+
+	byMainNode index[MainTurn, int]
+}
+
+// MainTurn represents a turning movement through a main node from one cordon link to another
+type MainTurn struct {
+	MainNodeNo int      // Main node the turn is made at
+	FromLinkNo int      // Cordon link entering the main node
+	ToLinkNo   int      // Cordon link leaving the main node
+	TypeNo     int      // Turn type ID
+	TSysSet    string   // Transport systems allowed
+	CapPRT     int      // Capacity for private transport
+	T0PRT      Duration // Default travel time
+}
+
+// GetMainTurnsByMainNode retrieves all main turns of a main node
+func (s *MainTurnSection) GetMainTurnsByMainNode(mainNodeNo int) []MainTurn {
+	return s.byMainNode.find(s.MainTurns, mainNodeNo, func(turn MainTurn) int { return turn.MainNodeNo })
+}
+
+// GetMainTurn retrieves the main turn of a main node between two cordon links
+func (s *MainTurnSection) GetMainTurn(mainNodeNo, fromLinkNo, toLinkNo int) (MainTurn, bool) {
+	for _, i := range s.byMainNode.lookup(s.MainTurns, mainNodeNo, func(turn MainTurn) int { return turn.MainNodeNo }) {
+		if s.MainTurns[i].FromLinkNo == fromLinkNo && s.MainTurns[i].ToLinkNo == toLinkNo {
+			return s.MainTurns[i], true
+		}
+	}
+	return MainTurn{}, false
+}
+
+// GetLinkSequence resolves the links a main turn runs along: the entering cordon link, the internal links
+// on the shortest path through the main node and the leaving cordon link. False is returned when the cordon
+// links are not found or the member nodes are not connected
+func (s *MainTurnSection) GetLinkSequence(turn MainTurn, data *PTVData) ([]Link, bool) {
+	members := mainNodeMembers(turn.MainNodeNo, data)
+	if data.Link == nil || len(members) == 0 {
+		return nil, false
+	}
+
+	var from, to Link
+	var hasFrom, hasTo bool
+	for nodeNo := range members {
+		for _, link := range data.Link.GetLinksByToNode(nodeNo) {
+			if link.No == turn.FromLinkNo && !members[link.FromNodeNo] {
+				from, hasFrom = link, true
+			}
+		}
+		for _, link := range data.Link.GetLinksByFromNode(nodeNo) {
+			if link.No == turn.ToLinkNo && !members[link.ToNodeNo] {
+				to, hasTo = link, true
+			}
+		}
+	}
+	if !hasFrom || !hasTo {
+		return nil, false
+	}
+
+	internal, ok := shortestInternalPath(from.ToNodeNo, to.FromNodeNo, members, data)
+	if !ok {
+		return nil, false
+	}
+	sequence := make([]Link, 0, len(internal)+2)
+	sequence = append(sequence, from)
+	sequence = append(sequence, internal...)
+	return append(sequence, to), true
+}
+
+// shortestInternalPath finds the shortest path by length between two member nodes using internal links only.
+// Main nodes have a handful of members, so the closest node is picked by a plain scan
+func shortestInternalPath(fromNodeNo, toNodeNo int, members map[int]bool, data *PTVData) ([]Link, bool) {
+	distance := map[int]float64{fromNodeNo: 0}
+	previous := make(map[int]Link)
+	done := make(map[int]bool)
+	for {
+		current, best := 0, math.Inf(1)
+		for nodeNo, d := range distance {
+			if !done[nodeNo] && (d < best || d == best && nodeNo < current) {
+				current, best = nodeNo, d
+			}
+		}
+		if math.IsInf(best, 1) {
+			return nil, false
+		}
+		if current == toNodeNo {
+			break
+		}
+		done[current] = true
+		for _, link := range data.Link.GetLinksByFromNode(current) {
+			if !members[link.ToNodeNo] || done[link.ToNodeNo] {
+				continue
+			}
+			if d, ok := distance[link.ToNodeNo]; !ok || best+link.Length.Meters() < d {
+				distance[link.ToNodeNo] = best + link.Length.Meters()
+				previous[link.ToNodeNo] = link
+			}
+		}
+	}
+
+	var path []Link
+	for nodeNo := toNodeNo; nodeNo != fromNodeNo; {
+		link := previous[nodeNo]
+		path = append(path, link)
+		nodeNo = link.FromNodeNo
+	}
+	for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+		path[i], path[j] = path[j], path[i]
+	}
+	return path, true
+}
+
+// Reindex drops the lookup index after main nodes of main turns have been changed in place, it is built again on next use
+func (s *MainTurnSection) Reindex() {
+	s.byMainNode.reset()
+}
+
+// Count returns the number of main turns in the section
+func (s *MainTurnSection) Count() int {
+	return len(s.MainTurns)
+}
+
+// getMainTurn extracts data from MAINTURN section row
+func getMainTurn(values []string, headers []string) (MainTurn, error) {
+	var turn MainTurn
+	var err error
+
+	// Parse MAINNODENO, FROMLINKNO and TOLINKNO (required fields)
+	requiredFields := []struct {
+		index int
+		dest  *int
+		name  string
+	}{
+		{0, &turn.MainNodeNo, "MAINNODENO"},
+		{1, &turn.FromLinkNo, "FROMLINKNO"},
+		{2, &turn.ToLinkNo, "TOLINKNO"},
+	}
+	for _, field := range requiredFields {
+		value := columnValue(values, headers, field.name, field.index)
+		if value == "" {
+			return MainTurn{}, missingFieldError(field.name)
+		}
+		*field.dest, err = strconv.Atoi(value)
+		if err != nil {
+			return MainTurn{}, parseFieldError(field.name, err)
+		}
+	}
+
+	// Parse TYPENO (optional)
+	if value := columnValue(values, headers, "TYPENO", 3); value != "" {
+		turn.TypeNo, err = strconv.Atoi(value)
+		if err != nil {
+			return MainTurn{}, parseFieldError("TYPENO", err)
+		}
+	}
+
+	turn.TSysSet = columnValue(values, headers, "TSYSSET", 4)
+
+	// Parse CAPPRT and T0PRT (optional)
+	if value := columnValue(values, headers, "CAPPRT", 5); value != "" {
+		turn.CapPRT, err = strconv.Atoi(value)
+		if err != nil {
+			return MainTurn{}, parseFieldError("CAPPRT", err)
+		}
+	}
+	if value := columnValue(values, headers, "T0PRT", 6); value != "" {
+		turn.T0PRT, err = parseDuration(value)
+		if err != nil {
+			return MainTurn{}, parseFieldError("T0PRT", err)
+		}
+	}
+
+	return turn, nil
+}
+
+// mainTurnColumns are the columns written when the section has no headers
+var mainTurnColumns = []string{"MAINNODENO", "FROMLINKNO", "TOLINKNO", "TYPENO", "TSYSSET", "CAPPRT", "T0PRT"}
+
+func (turn MainTurn) attribute(column string) (string, bool) {
+	switch column {
+	case "MAINNODENO":
+		return formatInt(turn.MainNodeNo), true
+	case "FROMLINKNO":
+		return formatInt(turn.FromLinkNo), true
+	case "TOLINKNO":
+		return formatInt(turn.ToLinkNo), true
+	case "TYPENO":
+		return formatInt(turn.TypeNo), true
+	case "TSYSSET":
+		return turn.TSysSet, true
+	case "CAPPRT":
+		return formatInt(turn.CapPRT), true
+	case "T0PRT":
+		return turn.T0PRT.String(), true
+	}
+	return "", false
+}
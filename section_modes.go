@@ -1,7 +1,6 @@
 package ptvvisum
 
 import (
-	"fmt"
 	"strconv"
 	"strings"
 )
@@ -21,31 +20,44 @@ type Mode struct {
 }
 
 // getMode extracts data from MODE section row
-func getMode(values []string) (Mode, error) {
-	if len(values) < 4 {
-		return Mode{}, fmt.Errorf("invalid MODE data (need CODE;NAME;TSYSSET;INTERCHANGEABLE): %v", values)
-	}
-
+func getMode(values []string, headers []string) (Mode, error) {
 	// Process mode fields
 	mode := Mode{
-		Code:    values[0],
-		Name:    values[1],
+		Code:    columnValue(values, headers, "CODE", 0),
+		Name:    columnValue(values, headers, "NAME", 1),
 		TSysSet: []string{},
 	}
 
 	// Parse TSysSet (comma-separated list of transport systems)
-	if values[2] != "" {
-		mode.TSysSet = strings.Split(values[2], ",")
+	if value := columnValue(values, headers, "TSYSSET", 2); value != "" {
+		mode.TSysSet = strings.Split(value, ",")
 	}
 
 	// Parse Interchangeable flag
-	if values[3] != "" {
-		interchangeable, err := strconv.Atoi(values[3])
+	if value := columnValue(values, headers, "INTERCHANGEABLE", 3); value != "" {
+		interchangeable, err := strconv.Atoi(value)
 		if err != nil {
-			return Mode{}, fmt.Errorf("error parsing Interchangeable flag: %w", err)
+			return Mode{}, parseFieldError("INTERCHANGEABLE", err)
 		}
 		mode.Interchangeable = interchangeable
 	}
 
 	return mode, nil
 }
+
+// modeColumns are the columns written when the section has no headers
+var modeColumns = []string{"CODE", "NAME", "TSYSSET", "INTERCHANGEABLE"}
+
+func (m Mode) attribute(column string) (string, bool) {
+	switch column {
+	case "CODE":
+		return m.Code, true
+	case "NAME":
+		return m.Name, true
+	case "TSYSSET":
+		return strings.Join(m.TSysSet, ","), true
+	case "INTERCHANGEABLE":
+		return formatInt(m.Interchangeable), true
+	}
+	return "", false
+}
@@ -1,7 +1,6 @@
 package ptvvisum
 
 import (
-	"fmt"
 	"strconv"
 	"strings"
 )
@@ -41,25 +40,21 @@ type NetworkData struct {
 }
 
 // getNetwork extracts data from the NETWORK section row
-func getNetwork(values []string) (NetworkData, error) {
-	if len(values) < 14 {
-		return NetworkData{}, fmt.Errorf("insufficient NETWORK data (need at least 14 fields): %v", values)
-	}
-
+func getNetwork(values []string, headers []string) (NetworkData, error) {
 	network := NetworkData{
-		NetVersionID:   values[0],
-		NetVersionName: values[1],
-		Unit:           values[3],
+		NetVersionID:   columnValue(values, headers, "NETVERSIONID", 0),
+		NetVersionName: columnValue(values, headers, "NETVERSIONNAME", 1),
+		Unit:           columnValue(values, headers, "UNIT", 3),
 	}
 
 	// Parse numeric values
 	var err error
 
 	// Scale
-	if values[2] != "" {
-		network.Scale, err = strconv.ParseFloat(strings.Replace(values[2], ",", ".", -1), 64)
+	if value := columnValue(values, headers, "SCALE", 2); value != "" {
+		network.Scale, err = strconv.ParseFloat(strings.Replace(value, ",", ".", -1), 64)
 		if err != nil {
-			return NetworkData{}, fmt.Errorf("error parsing Scale: %w", err)
+			return NetworkData{}, parseFieldError("SCALE", err)
 		}
 	}
 
@@ -69,76 +64,121 @@ func getNetwork(values []string) (NetworkData, error) {
 		dest  *int
 		name  string
 	}{
-		{4, &network.LeftHandTraffic, "LeftHandTraffic"},
-		{5, &network.CoordDecPlaces, "CoordDecPlaces"},
-		{6, &network.DecPlacesOther, "DecPlacesOther"},
-		{7, &network.CurrencyDecPlaces, "CurrencyDecPlaces"},
-		{8, &network.LongLengthDecPlaces, "LongLengthDecPlaces"},
-		{9, &network.ShortLengthDecPlaces, "ShortLengthDecPlaces"},
-		{10, &network.TurnT0DecPlaces, "TurnT0DecPlaces"},
-		{11, &network.SpeedDecPlaces, "SpeedDecPlaces"},
-		{12, &network.MaxFloatPrecisionFileExport, "MaxFloatPrecisionFileExport"},
-		{13, &network.ConcatMaxLen, "ConcatMaxLen"},
+		{4, &network.LeftHandTraffic, "LEFTHANDTRAFFIC"},
+		{5, &network.CoordDecPlaces, "COORDDECPLACES"},
+		{6, &network.DecPlacesOther, "DECPLACESOTHER"},
+		{7, &network.CurrencyDecPlaces, "CURRENCYDECPLACES"},
+		{8, &network.LongLengthDecPlaces, "LONGLENGTHDECPLACES"},
+		{9, &network.ShortLengthDecPlaces, "SHORTLENGTHDECPLACES"},
+		{10, &network.TurnT0DecPlaces, "TURNT0DECPLACES"},
+		{11, &network.SpeedDecPlaces, "SPEEDDECPLACES"},
+		{12, &network.MaxFloatPrecisionFileExport, "MAXFLOATPRECISIONFILEEXPORT"},
+		{13, &network.ConcatMaxLen, "CONCATMAXLEN"},
 	}
 
 	for _, field := range intFields {
-		if values[field.index] != "" {
-			*field.dest, err = strconv.Atoi(values[field.index])
+		if value := columnValue(values, headers, field.name, field.index); value != "" {
+			*field.dest, err = strconv.Atoi(value)
 			if err != nil {
-				return NetworkData{}, fmt.Errorf("error parsing %s: %w", field.name, err)
+				return NetworkData{}, parseFieldError(field.name, err)
 			}
 		}
 	}
 
 	// Optional fields
-	if len(values) > 14 {
-		network.ConcatSeparator = values[14]
-	}
+	network.ConcatSeparator = columnValue(values, headers, "CONCATSEPARATOR", 14)
 
-	if len(values) > 15 && values[15] != "" {
-		network.CreateModedSeg, err = strconv.Atoi(values[15])
+	if value := columnValue(values, headers, "CREATEMODEDSEG", 15); value != "" {
+		network.CreateModedSeg, err = strconv.Atoi(value)
 		if err != nil {
-			return NetworkData{}, fmt.Errorf("error parsing CreateModedSeg: %w", err)
+			return NetworkData{}, parseFieldError("CREATEMODEDSEG", err)
 		}
 	}
 
-	if len(values) > 16 {
-		network.ProjectionDefinition = values[16]
-	}
-
-	if len(values) > 17 {
-		network.TurnTypeDefault = values[17]
-	}
-
-	if len(values) > 18 {
-		network.LinkOrientationCalculationType = values[18]
-	}
-
-	if len(values) > 19 {
-		network.TransferWaitTimeLimitForReached = values[19]
-	}
-
-	if len(values) > 20 {
-		network.TransferWaitTimeLimitForMissed = values[20]
-	}
+	network.ProjectionDefinition = columnValue(values, headers, "PROJECTIONDEFINITION", 16)
+	network.TurnTypeDefault = columnValue(values, headers, "TURNTYPEDEFAULT", 17)
+	network.LinkOrientationCalculationType = columnValue(values, headers, "LINKORIENTATIONCALCULATIONTYPE", 18)
+	network.TransferWaitTimeLimitForReached = columnValue(values, headers, "TRANSFERWAITTIMELIMITFORREACHED", 19)
+	network.TransferWaitTimeLimitForMissed = columnValue(values, headers, "TRANSFERWAITTIMELIMITFORMISSED", 20)
 
-	if len(values) > 21 && values[21] != "" {
-		network.TransfersOnlyDifferentLines, err = strconv.Atoi(values[21])
+	if value := columnValue(values, headers, "TRANSFERSONLYDIFFERENTLINES", 21); value != "" {
+		network.TransfersOnlyDifferentLines, err = strconv.Atoi(value)
 		if err != nil {
-			return NetworkData{}, fmt.Errorf("error parsing TransfersOnlyDifferentLines: %w", err)
+			return NetworkData{}, parseFieldError("TRANSFERSONLYDIFFERENTLINES", err)
 		}
 	}
 
-	if len(values) > 22 && values[22] != "" {
-		network.StrongLineRouteLengthsAdaption, err = strconv.Atoi(values[22])
+	if value := columnValue(values, headers, "STRONGLINEROUTELENGTHSADAPTION", 22); value != "" {
+		network.StrongLineRouteLengthsAdaption, err = strconv.Atoi(value)
 		if err != nil {
-			return NetworkData{}, fmt.Errorf("error parsing StrongLineRouteLengthsAdaption: %w", err)
+			return NetworkData{}, parseFieldError("STRONGLINEROUTELENGTHSADAPTION", err)
 		}
 	}
 
-	if len(values) > 23 {
-		network.Name = values[23]
-	}
+	network.Name = columnValue(values, headers, "NAME", 23)
 
 	return network, nil
 }
+
+// networkColumns are the columns written when the section has no headers
+var networkColumns = []string{
+	"NETVERSIONID", "NETVERSIONNAME", "SCALE", "UNIT", "LEFTHANDTRAFFIC", "COORDDECPLACES", "DECPLACESOTHER",
+	"CURRENCYDECPLACES", "LONGLENGTHDECPLACES", "SHORTLENGTHDECPLACES", "TURNT0DECPLACES", "SPEEDDECPLACES",
+	"MAXFLOATPRECISIONFILEEXPORT", "CONCATMAXLEN", "CONCATSEPARATOR", "CREATEMODEDSEG", "PROJECTIONDEFINITION",
+	"TURNTYPEDEFAULT", "LINKORIENTATIONCALCULATIONTYPE", "TRANSFERWAITTIMELIMITFORREACHED",
+	"TRANSFERWAITTIMELIMITFORMISSED", "TRANSFERSONLYDIFFERENTLINES", "STRONGLINEROUTELENGTHSADAPTION", "NAME",
+}
+
+func (n NetworkData) attribute(column string) (string, bool) {
+	switch column {
+	case "NETVERSIONID":
+		return n.NetVersionID, true
+	case "NETVERSIONNAME":
+		return n.NetVersionName, true
+	case "SCALE":
+		return formatFloat(n.Scale), true
+	case "UNIT":
+		return n.Unit, true
+	case "LEFTHANDTRAFFIC":
+		return formatInt(n.LeftHandTraffic), true
+	case "COORDDECPLACES":
+		return formatInt(n.CoordDecPlaces), true
+	case "DECPLACESOTHER":
+		return formatInt(n.DecPlacesOther), true
+	case "CURRENCYDECPLACES":
+		return formatInt(n.CurrencyDecPlaces), true
+	case "LONGLENGTHDECPLACES":
+		return formatInt(n.LongLengthDecPlaces), true
+	case "SHORTLENGTHDECPLACES":
+		return formatInt(n.ShortLengthDecPlaces), true
+	case "TURNT0DECPLACES":
+		return formatInt(n.TurnT0DecPlaces), true
+	case "SPEEDDECPLACES":
+		return formatInt(n.SpeedDecPlaces), true
+	case "MAXFLOATPRECISIONFILEEXPORT":
+		return formatInt(n.MaxFloatPrecisionFileExport), true
+	case "CONCATMAXLEN":
+		return formatInt(n.ConcatMaxLen), true
+	case "CONCATSEPARATOR":
+		return n.ConcatSeparator, true
+	case "CREATEMODEDSEG":
+		return formatInt(n.CreateModedSeg), true
+	case "PROJECTIONDEFINITION":
+		return n.ProjectionDefinition, true
+	case "TURNTYPEDEFAULT":
+		return n.TurnTypeDefault, true
+	case "LINKORIENTATIONCALCULATIONTYPE":
+		return n.LinkOrientationCalculationType, true
+	case "TRANSFERWAITTIMELIMITFORREACHED":
+		return n.TransferWaitTimeLimitForReached, true
+	case "TRANSFERWAITTIMELIMITFORMISSED":
+		return n.TransferWaitTimeLimitForMissed, true
+	case "TRANSFERSONLYDIFFERENTLINES":
+		return formatInt(n.TransfersOnlyDifferentLines), true
+	case "STRONGLINEROUTELENGTHSADAPTION":
+		return formatInt(n.StrongLineRouteLengthsAdaption), true
+	case "NAME":
+		return n.Name, true
+	}
+	return "", false
+}
@@ -1,7 +1,6 @@
 package ptvvisum
 
 import (
-	"fmt"
 	"math"
 	"strconv"
 	"strings"
@@ -11,37 +10,34 @@ import (
 type NodeSection struct {
 	BaseSection
 	Nodes []Node
+
+	byID index[Node, int]
 }
 
 // Node represents a single node in the network (typically an intersection)
 type Node struct {
-	ID              int     // Node identifier (NO)
-	Code            string  // Node code
-	Name            string  // Node name
-	TypeNo          int     // Node type number
-	ControlType     int     // Control type (0=uncontrolled, 1=priority, 2=signalized, etc.)
-	MainNodeNo      int     // Main node number (for complex intersections)
-	XCoord          float64 // X-coordinate
-	YCoord          float64 // Y-coordinate
-	ZCoord          float64 // Z-coordinate (elevation)
-	AddVal1         int     // Additional value 1
-	AddVal2         int     // Additional value 2
-	AddVal3         int     // Additional value 3
-	T0PRT           string  // Base travel time for public transport
-	CapPRT          int     // Capacity for private transport
-	LaneDef         int     // Lane definition
-	Notes           string  // Notes/comments
-	RailwayCrossing int     // Railway crossing flag
+	ID              int      // Node identifier (NO)
+	Code            string   // Node code
+	Name            string   // Node name
+	TypeNo          int      // Node type number
+	ControlType     int      // Control type (0=uncontrolled, 1=priority, 2=signalized, etc.)
+	MainNodeNo      int      // Main node number (for complex intersections)
+	XCoord          float64  // X-coordinate
+	YCoord          float64  // Y-coordinate
+	ZCoord          float64  // Z-coordinate (elevation)
+	AddVal1         int      // Additional value 1
+	AddVal2         int      // Additional value 2
+	AddVal3         int      // Additional value 3
+	T0PRT           Duration // Base travel time for private transport
+	CapPRT          int      // Capacity for private transport
+	LaneDef         int      // Lane definition
+	Notes           string   // Notes/comments
+	RailwayCrossing int      // Railway crossing flag
 }
 
 // GetNodeByID retrieves a node by its ID
 func (s *NodeSection) GetNodeByID(id int) (Node, bool) {
-	for _, node := range s.Nodes {
-		if node.ID == id {
-			return node, true
-		}
-	}
-	return Node{}, false
+	return s.byID.findFirst(s.Nodes, id, func(node Node) int { return node.ID })
 }
 
 // GetNodesByType retrieves all nodes of a specified type
@@ -55,6 +51,17 @@ func (s *NodeSection) GetNodesByType(typeNo int) []Node {
 	return result
 }
 
+// GetNodesByMainNode retrieves all member nodes of a main node
+func (s *NodeSection) GetNodesByMainNode(mainNodeNo int) []Node {
+	var result []Node
+	for _, node := range s.Nodes {
+		if node.MainNodeNo == mainNodeNo {
+			result = append(result, node)
+		}
+	}
+	return result
+}
+
 // GetNodesByControlType retrieves all nodes with a specified control type
 func (s *NodeSection) GetNodesByControlType(controlType int) []Node {
 	var result []Node
@@ -109,122 +116,174 @@ func (s *NodeSection) CalculateBoundingBox() (minX, minY, maxX, maxY float64) {
 	return minX, minY, maxX, maxY
 }
 
+// Reindex drops the lookup indexes after IDs of nodes have been changed in place, they are built again on next use
+func (s *NodeSection) Reindex() {
+	s.byID.reset()
+}
+
 // Count returns the number of nodes in the section
 func (s *NodeSection) Count() int {
 	return len(s.Nodes)
 }
 
 // getNode extracts data from NODE section row
-func getNode(values []string) (Node, error) {
-	if len(values) < 10 {
-		return Node{}, fmt.Errorf("invalid NODE data (insufficient fields): %v", values)
-	}
-
+func getNode(values []string, headers []string) (Node, error) {
 	var node Node
 	var err error
 
 	// Parse NO (required field)
-	if values[0] == "" {
-		return Node{}, fmt.Errorf("missing required field NO")
+	value := columnValue(values, headers, "NO", 0)
+	if value == "" {
+		return Node{}, missingFieldError("NO")
 	}
-	node.ID, err = strconv.Atoi(values[0])
+	node.ID, err = strconv.Atoi(value)
 	if err != nil {
-		return Node{}, fmt.Errorf("error parsing NO: %w", err)
+		return Node{}, parseFieldError("NO", err)
 	}
 
 	// Parse CODE (optional)
-	node.Code = values[1]
+	node.Code = columnValue(values, headers, "CODE", 1)
 
 	// Parse NAME (optional)
-	node.Name = values[2]
+	node.Name = columnValue(values, headers, "NAME", 2)
 
 	// Parse TYPENO (required field)
-	if values[3] != "" {
-		node.TypeNo, err = strconv.Atoi(values[3])
+	if value := columnValue(values, headers, "TYPENO", 3); value != "" {
+		node.TypeNo, err = strconv.Atoi(value)
 		if err != nil {
-			return Node{}, fmt.Errorf("error parsing TYPENO: %w", err)
+			return Node{}, parseFieldError("TYPENO", err)
 		}
 	}
 
 	// Parse CONTROLTYPE (required field)
-	if values[4] != "" {
-		node.ControlType, err = strconv.Atoi(values[4])
+	if value := columnValue(values, headers, "CONTROLTYPE", 4); value != "" {
+		node.ControlType, err = strconv.Atoi(value)
 		if err != nil {
-			return Node{}, fmt.Errorf("error parsing CONTROLTYPE: %w", err)
+			return Node{}, parseFieldError("CONTROLTYPE", err)
 		}
 	}
 
 	// Parse MAINNODENO (required field)
-	if values[5] != "" {
-		node.MainNodeNo, err = strconv.Atoi(values[5])
+	if value := columnValue(values, headers, "MAINNODENO", 5); value != "" {
+		node.MainNodeNo, err = strconv.Atoi(value)
 		if err != nil {
-			return Node{}, fmt.Errorf("error parsing MAINNODENO: %w", err)
+			return Node{}, parseFieldError("MAINNODENO", err)
 		}
 	}
 
 	// Skip fields 6 and 7 (USEMETHODIMPATNODE, METHODIMPATNODE) for brevity
 
 	// Parse XCOORD (required field)
-	if values[9] == "" {
-		return Node{}, fmt.Errorf("missing required field XCOORD")
+	value = columnValue(values, headers, "XCOORD", 9)
+	if value == "" {
+		return Node{}, missingFieldError("XCOORD")
 	}
-	node.XCoord, err = strconv.ParseFloat(strings.Replace(values[9], ",", ".", -1), 64)
+	node.XCoord, err = strconv.ParseFloat(strings.Replace(value, ",", ".", -1), 64)
 	if err != nil {
-		return Node{}, fmt.Errorf("error parsing XCOORD: %w", err)
+		return Node{}, parseFieldError("XCOORD", err)
 	}
 
 	// Parse YCOORD (required field)
-	if values[10] == "" {
-		return Node{}, fmt.Errorf("missing required field YCOORD")
+	value = columnValue(values, headers, "YCOORD", 10)
+	if value == "" {
+		return Node{}, missingFieldError("YCOORD")
 	}
-	node.YCoord, err = strconv.ParseFloat(strings.Replace(values[10], ",", ".", -1), 64)
+	node.YCoord, err = strconv.ParseFloat(strings.Replace(value, ",", ".", -1), 64)
 	if err != nil {
-		return Node{}, fmt.Errorf("error parsing YCOORD: %w", err)
+		return Node{}, parseFieldError("YCOORD", err)
 	}
 
 	// Parse ZCOORD (optional but usually present)
-	if len(values) > 11 && values[11] != "" {
-		node.ZCoord, err = strconv.ParseFloat(strings.Replace(values[11], ",", ".", -1), 64)
+	if value := columnValue(values, headers, "ZCOORD", 11); value != "" {
+		node.ZCoord, err = strconv.ParseFloat(strings.Replace(value, ",", ".", -1), 64)
 		if err != nil {
-			return Node{}, fmt.Errorf("error parsing ZCOORD: %w", err)
+			return Node{}, parseFieldError("ZCOORD", err)
 		}
 	}
 
 	// Parse ADDVAL1, ADDVAL2, ADDVAL3 (optional)
-	if len(values) > 12 && values[12] != "" {
-		node.AddVal1, _ = strconv.Atoi(values[12])
+	if value := columnValue(values, headers, "ADDVAL1", 12); value != "" {
+		node.AddVal1, _ = strconv.Atoi(value)
 	}
-	if len(values) > 13 && values[13] != "" {
-		node.AddVal2, _ = strconv.Atoi(values[13])
+	if value := columnValue(values, headers, "ADDVAL2", 13); value != "" {
+		node.AddVal2, _ = strconv.Atoi(value)
 	}
-	if len(values) > 14 && values[14] != "" {
-		node.AddVal3, _ = strconv.Atoi(values[14])
+	if value := columnValue(values, headers, "ADDVAL3", 14); value != "" {
+		node.AddVal3, _ = strconv.Atoi(value)
 	}
 
 	// Parse T0PRT (optional) - contains time values like "13s"
-	if len(values) > 15 && values[15] != "" {
-		node.T0PRT = values[15]
+	if value := columnValue(values, headers, "T0PRT", 15); value != "" {
+		node.T0PRT, err = parseDuration(value)
+		if err != nil {
+			return Node{}, parseFieldError("T0PRT", err)
+		}
 	}
 
 	// Parse CAPPRT (optional) - capacity value
-	if len(values) > 16 && values[16] != "" {
-		node.CapPRT, _ = strconv.Atoi(strings.Replace(values[16], ",", "", -1))
+	if value := columnValue(values, headers, "CAPPRT", 16); value != "" {
+		node.CapPRT, _ = strconv.Atoi(strings.Replace(value, ",", "", -1))
 	}
 
 	// Parse LANEDEF (optional)
-	if len(values) > 17 && values[17] != "" {
-		node.LaneDef, _ = strconv.Atoi(values[17])
+	if value := columnValue(values, headers, "LANEDEF", 17); value != "" {
+		node.LaneDef, _ = strconv.Atoi(value)
 	}
 
-	// Parse NOTES (optional) - available at a specific index in your data
-	if len(values) > 31 && values[31] != "" {
-		node.Notes = values[31]
-	}
+	// Parse NOTES (optional)
+	node.Notes = columnValue(values, headers, "NOTES", 31)
 
-	// Parse RAILWAY_CROSSING (optional) - available at the end of your data
-	if len(values) > 45 && values[45] != "" {
-		node.RailwayCrossing, _ = strconv.Atoi(values[45])
+	// Parse RAILWAY_CROSSING (optional) - user-defined attribute at the end of the row
+	if value := columnValue(values, headers, "RAILWAY_CROSSING", 46); value != "" {
+		node.RailwayCrossing, _ = strconv.Atoi(value)
 	}
 
 	return node, nil
 }
+
+// nodeColumns are the columns written when the section has no headers
+var nodeColumns = []string{
+	"NO", "CODE", "NAME", "TYPENO", "CONTROLTYPE", "MAINNODENO", "USEMETHODIMPATNODE", "METHODIMPATNODE",
+	"AUTOLINKORIENTATION", "XCOORD", "YCOORD", "ZCOORD", "ADDVAL1", "ADDVAL2", "ADDVAL3", "T0PRT", "CAPPRT", "LANEDEF",
+	"NOTES", "RAILWAY_CROSSING",
+}
+
+func (node Node) attribute(column string) (string, bool) {
+	switch column {
+	case "NO":
+		return formatInt(node.ID), true
+	case "CODE":
+		return node.Code, true
+	case "NAME":
+		return node.Name, true
+	case "TYPENO":
+		return formatInt(node.TypeNo), true
+	case "CONTROLTYPE":
+		return formatInt(node.ControlType), true
+	case "MAINNODENO":
+		return formatInt(node.MainNodeNo), true
+	case "XCOORD":
+		return formatFloat(node.XCoord), true
+	case "YCOORD":
+		return formatFloat(node.YCoord), true
+	case "ZCOORD":
+		return formatFloat(node.ZCoord), true
+	case "ADDVAL1":
+		return formatInt(node.AddVal1), true
+	case "ADDVAL2":
+		return formatInt(node.AddVal2), true
+	case "ADDVAL3":
+		return formatInt(node.AddVal3), true
+	case "T0PRT":
+		return node.T0PRT.String(), true
+	case "CAPPRT":
+		return formatInt(node.CapPRT), true
+	case "LANEDEF":
+		return formatInt(node.LaneDef), true
+	case "NOTES":
+		return node.Notes, true
+	case "RAILWAY_CROSSING":
+		return formatInt(node.RailwayCrossing), true
+	}
+	return "", false
+}
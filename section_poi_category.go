@@ -16,25 +16,71 @@ type POICategory struct {
 	ParentCatNo int
 }
 
-func getPoiCategory(values []string) (POICategory, error) {
-	if len(values) < 5 {
-		return POICategory{}, fmt.Errorf("invalid POICATEGORY data: %v", values)
+// GetCategoryByID retrieves a POI category by its number
+func (s *POICategorySection) GetCategoryByID(no int) (POICategory, bool) {
+	for _, category := range s.Categories {
+		if category.No == no {
+			return category, true
+		}
+	}
+	return POICategory{}, false
+}
+
+// GetCategoryPath retrieves a POI category followed by its parent categories up to the root
+func (s *POICategorySection) GetCategoryPath(no int) []POICategory {
+	var path []POICategory
+	visited := make(map[int]bool)
+	for no != 0 && !visited[no] {
+		visited[no] = true
+		category, found := s.GetCategoryByID(no)
+		if !found {
+			break
+		}
+		path = append(path, category)
+		no = category.ParentCatNo
+	}
+	return path
+}
+
+func getPoiCategory(values []string, headers []string) (POICategory, error) {
+	value := columnValue(values, headers, "NO", 0)
+	if value == "" {
+		return POICategory{}, missingFieldError("NO")
 	}
 	var no int
-	if _, err := fmt.Sscanf(values[0], "%d", &no); err != nil {
-		return POICategory{}, fmt.Errorf("error parsing No: %w", err)
+	if _, err := fmt.Sscanf(value, "%d", &no); err != nil {
+		return POICategory{}, parseFieldError("NO", err)
 	}
 	parentCatNo := 0
-	if values[4] != "" {
-		if _, err := fmt.Sscanf(values[4], "%d", &parentCatNo); err != nil {
-			return POICategory{}, fmt.Errorf("error parsing ParentCatNo: %w", err)
+	if value := columnValue(values, headers, "PARENTCATNO", 4); value != "" {
+		if _, err := fmt.Sscanf(value, "%d", &parentCatNo); err != nil {
+			return POICategory{}, parseFieldError("PARENTCATNO", err)
 		}
 	}
 	return POICategory{
 		No:          no,
-		Code:        values[1],
-		Name:        values[2],
-		Comment:     values[3],
+		Code:        columnValue(values, headers, "CODE", 1),
+		Name:        columnValue(values, headers, "NAME", 2),
+		Comment:     columnValue(values, headers, "COMMENT", 3),
 		ParentCatNo: parentCatNo,
 	}, nil
 }
+
+// poiCategoryColumns are the columns written when the section has no headers
+var poiCategoryColumns = []string{"NO", "CODE", "NAME", "COMMENT", "PARENTCATNO"}
+
+func (c POICategory) attribute(column string) (string, bool) {
+	switch column {
+	case "NO":
+		return formatInt(c.No), true
+	case "CODE":
+		return c.Code, true
+	case "NAME":
+		return c.Name, true
+	case "COMMENT":
+		return c.Comment, true
+	case "PARENTCATNO":
+		return formatInt(c.ParentCatNo), true
+	}
+	return "", false
+}
@@ -1,7 +1,6 @@
 package ptvvisum
 
 import (
-	"fmt"
 	"math"
 	"strconv"
 	"strings"
@@ -11,6 +10,8 @@ import (
 type PointSection struct {
 	BaseSection
 	Points []Point
+
+	byID index[Point, int]
 }
 
 // Point represents a single point with coordinates
@@ -22,12 +23,12 @@ type Point struct {
 
 // GetPointByID retrieves a point by its ID
 func (s *PointSection) GetPointByID(id int) (Point, bool) {
-	for _, p := range s.Points {
-		if p.ID == id {
-			return p, true
-		}
-	}
-	return Point{}, false
+	return s.byID.findFirst(s.Points, id, func(p Point) int { return p.ID })
+}
+
+// Reindex drops the lookup index after point IDs have been changed in place, it is built again on next use
+func (s *PointSection) Reindex() {
+	s.byID.reset()
 }
 
 // GetPointsInArea returns all points within a bounding box
@@ -50,40 +51,54 @@ func (p Point) Distance(other Point) float64 {
 }
 
 // getPoint extracts data from POINT section row
-func getPoint(values []string) (Point, error) {
-	if len(values) < 3 {
-		return Point{}, fmt.Errorf("invalid POINT data: %v", values)
-	}
-
+func getPoint(values []string, headers []string) (Point, error) {
 	var point Point
 	var err error
 
 	// Parse ID (required field)
-	if values[0] == "" {
-		return Point{}, fmt.Errorf("missing required field ID")
+	value := columnValue(values, headers, "ID", 0)
+	if value == "" {
+		return Point{}, missingFieldError("ID")
 	}
-	point.ID, err = strconv.Atoi(values[0])
+	point.ID, err = strconv.Atoi(value)
 	if err != nil {
-		return Point{}, fmt.Errorf("error parsing ID: %w", err)
+		return Point{}, parseFieldError("ID", err)
 	}
 
 	// Parse XCOORD (required field)
-	if values[1] == "" {
-		return Point{}, fmt.Errorf("missing required field XCOORD")
+	value = columnValue(values, headers, "XCOORD", 1)
+	if value == "" {
+		return Point{}, missingFieldError("XCOORD")
 	}
-	point.XCoord, err = strconv.ParseFloat(strings.Replace(values[1], ",", ".", -1), 64)
+	point.XCoord, err = strconv.ParseFloat(strings.Replace(value, ",", ".", -1), 64)
 	if err != nil {
-		return Point{}, fmt.Errorf("error parsing XCOORD: %w", err)
+		return Point{}, parseFieldError("XCOORD", err)
 	}
 
 	// Parse YCOORD (required field)
-	if values[2] == "" {
-		return Point{}, fmt.Errorf("missing required field YCOORD")
+	value = columnValue(values, headers, "YCOORD", 2)
+	if value == "" {
+		return Point{}, missingFieldError("YCOORD")
 	}
-	point.YCoord, err = strconv.ParseFloat(strings.Replace(values[2], ",", ".", -1), 64)
+	point.YCoord, err = strconv.ParseFloat(strings.Replace(value, ",", ".", -1), 64)
 	if err != nil {
-		return Point{}, fmt.Errorf("error parsing YCOORD: %w", err)
+		return Point{}, parseFieldError("YCOORD", err)
 	}
 
 	return point, nil
 }
+
+// pointColumns are the columns written when the section has no headers
+var pointColumns = []string{"ID", "XCOORD", "YCOORD"}
+
+func (p Point) attribute(column string) (string, bool) {
+	switch column {
+	case "ID":
+		return formatInt(p.ID), true
+	case "XCOORD":
+		return formatFloat(p.XCoord), true
+	case "YCOORD":
+		return formatFloat(p.YCoord), true
+	}
+	return "", false
+}
@@ -0,0 +1,204 @@
+package ptvvisum
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// POISection represents a $POIOFCAT_<n> section, a file has one for every POI category with points of interest
+type POISection struct {
+	BaseSection
+	CategoryNo int // Number of the POI category, n in the section name
+	POIs       []POI
+
+	byID index[POI, int]
+}
+
+// POI represents a point of interest
+type POI struct {
+	CatNo          int     // POI category number
+	No             int     // POI number, unique within the category
+	Code           string  // POI code
+	Name           string  // POI name
+	Comment        string  // Comment
+	ImageFileName  string  // Image shown for the POI
+	UseImageFile   int     // Whether the image is shown (0/1)
+	XCoord         float64 // X-coordinate
+	YCoord         float64 // Y-coordinate
+	SurfaceID      int     // Surface of the POI, 0 for POIs without polygon
+	ImageHeight    float64 // Height of the image
+	UseImageHeight int     // Whether the image height is used (0/1)
+	ImageAngle     float64 // Rotation of the image in degrees
+	LabelPosRelX   float64 // X coordinate for label
+	LabelPosRelY   float64 // Y coordinate for label
+}
+
+// poiCategoryNo extracts the category number from a POI section name like POIOFCAT_32
+func poiCategoryNo(name string) (int, bool) {
+	no, found := strings.CutPrefix(name, "POIOFCAT_")
+	if !found {
+		return 0, false
+	}
+	catNo, err := strconv.Atoi(no)
+	return catNo, err == nil
+}
+
+// GetPOIByID retrieves a POI by its number
+func (s *POISection) GetPOIByID(no int) (POI, bool) {
+	return s.byID.findFirst(s.POIs, no, func(poi POI) int { return poi.No })
+}
+
+// GetCategory retrieves the POI category of the section
+func (s *POISection) GetCategory(data *PTVData) (POICategory, bool) {
+	if data.POICategory == nil {
+		return POICategory{}, false
+	}
+	return data.POICategory.GetCategoryByID(s.CategoryNo)
+}
+
+// GetCategoryPath retrieves the POI category of the section followed by its parent categories up to the root
+func (s *POISection) GetCategoryPath(data *PTVData) []POICategory {
+	if data.POICategory == nil {
+		return nil
+	}
+	return data.POICategory.GetCategoryPath(s.CategoryNo)
+}
+
+// GetSurfaceGeometry builds the polygon of a POI, outer boundaries and inner holes (enclaves) separately.
+// Both are empty for POIs without surface
+func (s *POISection) GetSurfaceGeometry(no int, data *PTVData) (outer [][][2]float64, inner [][][2]float64) {
+	poi, found := s.GetPOIByID(no)
+	if !found || poi.SurfaceID == 0 || data.SurfaceItem == nil {
+		return nil, nil
+	}
+	return data.SurfaceItem.GetSurfaceGeometry(poi.SurfaceID, data)
+}
+
+// Reindex drops the lookup index after POI numbers have been changed in place, it is built again on next use
+func (s *POISection) Reindex() {
+	s.byID.reset()
+}
+
+// Count returns the number of POIs in the section
+func (s *POISection) Count() int {
+	return len(s.POIs)
+}
+
+// getPOI extracts data from POIOFCAT_<n> section row of the given category
+func getPOI(values []string, headers []string, catNo int) (POI, error) {
+	var poi POI
+	var err error
+
+	// Parse CATNO (optional, the category of the section if missing)
+	poi.CatNo = catNo
+	if value := columnValue(values, headers, "CATNO", 0); value != "" {
+		poi.CatNo, err = strconv.Atoi(value)
+		if err != nil {
+			return POI{}, parseFieldError("CATNO", err)
+		}
+		if poi.CatNo != catNo {
+			return POI{}, parseFieldError("CATNO", fmt.Errorf("category %d in section of category %d", poi.CatNo, catNo))
+		}
+	}
+
+	// Parse NO (required field)
+	value := columnValue(values, headers, "NO", 1)
+	if value == "" {
+		return POI{}, missingFieldError("NO")
+	}
+	poi.No, err = strconv.Atoi(value)
+	if err != nil {
+		return POI{}, parseFieldError("NO", err)
+	}
+
+	poi.Code = columnValue(values, headers, "CODE", 2)
+	poi.Name = columnValue(values, headers, "NAME", 3)
+	poi.Comment = columnValue(values, headers, "COMMENT", 4)
+	poi.ImageFileName = columnValue(values, headers, "IMAGEFILENAME", 5)
+
+	// Parse integer fields (optional)
+	intFields := []struct {
+		index int
+		dest  *int
+		name  string
+	}{
+		{6, &poi.UseImageFile, "USEIMAGEFILE"},
+		{9, &poi.SurfaceID, "SURFACEID"},
+		{11, &poi.UseImageHeight, "USEIMAGEHEIGHT"},
+	}
+	for _, field := range intFields {
+		if value := columnValue(values, headers, field.name, field.index); value != "" {
+			*field.dest, err = strconv.Atoi(value)
+			if err != nil {
+				return POI{}, parseFieldError(field.name, err)
+			}
+		}
+	}
+
+	// Parse float fields (optional)
+	floatFields := []struct {
+		index int
+		dest  *float64
+		name  string
+	}{
+		{7, &poi.XCoord, "XCOORD"},
+		{8, &poi.YCoord, "YCOORD"},
+		{10, &poi.ImageHeight, "IMAGEHEIGHT"},
+		{12, &poi.ImageAngle, "IMAGEANGLE"},
+		{13, &poi.LabelPosRelX, "LABELPOSRELX"},
+		{14, &poi.LabelPosRelY, "LABELPOSRELY"},
+	}
+	for _, field := range floatFields {
+		if value := columnValue(values, headers, field.name, field.index); value != "" {
+			*field.dest, err = strconv.ParseFloat(strings.Replace(value, ",", ".", -1), 64)
+			if err != nil {
+				return POI{}, parseFieldError(field.name, err)
+			}
+		}
+	}
+
+	return poi, nil
+}
+
+// poiColumns are the columns written when the section has no headers
+var poiColumns = []string{
+	"CATNO", "NO", "CODE", "NAME", "COMMENT", "IMAGEFILENAME", "USEIMAGEFILE", "XCOORD", "YCOORD", "SURFACEID",
+	"IMAGEHEIGHT", "USEIMAGEHEIGHT", "IMAGEANGLE", "LABELPOSRELX", "LABELPOSRELY",
+}
+
+func (poi POI) attribute(column string) (string, bool) {
+	switch column {
+	case "CATNO":
+		return formatInt(poi.CatNo), true
+	case "NO":
+		return formatInt(poi.No), true
+	case "CODE":
+		return poi.Code, true
+	case "NAME":
+		return poi.Name, true
+	case "COMMENT":
+		return poi.Comment, true
+	case "IMAGEFILENAME":
+		return poi.ImageFileName, true
+	case "USEIMAGEFILE":
+		return formatInt(poi.UseImageFile), true
+	case "XCOORD":
+		return formatFloat(poi.XCoord), true
+	case "YCOORD":
+		return formatFloat(poi.YCoord), true
+	case "SURFACEID":
+		return formatOptionalInt(poi.SurfaceID), true
+	case "IMAGEHEIGHT":
+		return formatFloat(poi.ImageHeight), true
+	case "USEIMAGEHEIGHT":
+		return formatInt(poi.UseImageHeight), true
+	case "IMAGEANGLE":
+		return formatFloat(poi.ImageAngle), true
+	case "LABELPOSRELX":
+		return formatFloat(poi.LabelPosRelX), true
+	case "LABELPOSRELY":
+		return formatFloat(poi.LabelPosRelY), true
+	}
+	return "", false
+}
@@ -0,0 +1,103 @@
+package ptvvisum
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ScreenlinePolySection represents $SCREENLINEPOLY section
+type ScreenlinePolySection struct {
+	BaseSection
+	Points []ScreenlinePoint
+
+	byScreenline index[ScreenlinePoint, int]
+}
+
+// ScreenlinePoint represents a single point of the polyline of a screenline
+type ScreenlinePoint struct {
+	ScreenlineNo int     // Screenline the point belongs to
+	Index        int     // Position of the point along the screenline
+	XCoord       float64 // X coordinate
+	YCoord       float64 // Y coordinate
+}
+
+// GetPointsByScreenline retrieves the points of a screenline ordered by index
+func (s *ScreenlinePolySection) GetPointsByScreenline(screenlineNo int) []ScreenlinePoint {
+	result := s.byScreenline.find(s.Points, screenlineNo, func(point ScreenlinePoint) int { return point.ScreenlineNo })
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Index < result[j].Index
+	})
+	return result
+}
+
+// Reindex drops the lookup index after screenlines of points have been changed in place, it is built again on next use
+func (s *ScreenlinePolySection) Reindex() {
+	s.byScreenline.reset()
+}
+
+// Count returns the number of points in the section
+func (s *ScreenlinePolySection) Count() int {
+	return len(s.Points)
+}
+
+// getScreenlinePoint extracts data from SCREENLINEPOLY section row
+func getScreenlinePoint(values []string, headers []string) (ScreenlinePoint, error) {
+	var point ScreenlinePoint
+	var err error
+
+	// Parse SCREENLINENO and INDEX (required fields)
+	value := columnValue(values, headers, "SCREENLINENO", 0)
+	if value == "" {
+		return ScreenlinePoint{}, missingFieldError("SCREENLINENO")
+	}
+	point.ScreenlineNo, err = strconv.Atoi(value)
+	if err != nil {
+		return ScreenlinePoint{}, parseFieldError("SCREENLINENO", err)
+	}
+	value = columnValue(values, headers, "INDEX", 1)
+	if value == "" {
+		return ScreenlinePoint{}, missingFieldError("INDEX")
+	}
+	point.Index, err = strconv.Atoi(value)
+	if err != nil {
+		return ScreenlinePoint{}, parseFieldError("INDEX", err)
+	}
+
+	// Parse XCOORD and YCOORD (required fields)
+	value = columnValue(values, headers, "XCOORD", 2)
+	if value == "" {
+		return ScreenlinePoint{}, missingFieldError("XCOORD")
+	}
+	point.XCoord, err = strconv.ParseFloat(strings.Replace(value, ",", ".", -1), 64)
+	if err != nil {
+		return ScreenlinePoint{}, parseFieldError("XCOORD", err)
+	}
+	value = columnValue(values, headers, "YCOORD", 3)
+	if value == "" {
+		return ScreenlinePoint{}, missingFieldError("YCOORD")
+	}
+	point.YCoord, err = strconv.ParseFloat(strings.Replace(value, ",", ".", -1), 64)
+	if err != nil {
+		return ScreenlinePoint{}, parseFieldError("YCOORD", err)
+	}
+
+	return point, nil
+}
+
+// screenlinePointColumns are the columns written when the section has no headers
+var screenlinePointColumns = []string{"SCREENLINENO", "INDEX", "XCOORD", "YCOORD"}
+
+func (point ScreenlinePoint) attribute(column string) (string, bool) {
+	switch column {
+	case "SCREENLINENO":
+		return formatInt(point.ScreenlineNo), true
+	case "INDEX":
+		return formatInt(point.Index), true
+	case "XCOORD":
+		return formatFloat(point.XCoord), true
+	case "YCOORD":
+		return formatFloat(point.YCoord), true
+	}
+	return "", false
+}
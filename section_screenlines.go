@@ -0,0 +1,138 @@
+package ptvvisum
+
+import "strconv"
+
+// ScreenlineSection represents $SCREENLINE section
+type ScreenlineSection struct {
+	BaseSection
+	Screenlines []Screenline
+
+	byID index[Screenline, int]
+}
+
+// Screenline represents a polyline across the network used to compare counted and assigned volumes
+type Screenline struct {
+	No   int    // Screenline number
+	Code string // Screenline code
+	Name string // Screenline name
+}
+
+// GetScreenlineByID retrieves a screenline by its number
+func (s *ScreenlineSection) GetScreenlineByID(no int) (Screenline, bool) {
+	return s.byID.findFirst(s.Screenlines, no, func(screenline Screenline) int { return screenline.No })
+}
+
+// GetGeometry returns the points of a screenline in order
+func (s *ScreenlineSection) GetGeometry(no int, data *PTVData) [][2]float64 {
+	if data.ScreenlinePoly == nil {
+		return nil
+	}
+	points := data.ScreenlinePoly.GetPointsByScreenline(no)
+	geometry := make([][2]float64, len(points))
+	for i, point := range points {
+		geometry[i] = [2]float64{point.XCoord, point.YCoord}
+	}
+	return geometry
+}
+
+// GetCrossedLinks retrieves the links whose course intersects a screenline, each direction separately
+func (s *ScreenlineSection) GetCrossedLinks(no int, data *PTVData) []Link {
+	screenline := s.GetGeometry(no, data)
+	if len(screenline) < 2 || data.Link == nil {
+		return nil
+	}
+	var result []Link
+	for _, link := range data.Link.Links {
+		if geometry, found := linkGeometry(link, data); found && polylinesIntersect(screenline, geometry) {
+			result = append(result, link)
+		}
+	}
+	return result
+}
+
+// GetCountLocations retrieves the count locations on the links crossed by a screenline
+func (s *ScreenlineSection) GetCountLocations(no int, data *PTVData) []CountLocation {
+	if data.CountLocation == nil {
+		return nil
+	}
+	var result []CountLocation
+	for _, link := range s.GetCrossedLinks(no, data) {
+		result = append(result, data.CountLocation.GetCountLocationsByLink(link.No, link.FromNodeNo)...)
+	}
+	return result
+}
+
+// Reindex drops the lookup index after numbers of screenlines have been changed in place, it is built again on next use
+func (s *ScreenlineSection) Reindex() {
+	s.byID.reset()
+}
+
+// Count returns the number of screenlines in the section
+func (s *ScreenlineSection) Count() int {
+	return len(s.Screenlines)
+}
+
+// polylinesIntersect reports whether any segment of one polyline touches or crosses any segment of the other
+func polylinesIntersect(a, b [][2]float64) bool {
+	for i := 1; i < len(a); i++ {
+		for j := 1; j < len(b); j++ {
+			if segmentsIntersect(a[i-1], a[i], b[j-1], b[j]) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// segmentsIntersect reports whether the segments p1-p2 and q1-q2 have a point in common
+func segmentsIntersect(p1, p2, q1, q2 [2]float64) bool {
+	cross := func(o, a, b [2]float64) float64 {
+		return (a[0]-o[0])*(b[1]-o[1]) - (a[1]-o[1])*(b[0]-o[0])
+	}
+	onSegment := func(p, q, r [2]float64) bool {
+		return min(p[0], r[0]) <= q[0] && q[0] <= max(p[0], r[0]) && min(p[1], r[1]) <= q[1] && q[1] <= max(p[1], r[1])
+	}
+	d1, d2 := cross(q1, q2, p1), cross(q1, q2, p2)
+	d3, d4 := cross(p1, p2, q1), cross(p1, p2, q2)
+	if ((d1 > 0 && d2 < 0) || (d1 < 0 && d2 > 0)) && ((d3 > 0 && d4 < 0) || (d3 < 0 && d4 > 0)) {
+		return true
+	}
+	return d1 == 0 && onSegment(q1, p1, q2) || d2 == 0 && onSegment(q1, p2, q2) ||
+		d3 == 0 && onSegment(p1, q1, p2) || d4 == 0 && onSegment(p1, q2, p2)
+}
+
+// getScreenline extracts data from SCREENLINE section row
+func getScreenline(values []string, headers []string) (Screenline, error) {
+	var screenline Screenline
+	var err error
+
+	// Parse NO (required field)
+	value := columnValue(values, headers, "NO", 0)
+	if value == "" {
+		return Screenline{}, missingFieldError("NO")
+	}
+	screenline.No, err = strconv.Atoi(value)
+	if err != nil {
+		return Screenline{}, parseFieldError("NO", err)
+	}
+
+	screenline.Code = columnValue(values, headers, "CODE", 1)
+	screenline.Name = columnValue(values, headers, "NAME", 2)
+
+	return screenline, nil
+}
+
+// screenlineColumns are the columns written when the section has no headers
+var screenlineColumns = []string{"NO", "CODE", "NAME"}
+
+func (screenline Screenline) attribute(column string) (string, bool) {
+	switch column {
+	case "NO":
+		return formatInt(screenline.No), true
+	case "CODE":
+		return screenline.Code, true
+	case "NAME":
+		return screenline.Name, true
+	}
+	return "", false
+}
@@ -0,0 +1,79 @@
+package ptvvisum
+
+import "strconv"
+
+// SignalControlToNodeSection represents $SIGNALCONTROLTONODE section
+type SignalControlToNodeSection struct {
+	BaseSection
+	Items []SignalControlToNode
+
+	bySignalControl index[SignalControlToNode, int]
+	byNode          index[SignalControlToNode, int]
+}
+
+// SignalControlToNode assigns a node to the signal control controlling it
+type SignalControlToNode struct {
+	SCNo   int // Signal control number
+	NodeNo int // Controlled node
+}
+
+// GetItemsBySignalControl retrieves the assignments of a signal control
+func (s *SignalControlToNodeSection) GetItemsBySignalControl(scNo int) []SignalControlToNode {
+	return s.bySignalControl.find(s.Items, scNo, func(item SignalControlToNode) int { return item.SCNo })
+}
+
+// GetSignalControlNo returns the number of the signal control controlling a node
+func (s *SignalControlToNodeSection) GetSignalControlNo(nodeNo int) (int, bool) {
+	item, ok := s.byNode.findFirst(s.Items, nodeNo, func(item SignalControlToNode) int { return item.NodeNo })
+	return item.SCNo, ok
+}
+
+// Reindex drops the lookup indexes after assignments have been changed in place, they are built again on next use
+func (s *SignalControlToNodeSection) Reindex() {
+	s.bySignalControl.reset()
+	s.byNode.reset()
+}
+
+// Count returns the number of assignments in the section
+func (s *SignalControlToNodeSection) Count() int {
+	return len(s.Items)
+}
+
+// getSignalControlToNode extracts data from SIGNALCONTROLTONODE section row
+func getSignalControlToNode(values []string, headers []string) (SignalControlToNode, error) {
+	var item SignalControlToNode
+	var err error
+
+	// Parse SCNO and NODENO (required fields)
+	value := columnValue(values, headers, "SCNO", 0)
+	if value == "" {
+		return SignalControlToNode{}, missingFieldError("SCNO")
+	}
+	item.SCNo, err = strconv.Atoi(value)
+	if err != nil {
+		return SignalControlToNode{}, parseFieldError("SCNO", err)
+	}
+	value = columnValue(values, headers, "NODENO", 1)
+	if value == "" {
+		return SignalControlToNode{}, missingFieldError("NODENO")
+	}
+	item.NodeNo, err = strconv.Atoi(value)
+	if err != nil {
+		return SignalControlToNode{}, parseFieldError("NODENO", err)
+	}
+
+	return item, nil
+}
+
+// signalControlToNodeColumns are the columns written when the section has no headers
+var signalControlToNodeColumns = []string{"SCNO", "NODENO"}
+
+func (item SignalControlToNode) attribute(column string) (string, bool) {
+	switch column {
+	case "SCNO":
+		return formatInt(item.SCNo), true
+	case "NODENO":
+		return formatInt(item.NodeNo), true
+	}
+	return "", false
+}
@@ -0,0 +1,107 @@
+package ptvvisum
+
+import "strconv"
+
+// SignalControlSection represents $SIGNALCONTROL section
+type SignalControlSection struct {
+	BaseSection
+	SignalControls []SignalControl
+
+	byID index[SignalControl, int]
+}
+
+// SignalControl represents a signal controller, it may control several nodes
+type SignalControl struct {
+	No                int      // Signal control number
+	Code              string   // Signal control code
+	Name              string   // Signal control name
+	SignalizationType string   // Kind of timing, e.g. SIGNALIZATIONSIGNALGROUPBASED or SIGNALIZATIONSTAGEBASED
+	CycleTime         Duration // Cycle time
+	TimeOffset        Duration // Offset of the cycle for coordination
+}
+
+// GetSignalControlByID retrieves a signal control by its number
+func (s *SignalControlSection) GetSignalControlByID(no int) (SignalControl, bool) {
+	return s.byID.findFirst(s.SignalControls, no, func(control SignalControl) int { return control.No })
+}
+
+// GetNodes retrieves the nodes controlled by a signal control
+func (s *SignalControlSection) GetNodes(no int, data *PTVData) []Node {
+	if data.SignalControlToNode == nil || data.Node == nil {
+		return nil
+	}
+	var result []Node
+	for _, item := range data.SignalControlToNode.GetItemsBySignalControl(no) {
+		if node, ok := data.Node.GetNodeByID(item.NodeNo); ok {
+			result = append(result, node)
+		}
+	}
+	return result
+}
+
+// Reindex drops the lookup index after numbers of signal controls have been changed in place, it is built again on next use
+func (s *SignalControlSection) Reindex() {
+	s.byID.reset()
+}
+
+// Count returns the number of signal controls in the section
+func (s *SignalControlSection) Count() int {
+	return len(s.SignalControls)
+}
+
+// getSignalControl extracts data from SIGNALCONTROL section row
+func getSignalControl(values []string, headers []string) (SignalControl, error) {
+	var control SignalControl
+	var err error
+
+	// Parse NO (required field)
+	value := columnValue(values, headers, "NO", 0)
+	if value == "" {
+		return SignalControl{}, missingFieldError("NO")
+	}
+	control.No, err = strconv.Atoi(value)
+	if err != nil {
+		return SignalControl{}, parseFieldError("NO", err)
+	}
+
+	control.Code = columnValue(values, headers, "CODE", 1)
+	control.Name = columnValue(values, headers, "NAME", 2)
+	control.SignalizationType = columnValue(values, headers, "SIGNALIZATIONTYPE", 3)
+
+	// Parse CYCLETIME and TIMEOFFSET (optional)
+	if value := columnValue(values, headers, "CYCLETIME", 4); value != "" {
+		control.CycleTime, err = parseDuration(value)
+		if err != nil {
+			return SignalControl{}, parseFieldError("CYCLETIME", err)
+		}
+	}
+	if value := columnValue(values, headers, "TIMEOFFSET", 5); value != "" {
+		control.TimeOffset, err = parseDuration(value)
+		if err != nil {
+			return SignalControl{}, parseFieldError("TIMEOFFSET", err)
+		}
+	}
+
+	return control, nil
+}
+
+// signalControlColumns are the columns written when the section has no headers
+var signalControlColumns = []string{"NO", "CODE", "NAME", "SIGNALIZATIONTYPE", "CYCLETIME", "TIMEOFFSET"}
+
+func (control SignalControl) attribute(column string) (string, bool) {
+	switch column {
+	case "NO":
+		return formatInt(control.No), true
+	case "CODE":
+		return control.Code, true
+	case "NAME":
+		return control.Name, true
+	case "SIGNALIZATIONTYPE":
+		return control.SignalizationType, true
+	case "CYCLETIME":
+		return control.CycleTime.String(), true
+	case "TIMEOFFSET":
+		return control.TimeOffset.String(), true
+	}
+	return "", false
+}
@@ -0,0 +1,147 @@
+package ptvvisum
+
+import "strconv"
+
+// SignalGroupToLaneTurnSection represents $SIGNALGROUPTOLANETURN section
+type SignalGroupToLaneTurnSection struct {
+	BaseSection
+	Items []SignalGroupToLaneTurn
+
+	byJunction index[SignalGroupToLaneTurn, JunctionKey]
+}
+
+// SignalGroupToLaneTurn assigns a lane turn to a signal group releasing it
+type SignalGroupToLaneTurn struct {
+	SCNo       int // Signal control number
+	SGNo       int // Signal group number
+	NodeNo     int // Node of the lane turn, 0 for lane turns of main nodes
+	MainNodeNo int // Main node of the lane turn, 0 for lane turns of nodes
+	FromLinkNo int // Link the lane turn comes from
+	FromLaneNo int // Lane the lane turn comes from
+	ToLinkNo   int // Link the lane turn goes to
+	ToLaneNo   int // Lane the lane turn goes to
+}
+
+// SignalGroupKey returns the key of the assigned signal group
+func (item SignalGroupToLaneTurn) SignalGroupKey() SignalGroupKey {
+	return SignalGroupKey{SCNo: item.SCNo, No: item.SGNo}
+}
+
+// JunctionKey returns the key of the junction of the assigned lane turn
+func (item SignalGroupToLaneTurn) JunctionKey() JunctionKey {
+	return JunctionKey{NodeNo: item.NodeNo, MainNodeNo: item.MainNodeNo}
+}
+
+// GetItemsByJunction retrieves the assignments of lane turns of a node or main node
+func (s *SignalGroupToLaneTurnSection) GetItemsByJunction(key JunctionKey) []SignalGroupToLaneTurn {
+	return s.byJunction.find(s.Items, key, SignalGroupToLaneTurn.JunctionKey)
+}
+
+// GetItemsByLaneTurn retrieves the assignments of a lane turn
+func (s *SignalGroupToLaneTurnSection) GetItemsByLaneTurn(turn LaneTurn) []SignalGroupToLaneTurn {
+	var result []SignalGroupToLaneTurn
+	for _, i := range s.byJunction.lookup(s.Items, turn.JunctionKey(), SignalGroupToLaneTurn.JunctionKey) {
+		item := s.Items[i]
+		if item.FromLinkNo == turn.FromLinkNo && item.FromLaneNo == turn.FromLaneNo &&
+			item.ToLinkNo == turn.ToLinkNo && item.ToLaneNo == turn.ToLaneNo {
+			result = append(result, item)
+		}
+	}
+	return result
+}
+
+// GetLaneTurn retrieves the lane turn of an assignment
+func (s *SignalGroupToLaneTurnSection) GetLaneTurn(item SignalGroupToLaneTurn, data *PTVData) (LaneTurn, bool) {
+	if data.LaneTurn == nil {
+		return LaneTurn{}, false
+	}
+	for _, turn := range data.LaneTurn.GetLaneTurnsByTurn(item.JunctionKey(), item.FromLinkNo, item.ToLinkNo) {
+		if turn.FromLaneNo == item.FromLaneNo && turn.ToLaneNo == item.ToLaneNo {
+			return turn, true
+		}
+	}
+	return LaneTurn{}, false
+}
+
+// Reindex drops the lookup index after assignments have been changed in place, it is built again on next use
+func (s *SignalGroupToLaneTurnSection) Reindex() {
+	s.byJunction.reset()
+}
+
+// Count returns the number of assignments in the section
+func (s *SignalGroupToLaneTurnSection) Count() int {
+	return len(s.Items)
+}
+
+// getSignalGroupToLaneTurn extracts data from SIGNALGROUPTOLANETURN section row
+func getSignalGroupToLaneTurn(values []string, headers []string) (SignalGroupToLaneTurn, error) {
+	var item SignalGroupToLaneTurn
+	var err error
+
+	// Parse NODENO and MAINNODENO (optional, one of them identifies the junction)
+	if value := columnValue(values, headers, "NODENO", 2); value != "" {
+		item.NodeNo, err = strconv.Atoi(value)
+		if err != nil {
+			return SignalGroupToLaneTurn{}, parseFieldError("NODENO", err)
+		}
+	}
+	if value := columnValue(values, headers, "MAINNODENO", 3); value != "" {
+		item.MainNodeNo, err = strconv.Atoi(value)
+		if err != nil {
+			return SignalGroupToLaneTurn{}, parseFieldError("MAINNODENO", err)
+		}
+	}
+
+	// Parse the signal group and the lanes (required fields)
+	requiredFields := []struct {
+		index int
+		dest  *int
+		name  string
+	}{
+		{0, &item.SCNo, "SCNO"},
+		{1, &item.SGNo, "SGNO"},
+		{4, &item.FromLinkNo, "FROMLINKNO"},
+		{5, &item.FromLaneNo, "FROMLANENO"},
+		{6, &item.ToLinkNo, "TOLINKNO"},
+		{7, &item.ToLaneNo, "TOLANENO"},
+	}
+	for _, field := range requiredFields {
+		value := columnValue(values, headers, field.name, field.index)
+		if value == "" {
+			return SignalGroupToLaneTurn{}, missingFieldError(field.name)
+		}
+		*field.dest, err = strconv.Atoi(value)
+		if err != nil {
+			return SignalGroupToLaneTurn{}, parseFieldError(field.name, err)
+		}
+	}
+
+	return item, nil
+}
+
+// signalGroupToLaneTurnColumns are the columns written when the section has no headers
+var signalGroupToLaneTurnColumns = []string{
+	"SCNO", "SGNO", "NODENO", "MAINNODENO", "FROMLINKNO", "FROMLANENO", "TOLINKNO", "TOLANENO",
+}
+
+func (item SignalGroupToLaneTurn) attribute(column string) (string, bool) {
+	switch column {
+	case "SCNO":
+		return formatInt(item.SCNo), true
+	case "SGNO":
+		return formatInt(item.SGNo), true
+	case "NODENO":
+		return formatInt(item.NodeNo), true
+	case "MAINNODENO":
+		return formatInt(item.MainNodeNo), true
+	case "FROMLINKNO":
+		return formatInt(item.FromLinkNo), true
+	case "FROMLANENO":
+		return formatInt(item.FromLaneNo), true
+	case "TOLINKNO":
+		return formatInt(item.ToLinkNo), true
+	case "TOLANENO":
+		return formatInt(item.ToLaneNo), true
+	}
+	return "", false
+}
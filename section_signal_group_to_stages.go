@@ -0,0 +1,99 @@
+package ptvvisum
+
+import "strconv"
+
+// SignalGroupToStageSection represents $SIGNALGROUPTOSTAGE section
+type SignalGroupToStageSection struct {
+	BaseSection
+	Items []SignalGroupToStage
+
+	bySignalControl index[SignalGroupToStage, int]
+}
+
+// SignalGroupToStage assigns a signal group to a stage it shows green in
+type SignalGroupToStage struct {
+	SCNo    int // Signal control number
+	SGNo    int // Signal group number
+	StageNo int // Stage number
+}
+
+// SignalGroupKey returns the key of the assigned signal group
+func (item SignalGroupToStage) SignalGroupKey() SignalGroupKey {
+	return SignalGroupKey{SCNo: item.SCNo, No: item.SGNo}
+}
+
+// GetItemsByStage retrieves the assignments of a stage
+func (s *SignalGroupToStageSection) GetItemsByStage(scNo, stageNo int) []SignalGroupToStage {
+	var result []SignalGroupToStage
+	for _, i := range s.bySignalControl.lookup(s.Items, scNo, func(item SignalGroupToStage) int { return item.SCNo }) {
+		if s.Items[i].StageNo == stageNo {
+			result = append(result, s.Items[i])
+		}
+	}
+	return result
+}
+
+// GetItemsBySignalGroup retrieves the assignments of a signal group
+func (s *SignalGroupToStageSection) GetItemsBySignalGroup(key SignalGroupKey) []SignalGroupToStage {
+	var result []SignalGroupToStage
+	for _, i := range s.bySignalControl.lookup(s.Items, key.SCNo, func(item SignalGroupToStage) int { return item.SCNo }) {
+		if s.Items[i].SGNo == key.No {
+			result = append(result, s.Items[i])
+		}
+	}
+	return result
+}
+
+// Reindex drops the lookup index after assignments have been changed in place, it is built again on next use
+func (s *SignalGroupToStageSection) Reindex() {
+	s.bySignalControl.reset()
+}
+
+// Count returns the number of assignments in the section
+func (s *SignalGroupToStageSection) Count() int {
+	return len(s.Items)
+}
+
+// getSignalGroupToStage extracts data from SIGNALGROUPTOSTAGE section row
+func getSignalGroupToStage(values []string, headers []string) (SignalGroupToStage, error) {
+	var item SignalGroupToStage
+	var err error
+
+	// Parse SCNO, SGNO and STAGENO (required fields)
+	requiredFields := []struct {
+		index int
+		dest  *int
+		name  string
+	}{
+		{0, &item.SCNo, "SCNO"},
+		{1, &item.SGNo, "SGNO"},
+		{2, &item.StageNo, "STAGENO"},
+	}
+	for _, field := range requiredFields {
+		value := columnValue(values, headers, field.name, field.index)
+		if value == "" {
+			return SignalGroupToStage{}, missingFieldError(field.name)
+		}
+		*field.dest, err = strconv.Atoi(value)
+		if err != nil {
+			return SignalGroupToStage{}, parseFieldError(field.name, err)
+		}
+	}
+
+	return item, nil
+}
+
+// signalGroupToStageColumns are the columns written when the section has no headers
+var signalGroupToStageColumns = []string{"SCNO", "SGNO", "STAGENO"}
+
+func (item SignalGroupToStage) attribute(column string) (string, bool) {
+	switch column {
+	case "SCNO":
+		return formatInt(item.SCNo), true
+	case "SGNO":
+		return formatInt(item.SGNo), true
+	case "STAGENO":
+		return formatInt(item.StageNo), true
+	}
+	return "", false
+}
@@ -0,0 +1,107 @@
+package ptvvisum
+
+import "strconv"
+
+// SignalGroupToTurnSection represents $SIGNALGROUPTOTURN section
+type SignalGroupToTurnSection struct {
+	BaseSection
+	Items []SignalGroupToTurn
+
+	byViaNode index[SignalGroupToTurn, int]
+}
+
+// SignalGroupToTurn assigns a turn to a signal group releasing it
+type SignalGroupToTurn struct {
+	SCNo       int // Signal control number
+	SGNo       int // Signal group number
+	FromNodeNo int // Origin node of the turn
+	ViaNodeNo  int // Node the turn is made at
+	ToNodeNo   int // Destination node of the turn
+}
+
+// SignalGroupKey returns the key of the assigned signal group
+func (item SignalGroupToTurn) SignalGroupKey() SignalGroupKey {
+	return SignalGroupKey{SCNo: item.SCNo, No: item.SGNo}
+}
+
+// GetItemsByTurn retrieves the assignments of a turn
+func (s *SignalGroupToTurnSection) GetItemsByTurn(fromNodeNo, viaNodeNo, toNodeNo int) []SignalGroupToTurn {
+	var result []SignalGroupToTurn
+	for _, i := range s.byViaNode.lookup(s.Items, viaNodeNo, func(item SignalGroupToTurn) int { return item.ViaNodeNo }) {
+		if s.Items[i].FromNodeNo == fromNodeNo && s.Items[i].ToNodeNo == toNodeNo {
+			result = append(result, s.Items[i])
+		}
+	}
+	return result
+}
+
+// GetItemsBySignalGroup retrieves the assignments of a signal group
+func (s *SignalGroupToTurnSection) GetItemsBySignalGroup(key SignalGroupKey) []SignalGroupToTurn {
+	var result []SignalGroupToTurn
+	for _, item := range s.Items {
+		if item.SignalGroupKey() == key {
+			result = append(result, item)
+		}
+	}
+	return result
+}
+
+// Reindex drops the lookup index after assignments have been changed in place, it is built again on next use
+func (s *SignalGroupToTurnSection) Reindex() {
+	s.byViaNode.reset()
+}
+
+// Count returns the number of assignments in the section
+func (s *SignalGroupToTurnSection) Count() int {
+	return len(s.Items)
+}
+
+// getSignalGroupToTurn extracts data from SIGNALGROUPTOTURN section row
+func getSignalGroupToTurn(values []string, headers []string) (SignalGroupToTurn, error) {
+	var item SignalGroupToTurn
+	var err error
+
+	// Parse SCNO, SGNO and the nodes of the turn (required fields)
+	requiredFields := []struct {
+		index int
+		dest  *int
+		name  string
+	}{
+		{0, &item.SCNo, "SCNO"},
+		{1, &item.SGNo, "SGNO"},
+		{2, &item.FromNodeNo, "FROMNODENO"},
+		{3, &item.ViaNodeNo, "VIANODENO"},
+		{4, &item.ToNodeNo, "TONODENO"},
+	}
+	for _, field := range requiredFields {
+		value := columnValue(values, headers, field.name, field.index)
+		if value == "" {
+			return SignalGroupToTurn{}, missingFieldError(field.name)
+		}
+		*field.dest, err = strconv.Atoi(value)
+		if err != nil {
+			return SignalGroupToTurn{}, parseFieldError(field.name, err)
+		}
+	}
+
+	return item, nil
+}
+
+// signalGroupToTurnColumns are the columns written when the section has no headers
+var signalGroupToTurnColumns = []string{"SCNO", "SGNO", "FROMNODENO", "VIANODENO", "TONODENO"}
+
+func (item SignalGroupToTurn) attribute(column string) (string, bool) {
+	switch column {
+	case "SCNO":
+		return formatInt(item.SCNo), true
+	case "SGNO":
+		return formatInt(item.SGNo), true
+	case "FROMNODENO":
+		return formatInt(item.FromNodeNo), true
+	case "VIANODENO":
+		return formatInt(item.ViaNodeNo), true
+	case "TONODENO":
+		return formatInt(item.ToNodeNo), true
+	}
+	return "", false
+}
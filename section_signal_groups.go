@@ -0,0 +1,230 @@
+package ptvvisum
+
+import (
+	"sort"
+	"strconv"
+	"time"
+)
+
+// SignalGroupSection represents $SIGNALGROUP section
+type SignalGroupSection struct {
+	BaseSection
+	SignalGroups []SignalGroup
+
+	byKey           index[SignalGroup, SignalGroupKey]
+	bySignalControl index[SignalGroup, int]
+}
+
+// SignalGroupKey identifies a signal group within its signal control
+type SignalGroupKey struct {
+	SCNo int
+	No   int
+}
+
+// SignalGroup represents a group of signal heads showing the same aspect
+type SignalGroup struct {
+	SCNo     int      // Signal control number
+	No       int      // Signal group number
+	Name     string   // Signal group name
+	GtStart  Duration // Start of green within the cycle
+	GtEnd    Duration // End of green within the cycle
+	Amber    Duration // Amber time following the green
+	AllRed   Duration // All-red time following the amber
+	MinGreen Duration // Minimum green time
+}
+
+// Key returns the key of the signal group
+func (group SignalGroup) Key() SignalGroupKey {
+	return SignalGroupKey{SCNo: group.SCNo, No: group.No}
+}
+
+// GreenTime returns the length of the green of the signal group, green running past the end of the cycle is wrapped
+func (group SignalGroup) GreenTime(cycleTime time.Duration) time.Duration {
+	green := group.GtEnd.Duration - group.GtStart.Duration
+	if cycleTime > 0 {
+		green %= cycleTime
+		if green < 0 {
+			green += cycleTime
+		}
+	}
+	return green
+}
+
+// GetSignalGroup retrieves a signal group by its key
+func (s *SignalGroupSection) GetSignalGroup(key SignalGroupKey) (SignalGroup, bool) {
+	return s.byKey.findFirst(s.SignalGroups, key, SignalGroup.Key)
+}
+
+// GetSignalGroupsBySignalControl retrieves all signal groups of a signal control
+func (s *SignalGroupSection) GetSignalGroupsBySignalControl(scNo int) []SignalGroup {
+	return s.bySignalControl.find(s.SignalGroups, scNo, func(group SignalGroup) int { return group.SCNo })
+}
+
+// GetSignalControl retrieves the signal control of a signal group
+func (s *SignalGroupSection) GetSignalControl(key SignalGroupKey, data *PTVData) (SignalControl, bool) {
+	if data.SignalControl == nil {
+		return SignalControl{}, false
+	}
+	if _, ok := s.GetSignalGroup(key); !ok {
+		return SignalControl{}, false
+	}
+	return data.SignalControl.GetSignalControlByID(key.SCNo)
+}
+
+// GetGreenTime returns the green time of a signal group using the cycle time of its signal control
+func (s *SignalGroupSection) GetGreenTime(key SignalGroupKey, data *PTVData) (time.Duration, bool) {
+	group, ok := s.GetSignalGroup(key)
+	if !ok {
+		return 0, false
+	}
+	var cycleTime time.Duration
+	if control, ok := s.GetSignalControl(key, data); ok {
+		cycleTime = control.CycleTime.Duration
+	}
+	return group.GreenTime(cycleTime), true
+}
+
+// GetSignalGroupsByTurn retrieves the signal groups releasing a turn, either assigned to the turn itself
+// or to one of the lane turns making the same movement
+func (s *SignalGroupSection) GetSignalGroupsByTurn(turn Turn, data *PTVData) []SignalGroup {
+	keys := make(map[SignalGroupKey]bool)
+	if data.SignalGroupToTurn != nil {
+		for _, item := range data.SignalGroupToTurn.GetItemsByTurn(turn.FromNodeNo, turn.ViaNodeNo, turn.ToNodeNo) {
+			keys[item.SignalGroupKey()] = true
+		}
+	}
+	if data.SignalGroupToLaneTurn != nil && data.LaneTurn != nil {
+		junction := JunctionKey{NodeNo: turn.ViaNodeNo}
+		for _, item := range data.SignalGroupToLaneTurn.GetItemsByJunction(junction) {
+			laneTurn, ok := data.SignalGroupToLaneTurn.GetLaneTurn(item, data)
+			if !ok {
+				continue
+			}
+			if resolved, ok := data.LaneTurn.GetTurn(laneTurn, data); ok &&
+				resolved.FromNodeNo == turn.FromNodeNo && resolved.ToNodeNo == turn.ToNodeNo {
+				keys[item.SignalGroupKey()] = true
+			}
+		}
+	}
+
+	var result []SignalGroup
+	for key := range keys {
+		if group, ok := s.GetSignalGroup(key); ok {
+			result = append(result, group)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].SCNo != result[j].SCNo {
+			return result[i].SCNo < result[j].SCNo
+		}
+		return result[i].No < result[j].No
+	})
+	return result
+}
+
+// GetEffectiveGreen returns the effective green time of a turn per cycle for capacity calculations.
+// Green and amber of all signal groups releasing the turn are merged, the lost time is subtracted once for each
+// separate green window (g = G + A - l). False is returned for turns without signal groups
+func (s *SignalGroupSection) GetEffectiveGreen(turn Turn, lostTime time.Duration, data *PTVData) (time.Duration, bool) {
+	groups := s.GetSignalGroupsByTurn(turn, data)
+	if len(groups) == 0 {
+		return 0, false
+	}
+	var cycleTime time.Duration
+	if data.SignalControl != nil {
+		if control, ok := data.SignalControl.GetSignalControlByID(groups[0].SCNo); ok {
+			cycleTime = control.CycleTime.Duration
+		}
+	}
+	green, windows := mergeGreenWindows(groups, cycleTime)
+	effective := green - time.Duration(windows)*lostTime
+	if effective < 0 {
+		effective = 0
+	}
+	return effective, true
+}
+
+// Reindex drops the lookup indexes after signal groups have been changed in place, they are built again on next use
+func (s *SignalGroupSection) Reindex() {
+	s.byKey.reset()
+	s.bySignalControl.reset()
+}
+
+// Count returns the number of signal groups in the section
+func (s *SignalGroupSection) Count() int {
+	return len(s.SignalGroups)
+}
+
+// getSignalGroup extracts data from SIGNALGROUP section row
+func getSignalGroup(values []string, headers []string) (SignalGroup, error) {
+	var group SignalGroup
+	var err error
+
+	// Parse SCNO and NO (required fields)
+	value := columnValue(values, headers, "SCNO", 0)
+	if value == "" {
+		return SignalGroup{}, missingFieldError("SCNO")
+	}
+	group.SCNo, err = strconv.Atoi(value)
+	if err != nil {
+		return SignalGroup{}, parseFieldError("SCNO", err)
+	}
+	value = columnValue(values, headers, "NO", 1)
+	if value == "" {
+		return SignalGroup{}, missingFieldError("NO")
+	}
+	group.No, err = strconv.Atoi(value)
+	if err != nil {
+		return SignalGroup{}, parseFieldError("NO", err)
+	}
+
+	group.Name = columnValue(values, headers, "NAME", 2)
+
+	// Parse times (optional)
+	durationFields := []struct {
+		index int
+		dest  *Duration
+		name  string
+	}{
+		{3, &group.GtStart, "GTSTART"},
+		{4, &group.GtEnd, "GTEND"},
+		{5, &group.Amber, "AMBER"},
+		{6, &group.AllRed, "ALLRED"},
+		{7, &group.MinGreen, "MINGREEN"},
+	}
+	for _, field := range durationFields {
+		if value := columnValue(values, headers, field.name, field.index); value != "" {
+			*field.dest, err = parseDuration(value)
+			if err != nil {
+				return SignalGroup{}, parseFieldError(field.name, err)
+			}
+		}
+	}
+
+	return group, nil
+}
+
+// signalGroupColumns are the columns written when the section has no headers
+var signalGroupColumns = []string{"SCNO", "NO", "NAME", "GTSTART", "GTEND", "AMBER", "ALLRED", "MINGREEN"}
+
+func (group SignalGroup) attribute(column string) (string, bool) {
+	switch column {
+	case "SCNO":
+		return formatInt(group.SCNo), true
+	case "NO":
+		return formatInt(group.No), true
+	case "NAME":
+		return group.Name, true
+	case "GTSTART":
+		return group.GtStart.String(), true
+	case "GTEND":
+		return group.GtEnd.String(), true
+	case "AMBER":
+		return group.Amber.String(), true
+	case "ALLRED":
+		return group.AllRed.String(), true
+	case "MINGREEN":
+		return group.MinGreen.String(), true
+	}
+	return "", false
+}
@@ -0,0 +1,120 @@
+package ptvvisum
+
+import "strconv"
+
+// StageSection represents $STAGE section
+type StageSection struct {
+	BaseSection
+	Stages []Stage
+
+	bySignalControl index[Stage, int]
+}
+
+// Stage represents a stage of a stage-based signal control, a set of signal groups showing green together
+type Stage struct {
+	SCNo    int      // Signal control number
+	No      int      // Stage number
+	Name    string   // Stage name
+	GtStart Duration // Start of the stage within the cycle
+	GtEnd   Duration // End of the stage within the cycle
+}
+
+// GetStagesBySignalControl retrieves all stages of a signal control
+func (s *StageSection) GetStagesBySignalControl(scNo int) []Stage {
+	return s.bySignalControl.find(s.Stages, scNo, func(stage Stage) int { return stage.SCNo })
+}
+
+// GetStage retrieves a stage of a signal control
+func (s *StageSection) GetStage(scNo, no int) (Stage, bool) {
+	for _, i := range s.bySignalControl.lookup(s.Stages, scNo, func(stage Stage) int { return stage.SCNo }) {
+		if s.Stages[i].No == no {
+			return s.Stages[i], true
+		}
+	}
+	return Stage{}, false
+}
+
+// GetSignalGroups retrieves the signal groups assigned to a stage
+func (s *StageSection) GetSignalGroups(scNo, no int, data *PTVData) []SignalGroup {
+	if data.SignalGroupToStage == nil || data.SignalGroup == nil {
+		return nil
+	}
+	var result []SignalGroup
+	for _, item := range data.SignalGroupToStage.GetItemsByStage(scNo, no) {
+		if group, ok := data.SignalGroup.GetSignalGroup(item.SignalGroupKey()); ok {
+			result = append(result, group)
+		}
+	}
+	return result
+}
+
+// Reindex drops the lookup index after signal controls of stages have been changed in place, it is built again on next use
+func (s *StageSection) Reindex() {
+	s.bySignalControl.reset()
+}
+
+// Count returns the number of stages in the section
+func (s *StageSection) Count() int {
+	return len(s.Stages)
+}
+
+// getStage extracts data from STAGE section row
+func getStage(values []string, headers []string) (Stage, error) {
+	var stage Stage
+	var err error
+
+	// Parse SCNO and NO (required fields)
+	value := columnValue(values, headers, "SCNO", 0)
+	if value == "" {
+		return Stage{}, missingFieldError("SCNO")
+	}
+	stage.SCNo, err = strconv.Atoi(value)
+	if err != nil {
+		return Stage{}, parseFieldError("SCNO", err)
+	}
+	value = columnValue(values, headers, "NO", 1)
+	if value == "" {
+		return Stage{}, missingFieldError("NO")
+	}
+	stage.No, err = strconv.Atoi(value)
+	if err != nil {
+		return Stage{}, parseFieldError("NO", err)
+	}
+
+	stage.Name = columnValue(values, headers, "NAME", 2)
+
+	// Parse GTSTART and GTEND (optional)
+	if value := columnValue(values, headers, "GTSTART", 3); value != "" {
+		stage.GtStart, err = parseDuration(value)
+		if err != nil {
+			return Stage{}, parseFieldError("GTSTART", err)
+		}
+	}
+	if value := columnValue(values, headers, "GTEND", 4); value != "" {
+		stage.GtEnd, err = parseDuration(value)
+		if err != nil {
+			return Stage{}, parseFieldError("GTEND", err)
+		}
+	}
+
+	return stage, nil
+}
+
+// stageColumns are the columns written when the section has no headers
+var stageColumns = []string{"SCNO", "NO", "NAME", "GTSTART", "GTEND"}
+
+func (stage Stage) attribute(column string) (string, bool) {
+	switch column {
+	case "SCNO":
+		return formatInt(stage.SCNo), true
+	case "NO":
+		return formatInt(stage.No), true
+	case "NAME":
+		return stage.Name, true
+	case "GTSTART":
+		return stage.GtStart.String(), true
+	case "GTEND":
+		return stage.GtEnd.String(), true
+	}
+	return "", false
+}
@@ -0,0 +1,202 @@
+package ptvvisum
+
+import (
+	"strconv"
+	"strings"
+)
+
+// StopAreaSection represents $STOPAREA section
+type StopAreaSection struct {
+	BaseSection
+	StopAreas []StopArea
+
+	byID   index[StopArea, int]
+	byStop index[StopArea, int]
+}
+
+// StopArea represents a part of a stop, e.g. a platform group, holding the stop points
+type StopArea struct {
+	No               int     // Stop area number
+	StopNo           int     // Number of the stop the area belongs to
+	Code             string  // Stop area code
+	Name             string  // Stop area name
+	NodeNo           int     // Access node number, 0 if the area has no node
+	TypeNo           int     // Stop area type number
+	XCoord           float64 // X-coordinate
+	YCoord           float64 // Y-coordinate
+	AddVal           [3]int  // Additional values 1-3
+	TransferPriority int     // Transfer priority
+	LabelPosRelX     float64 // X coordinate for label
+	LabelPosRelY     float64 // Y coordinate for label
+}
+
+// GetStopAreaByID retrieves a stop area by its number
+func (s *StopAreaSection) GetStopAreaByID(no int) (StopArea, bool) {
+	return s.byID.findFirst(s.StopAreas, no, func(area StopArea) int { return area.No })
+}
+
+// GetStopAreasByStop retrieves all stop areas of a stop
+func (s *StopAreaSection) GetStopAreasByStop(stopNo int) []StopArea {
+	return s.byStop.find(s.StopAreas, stopNo, func(area StopArea) int { return area.StopNo })
+}
+
+// GetStop retrieves the stop a stop area belongs to
+func (s *StopAreaSection) GetStop(stopAreaNo int, data *PTVData) (Stop, bool) {
+	area, found := s.GetStopAreaByID(stopAreaNo)
+	if !found || data.Stop == nil {
+		return Stop{}, false
+	}
+	return data.Stop.GetStopByID(area.StopNo)
+}
+
+// Reindex drops the lookup indexes after numbers or stops of areas have been changed in place, they are built again on next use
+func (s *StopAreaSection) Reindex() {
+	s.byID.reset()
+	s.byStop.reset()
+}
+
+// Count returns the number of stop areas in the section
+func (s *StopAreaSection) Count() int {
+	return len(s.StopAreas)
+}
+
+// getStopArea extracts data from STOPAREA section row
+func getStopArea(values []string, headers []string) (StopArea, error) {
+	var area StopArea
+	var err error
+
+	// Parse NO (required field)
+	value := columnValue(values, headers, "NO", 0)
+	if value == "" {
+		return StopArea{}, missingFieldError("NO")
+	}
+	area.No, err = strconv.Atoi(value)
+	if err != nil {
+		return StopArea{}, parseFieldError("NO", err)
+	}
+
+	// Parse STOPNO (required field)
+	value = columnValue(values, headers, "STOPNO", 1)
+	if value == "" {
+		return StopArea{}, missingFieldError("STOPNO")
+	}
+	area.StopNo, err = strconv.Atoi(value)
+	if err != nil {
+		return StopArea{}, parseFieldError("STOPNO", err)
+	}
+
+	area.Code = columnValue(values, headers, "CODE", 2)
+	area.Name = columnValue(values, headers, "NAME", 3)
+
+	// Parse NODENO (optional)
+	if value := columnValue(values, headers, "NODENO", 4); value != "" {
+		area.NodeNo, err = strconv.Atoi(value)
+		if err != nil {
+			return StopArea{}, parseFieldError("NODENO", err)
+		}
+	}
+
+	// Parse TYPENO (optional)
+	if value := columnValue(values, headers, "TYPENO", 5); value != "" {
+		area.TypeNo, err = strconv.Atoi(value)
+		if err != nil {
+			return StopArea{}, parseFieldError("TYPENO", err)
+		}
+	}
+
+	// Parse XCOORD (required field)
+	value = columnValue(values, headers, "XCOORD", 6)
+	if value == "" {
+		return StopArea{}, missingFieldError("XCOORD")
+	}
+	area.XCoord, err = strconv.ParseFloat(strings.Replace(value, ",", ".", -1), 64)
+	if err != nil {
+		return StopArea{}, parseFieldError("XCOORD", err)
+	}
+
+	// Parse YCOORD (required field)
+	value = columnValue(values, headers, "YCOORD", 7)
+	if value == "" {
+		return StopArea{}, missingFieldError("YCOORD")
+	}
+	area.YCoord, err = strconv.ParseFloat(strings.Replace(value, ",", ".", -1), 64)
+	if err != nil {
+		return StopArea{}, parseFieldError("YCOORD", err)
+	}
+
+	// Parse ADDVAL1, ADDVAL2, ADDVAL3 (optional)
+	for i, column := range []string{"ADDVAL1", "ADDVAL2", "ADDVAL3"} {
+		if value := columnValue(values, headers, column, 8+i); value != "" {
+			area.AddVal[i], err = strconv.Atoi(value)
+			if err != nil {
+				return StopArea{}, parseFieldError(column, err)
+			}
+		}
+	}
+
+	// Parse TRANSFERPRIORITY (optional)
+	if value := columnValue(values, headers, "TRANSFERPRIORITY", 11); value != "" {
+		area.TransferPriority, err = strconv.Atoi(value)
+		if err != nil {
+			return StopArea{}, parseFieldError("TRANSFERPRIORITY", err)
+		}
+	}
+
+	// Parse LABELPOSRELX (optional)
+	if value := columnValue(values, headers, "LABELPOSRELX", 12); value != "" {
+		area.LabelPosRelX, err = strconv.ParseFloat(strings.Replace(value, ",", ".", -1), 64)
+		if err != nil {
+			return StopArea{}, parseFieldError("LABELPOSRELX", err)
+		}
+	}
+
+	// Parse LABELPOSRELY (optional)
+	if value := columnValue(values, headers, "LABELPOSRELY", 13); value != "" {
+		area.LabelPosRelY, err = strconv.ParseFloat(strings.Replace(value, ",", ".", -1), 64)
+		if err != nil {
+			return StopArea{}, parseFieldError("LABELPOSRELY", err)
+		}
+	}
+
+	return area, nil
+}
+
+// stopAreaColumns are the columns written when the section has no headers
+var stopAreaColumns = []string{
+	"NO", "STOPNO", "CODE", "NAME", "NODENO", "TYPENO", "XCOORD", "YCOORD", "ADDVAL1", "ADDVAL2", "ADDVAL3",
+	"TRANSFERPRIORITY", "LABELPOSRELX", "LABELPOSRELY",
+}
+
+func (area StopArea) attribute(column string) (string, bool) {
+	switch column {
+	case "NO":
+		return formatInt(area.No), true
+	case "STOPNO":
+		return formatInt(area.StopNo), true
+	case "CODE":
+		return area.Code, true
+	case "NAME":
+		return area.Name, true
+	case "NODENO":
+		return formatInt(area.NodeNo), true
+	case "TYPENO":
+		return formatInt(area.TypeNo), true
+	case "XCOORD":
+		return formatFloat(area.XCoord), true
+	case "YCOORD":
+		return formatFloat(area.YCoord), true
+	case "ADDVAL1":
+		return formatInt(area.AddVal[0]), true
+	case "ADDVAL2":
+		return formatInt(area.AddVal[1]), true
+	case "ADDVAL3":
+		return formatInt(area.AddVal[2]), true
+	case "TRANSFERPRIORITY":
+		return formatInt(area.TransferPriority), true
+	case "LABELPOSRELX":
+		return formatFloat(area.LabelPosRelX), true
+	case "LABELPOSRELY":
+		return formatFloat(area.LabelPosRelY), true
+	}
+	return "", false
+}
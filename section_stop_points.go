@@ -0,0 +1,324 @@
+package ptvvisum
+
+import (
+	"math"
+	"strconv"
+	"strings"
+)
+
+// StopPointSection represents $STOPPOINT section
+type StopPointSection struct {
+	BaseSection
+	StopPoints []StopPoint
+
+	byID       index[StopPoint, int]
+	byStopArea index[StopPoint, int]
+	byNode     index[StopPoint, int]
+	byLink     index[StopPoint, int]
+}
+
+// StopPoint represents a place where vehicles stop, either on a node or on a link
+type StopPoint struct {
+	No           int      // Stop point number
+	StopAreaNo   int      // Number of the stop area the point belongs to
+	Code         string   // Stop point code
+	Name         string   // Stop point name
+	TypeNo       int      // Stop point type number
+	TSysSet      string   // Transport systems served
+	Directed     int      // Whether the point serves only the direction of the link (0/1)
+	NodeNo       int      // Node of a node-based stop point, 0 for link-based ones
+	FromNodeNo   int      // From node of the link of a link-based stop point
+	LinkNo       int      // Link of a link-based stop point, 0 for node-based ones
+	RelPos       float64  // Relative position on the link from FromNodeNo (0..1)
+	AddVal       [3]int   // Additional values 1-3
+	LabelPosRelX float64  // X coordinate for label
+	LabelPosRelY float64  // Y coordinate for label
+	DefDwellTime Duration // Default dwell time
+}
+
+// IsNodeBased reports whether the stop point lies on a node rather than on a link
+func (p StopPoint) IsNodeBased() bool {
+	return p.LinkNo == 0
+}
+
+// GetStopPointByID retrieves a stop point by its number
+func (s *StopPointSection) GetStopPointByID(no int) (StopPoint, bool) {
+	return s.byID.findFirst(s.StopPoints, no, func(point StopPoint) int { return point.No })
+}
+
+// GetStopPointsByStopArea retrieves all stop points of a stop area
+func (s *StopPointSection) GetStopPointsByStopArea(stopAreaNo int) []StopPoint {
+	return s.byStopArea.find(s.StopPoints, stopAreaNo, func(point StopPoint) int { return point.StopAreaNo })
+}
+
+// GetStopPointsByNode retrieves all node-based stop points on a node
+func (s *StopPointSection) GetStopPointsByNode(nodeNo int) []StopPoint {
+	return s.byNode.find(s.StopPoints, nodeNo, func(point StopPoint) int { return point.NodeNo })
+}
+
+// GetStopPointsByLink retrieves all link-based stop points on a link
+func (s *StopPointSection) GetStopPointsByLink(linkNo int) []StopPoint {
+	// Node-based stop points have no link
+	if linkNo == 0 {
+		return nil
+	}
+	return s.byLink.find(s.StopPoints, linkNo, func(point StopPoint) int { return point.LinkNo })
+}
+
+// GetStopPointsByTransportSystem retrieves all stop points serving a specific transport system
+func (s *StopPointSection) GetStopPointsByTransportSystem(tsys string) []StopPoint {
+	var result []StopPoint
+	for _, point := range s.StopPoints {
+		for _, system := range strings.Split(point.TSysSet, ",") {
+			if system == tsys {
+				result = append(result, point)
+				break
+			}
+		}
+	}
+	return result
+}
+
+// GetStopPointsByStop retrieves all stop points of all stop areas of a stop
+func (s *StopPointSection) GetStopPointsByStop(stopNo int, data *PTVData) []StopPoint {
+	if data.StopArea == nil {
+		return nil
+	}
+	var result []StopPoint
+	for _, area := range data.StopArea.GetStopAreasByStop(stopNo) {
+		result = append(result, s.GetStopPointsByStopArea(area.No)...)
+	}
+	return result
+}
+
+// GetStopArea retrieves the stop area a stop point belongs to
+func (s *StopPointSection) GetStopArea(stopPointNo int, data *PTVData) (StopArea, bool) {
+	point, found := s.GetStopPointByID(stopPointNo)
+	if !found || data.StopArea == nil {
+		return StopArea{}, false
+	}
+	return data.StopArea.GetStopAreaByID(point.StopAreaNo)
+}
+
+// GetStop retrieves the stop a stop point belongs to through its stop area
+func (s *StopPointSection) GetStop(stopPointNo int, data *PTVData) (Stop, bool) {
+	area, found := s.GetStopArea(stopPointNo, data)
+	if !found {
+		return Stop{}, false
+	}
+	return data.StopArea.GetStop(area.No, data)
+}
+
+// GetCoordinate returns the position of a stop point in the network:
+// the node of a node-based point or the point at RelPos along the geometry of the link of a link-based one
+func (s *StopPointSection) GetCoordinate(stopPointNo int, data *PTVData) (x, y float64, found bool) {
+	point, found := s.GetStopPointByID(stopPointNo)
+	if !found || data.Node == nil {
+		return 0, 0, false
+	}
+	if point.IsNodeBased() {
+		node, found := data.Node.GetNodeByID(point.NodeNo)
+		return node.XCoord, node.YCoord, found
+	}
+
+	if data.Link == nil {
+		return 0, 0, false
+	}
+	link, found := data.Link.GetLinkByFromNode(point.LinkNo, point.FromNodeNo)
+	if !found {
+		return 0, 0, false
+	}
+	geometry, found := linkGeometry(link, data)
+	if !found {
+		return 0, 0, false
+	}
+	x, y = interpolateGeometry(geometry, point.RelPos)
+	return x, y, true
+}
+
+// interpolateGeometry returns the point at the given share (0..1) of the length of a polyline
+func interpolateGeometry(geometry [][2]float64, share float64) (float64, float64) {
+	var total float64
+	for i := 1; i < len(geometry); i++ {
+		total += math.Hypot(geometry[i][0]-geometry[i-1][0], geometry[i][1]-geometry[i-1][1])
+	}
+	remaining := math.Max(0, math.Min(1, share)) * total
+	for i := 1; i < len(geometry); i++ {
+		segment := math.Hypot(geometry[i][0]-geometry[i-1][0], geometry[i][1]-geometry[i-1][1])
+		if remaining <= segment && segment > 0 {
+			t := remaining / segment
+			return geometry[i-1][0] + t*(geometry[i][0]-geometry[i-1][0]), geometry[i-1][1] + t*(geometry[i][1]-geometry[i-1][1])
+		}
+		remaining -= segment
+	}
+	last := geometry[len(geometry)-1]
+	return last[0], last[1]
+}
+
+// Reindex drops the lookup indexes after numbers, areas, nodes or links of stop points have been changed in place, they are built again on next use
+func (s *StopPointSection) Reindex() {
+	s.byID.reset()
+	s.byStopArea.reset()
+	s.byNode.reset()
+	s.byLink.reset()
+}
+
+// Count returns the number of stop points in the section
+func (s *StopPointSection) Count() int {
+	return len(s.StopPoints)
+}
+
+// getStopPoint extracts data from STOPPOINT section row
+func getStopPoint(values []string, headers []string) (StopPoint, error) {
+	var point StopPoint
+	var err error
+
+	// Parse NO (required field)
+	value := columnValue(values, headers, "NO", 0)
+	if value == "" {
+		return StopPoint{}, missingFieldError("NO")
+	}
+	point.No, err = strconv.Atoi(value)
+	if err != nil {
+		return StopPoint{}, parseFieldError("NO", err)
+	}
+
+	// Parse STOPAREANO (required field)
+	value = columnValue(values, headers, "STOPAREANO", 1)
+	if value == "" {
+		return StopPoint{}, missingFieldError("STOPAREANO")
+	}
+	point.StopAreaNo, err = strconv.Atoi(value)
+	if err != nil {
+		return StopPoint{}, parseFieldError("STOPAREANO", err)
+	}
+
+	point.Code = columnValue(values, headers, "CODE", 2)
+	point.Name = columnValue(values, headers, "NAME", 3)
+
+	// Parse TYPENO (optional)
+	if value := columnValue(values, headers, "TYPENO", 4); value != "" {
+		point.TypeNo, err = strconv.Atoi(value)
+		if err != nil {
+			return StopPoint{}, parseFieldError("TYPENO", err)
+		}
+	}
+
+	point.TSysSet = columnValue(values, headers, "TSYSSET", 5)
+
+	// Parse DIRECTED (optional)
+	if value := columnValue(values, headers, "DIRECTED", 6); value != "" {
+		point.Directed, err = strconv.Atoi(value)
+		if err != nil {
+			return StopPoint{}, parseFieldError("DIRECTED", err)
+		}
+	}
+
+	// Node-based stop points have NODENO, link-based ones FROMNODENO, LINKNO and RELPOS
+	if value := columnValue(values, headers, "NODENO", 7); value != "" {
+		point.NodeNo, err = strconv.Atoi(value)
+		if err != nil {
+			return StopPoint{}, parseFieldError("NODENO", err)
+		}
+	}
+	if value := columnValue(values, headers, "FROMNODENO", 8); value != "" {
+		point.FromNodeNo, err = strconv.Atoi(value)
+		if err != nil {
+			return StopPoint{}, parseFieldError("FROMNODENO", err)
+		}
+	}
+	if value := columnValue(values, headers, "LINKNO", 9); value != "" {
+		point.LinkNo, err = strconv.Atoi(value)
+		if err != nil {
+			return StopPoint{}, parseFieldError("LINKNO", err)
+		}
+	}
+	if value := columnValue(values, headers, "RELPOS", 10); value != "" {
+		point.RelPos, err = strconv.ParseFloat(strings.Replace(value, ",", ".", -1), 64)
+		if err != nil {
+			return StopPoint{}, parseFieldError("RELPOS", err)
+		}
+	}
+
+	// Parse ADDVAL1, ADDVAL2, ADDVAL3 (optional)
+	for i, column := range []string{"ADDVAL1", "ADDVAL2", "ADDVAL3"} {
+		if value := columnValue(values, headers, column, 11+i); value != "" {
+			point.AddVal[i], err = strconv.Atoi(value)
+			if err != nil {
+				return StopPoint{}, parseFieldError(column, err)
+			}
+		}
+	}
+
+	// Parse LABELPOSRELX (optional)
+	if value := columnValue(values, headers, "LABELPOSRELX", 14); value != "" {
+		point.LabelPosRelX, err = strconv.ParseFloat(strings.Replace(value, ",", ".", -1), 64)
+		if err != nil {
+			return StopPoint{}, parseFieldError("LABELPOSRELX", err)
+		}
+	}
+
+	// Parse LABELPOSRELY (optional)
+	if value := columnValue(values, headers, "LABELPOSRELY", 15); value != "" {
+		point.LabelPosRelY, err = strconv.ParseFloat(strings.Replace(value, ",", ".", -1), 64)
+		if err != nil {
+			return StopPoint{}, parseFieldError("LABELPOSRELY", err)
+		}
+	}
+
+	// Parse DEFDWELLTIME (optional)
+	if value := columnValue(values, headers, "DEFDWELLTIME", 16); value != "" {
+		point.DefDwellTime, err = parseDuration(value)
+		if err != nil {
+			return StopPoint{}, parseFieldError("DEFDWELLTIME", err)
+		}
+	}
+
+	return point, nil
+}
+
+// stopPointColumns are the columns written when the section has no headers
+var stopPointColumns = []string{
+	"NO", "STOPAREANO", "CODE", "NAME", "TYPENO", "TSYSSET", "DIRECTED", "NODENO", "FROMNODENO", "LINKNO", "RELPOS",
+	"ADDVAL1", "ADDVAL2", "ADDVAL3", "LABELPOSRELX", "LABELPOSRELY", "DEFDWELLTIME",
+}
+
+func (p StopPoint) attribute(column string) (string, bool) {
+	switch column {
+	case "NO":
+		return formatInt(p.No), true
+	case "STOPAREANO":
+		return formatInt(p.StopAreaNo), true
+	case "CODE":
+		return p.Code, true
+	case "NAME":
+		return p.Name, true
+	case "TYPENO":
+		return formatInt(p.TypeNo), true
+	case "TSYSSET":
+		return p.TSysSet, true
+	case "DIRECTED":
+		return formatInt(p.Directed), true
+	case "NODENO":
+		return formatOptionalInt(p.NodeNo), true
+	case "FROMNODENO":
+		return formatOptionalInt(p.FromNodeNo), true
+	case "LINKNO":
+		return formatOptionalInt(p.LinkNo), true
+	case "RELPOS":
+		return formatFloat(p.RelPos), true
+	case "ADDVAL1":
+		return formatInt(p.AddVal[0]), true
+	case "ADDVAL2":
+		return formatInt(p.AddVal[1]), true
+	case "ADDVAL3":
+		return formatInt(p.AddVal[2]), true
+	case "LABELPOSRELX":
+		return formatFloat(p.LabelPosRelX), true
+	case "LABELPOSRELY":
+		return formatFloat(p.LabelPosRelY), true
+	case "DEFDWELLTIME":
+		return p.DefDwellTime.String(), true
+	}
+	return "", false
+}
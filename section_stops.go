@@ -0,0 +1,150 @@
+package ptvvisum
+
+import (
+	"strconv"
+	"strings"
+)
+
+// StopSection represents $STOP section
+type StopSection struct {
+	BaseSection
+	Stops []Stop
+
+	byID index[Stop, int]
+}
+
+// Stop represents a public transport stop, the top level of the stop hierarchy (stop → stop area → stop point)
+type Stop struct {
+	No           int     // Stop number
+	Code         string  // Stop code
+	Name         string  // Stop name
+	TypeNo       int     // Stop type number
+	XCoord       float64 // X-coordinate
+	YCoord       float64 // Y-coordinate
+	AddVal       [3]int  // Additional values 1-3
+	LabelPosRelX float64 // X coordinate for label
+	LabelPosRelY float64 // Y coordinate for label
+}
+
+// GetStopByID retrieves a stop by its number
+func (s *StopSection) GetStopByID(no int) (Stop, bool) {
+	return s.byID.findFirst(s.Stops, no, func(stop Stop) int { return stop.No })
+}
+
+// Reindex drops the lookup index after stop numbers have been changed in place, it is built again on next use
+func (s *StopSection) Reindex() {
+	s.byID.reset()
+}
+
+// Count returns the number of stops in the section
+func (s *StopSection) Count() int {
+	return len(s.Stops)
+}
+
+// getStop extracts data from STOP section row
+func getStop(values []string, headers []string) (Stop, error) {
+	var stop Stop
+	var err error
+
+	// Parse NO (required field)
+	value := columnValue(values, headers, "NO", 0)
+	if value == "" {
+		return Stop{}, missingFieldError("NO")
+	}
+	stop.No, err = strconv.Atoi(value)
+	if err != nil {
+		return Stop{}, parseFieldError("NO", err)
+	}
+
+	stop.Code = columnValue(values, headers, "CODE", 1)
+	stop.Name = columnValue(values, headers, "NAME", 2)
+
+	// Parse TYPENO (optional)
+	if value := columnValue(values, headers, "TYPENO", 3); value != "" {
+		stop.TypeNo, err = strconv.Atoi(value)
+		if err != nil {
+			return Stop{}, parseFieldError("TYPENO", err)
+		}
+	}
+
+	// Parse XCOORD (required field)
+	value = columnValue(values, headers, "XCOORD", 4)
+	if value == "" {
+		return Stop{}, missingFieldError("XCOORD")
+	}
+	stop.XCoord, err = strconv.ParseFloat(strings.Replace(value, ",", ".", -1), 64)
+	if err != nil {
+		return Stop{}, parseFieldError("XCOORD", err)
+	}
+
+	// Parse YCOORD (required field)
+	value = columnValue(values, headers, "YCOORD", 5)
+	if value == "" {
+		return Stop{}, missingFieldError("YCOORD")
+	}
+	stop.YCoord, err = strconv.ParseFloat(strings.Replace(value, ",", ".", -1), 64)
+	if err != nil {
+		return Stop{}, parseFieldError("YCOORD", err)
+	}
+
+	// Parse ADDVAL1, ADDVAL2, ADDVAL3 (optional)
+	for i, column := range []string{"ADDVAL1", "ADDVAL2", "ADDVAL3"} {
+		if value := columnValue(values, headers, column, 6+i); value != "" {
+			stop.AddVal[i], err = strconv.Atoi(value)
+			if err != nil {
+				return Stop{}, parseFieldError(column, err)
+			}
+		}
+	}
+
+	// Parse LABELPOSRELX (optional)
+	if value := columnValue(values, headers, "LABELPOSRELX", 9); value != "" {
+		stop.LabelPosRelX, err = strconv.ParseFloat(strings.Replace(value, ",", ".", -1), 64)
+		if err != nil {
+			return Stop{}, parseFieldError("LABELPOSRELX", err)
+		}
+	}
+
+	// Parse LABELPOSRELY (optional)
+	if value := columnValue(values, headers, "LABELPOSRELY", 10); value != "" {
+		stop.LabelPosRelY, err = strconv.ParseFloat(strings.Replace(value, ",", ".", -1), 64)
+		if err != nil {
+			return Stop{}, parseFieldError("LABELPOSRELY", err)
+		}
+	}
+
+	return stop, nil
+}
+
+// stopColumns are the columns written when the section has no headers
+var stopColumns = []string{
+	"NO", "CODE", "NAME", "TYPENO", "XCOORD", "YCOORD", "ADDVAL1", "ADDVAL2", "ADDVAL3", "LABELPOSRELX", "LABELPOSRELY",
+}
+
+func (stop Stop) attribute(column string) (string, bool) {
+	switch column {
+	case "NO":
+		return formatInt(stop.No), true
+	case "CODE":
+		return stop.Code, true
+	case "NAME":
+		return stop.Name, true
+	case "TYPENO":
+		return formatInt(stop.TypeNo), true
+	case "XCOORD":
+		return formatFloat(stop.XCoord), true
+	case "YCOORD":
+		return formatFloat(stop.YCoord), true
+	case "ADDVAL1":
+		return formatInt(stop.AddVal[0]), true
+	case "ADDVAL2":
+		return formatInt(stop.AddVal[1]), true
+	case "ADDVAL3":
+		return formatInt(stop.AddVal[2]), true
+	case "LABELPOSRELX":
+		return formatFloat(stop.LabelPosRelX), true
+	case "LABELPOSRELY":
+		return formatFloat(stop.LabelPosRelY), true
+	}
+	return "", false
+}
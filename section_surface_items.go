@@ -1,14 +1,14 @@
 package ptvvisum
 
-import (
-	"fmt"
-	"strconv"
-)
+import "strconv"
 
 // SurfaceItemSection represents $SURFACEITEM section
 type SurfaceItemSection struct {
 	BaseSection
 	Items []SurfaceItem
+
+	bySurface index[SurfaceItem, int]
+	byFace    index[SurfaceItem, int]
 }
 
 // SurfaceItem represents a face that is part of a surface
@@ -20,24 +20,18 @@ type SurfaceItem struct {
 
 // GetItemsBySurfaceID retrieves all items for a specific surface
 func (s *SurfaceItemSection) GetItemsBySurfaceID(surfaceID int) []SurfaceItem {
-	var result []SurfaceItem
-	for _, item := range s.Items {
-		if item.SurfaceID == surfaceID {
-			result = append(result, item)
-		}
-	}
-	return result
+	return s.bySurface.find(s.Items, surfaceID, func(item SurfaceItem) int { return item.SurfaceID })
 }
 
 // GetItemsByFaceID retrieves all items for a specific face
 func (s *SurfaceItemSection) GetItemsByFaceID(faceID int) []SurfaceItem {
-	var result []SurfaceItem
-	for _, item := range s.Items {
-		if item.FaceID == faceID {
-			result = append(result, item)
-		}
-	}
-	return result
+	return s.byFace.find(s.Items, faceID, func(item SurfaceItem) int { return item.FaceID })
+}
+
+// Reindex drops the lookup indexes after surfaces or faces of items have been changed in place, they are built again on next use
+func (s *SurfaceItemSection) Reindex() {
+	s.bySurface.reset()
+	s.byFace.reset()
 }
 
 // GetBoundariesBySurfaceID gets the outer boundary and inner holes (enclaves) for a surface
@@ -55,6 +49,25 @@ func (s *SurfaceItemSection) GetBoundariesBySurfaceID(surfaceID int) (outerFaces
 	return outerFaces, innerFaces
 }
 
+// GetSurfaceGeometry builds the rings of a surface from the geometry of its faces, outer boundaries and inner holes (enclaves) separately
+func (s *SurfaceItemSection) GetSurfaceGeometry(surfaceID int, data *PTVData) (outer [][][2]float64, inner [][][2]float64) {
+	if data.FaceItem == nil {
+		return nil, nil
+	}
+	outerFaces, innerFaces := s.GetBoundariesBySurfaceID(surfaceID)
+	for _, faceID := range outerFaces {
+		if ring := data.FaceItem.GetFaceGeometry(faceID, data); len(ring) > 0 {
+			outer = append(outer, ring)
+		}
+	}
+	for _, faceID := range innerFaces {
+		if ring := data.FaceItem.GetFaceGeometry(faceID, data); len(ring) > 0 {
+			inner = append(inner, ring)
+		}
+	}
+	return outer, inner
+}
+
 // GetSurfaceIDs returns all unique surface IDs in the section
 func (s *SurfaceItemSection) GetSurfaceIDs() []int {
 	surfaceMap := make(map[int]bool)
@@ -71,40 +84,54 @@ func (s *SurfaceItemSection) GetSurfaceIDs() []int {
 }
 
 // getSurfaceItem extracts data from SURFACEITEM section row
-func getSurfaceItem(values []string) (SurfaceItem, error) {
-	if len(values) < 3 {
-		return SurfaceItem{}, fmt.Errorf("invalid SURFACEITEM data: %v", values)
-	}
-
+func getSurfaceItem(values []string, headers []string) (SurfaceItem, error) {
 	var item SurfaceItem
 	var err error
 
 	// Parse SurfaceID (required field)
-	if values[0] == "" {
-		return SurfaceItem{}, fmt.Errorf("missing required field SURFACEID")
+	value := columnValue(values, headers, "SURFACEID", 0)
+	if value == "" {
+		return SurfaceItem{}, missingFieldError("SURFACEID")
 	}
-	item.SurfaceID, err = strconv.Atoi(values[0])
+	item.SurfaceID, err = strconv.Atoi(value)
 	if err != nil {
-		return SurfaceItem{}, fmt.Errorf("error parsing SURFACEID: %w", err)
+		return SurfaceItem{}, parseFieldError("SURFACEID", err)
 	}
 
 	// Parse FaceID (required field)
-	if values[1] == "" {
-		return SurfaceItem{}, fmt.Errorf("missing required field FACEID")
+	value = columnValue(values, headers, "FACEID", 1)
+	if value == "" {
+		return SurfaceItem{}, missingFieldError("FACEID")
 	}
-	item.FaceID, err = strconv.Atoi(values[1])
+	item.FaceID, err = strconv.Atoi(value)
 	if err != nil {
-		return SurfaceItem{}, fmt.Errorf("error parsing FACEID: %w", err)
+		return SurfaceItem{}, parseFieldError("FACEID", err)
 	}
 
 	// Parse Enclave (required field)
-	if values[2] == "" {
-		return SurfaceItem{}, fmt.Errorf("missing required field ENCLAVE")
+	value = columnValue(values, headers, "ENCLAVE", 2)
+	if value == "" {
+		return SurfaceItem{}, missingFieldError("ENCLAVE")
 	}
-	item.Enclave, err = strconv.Atoi(values[2])
+	item.Enclave, err = strconv.Atoi(value)
 	if err != nil {
-		return SurfaceItem{}, fmt.Errorf("error parsing ENCLAVE: %w", err)
+		return SurfaceItem{}, parseFieldError("ENCLAVE", err)
 	}
 
 	return item, nil
 }
+
+// surfaceItemColumns are the columns written when the section has no headers
+var surfaceItemColumns = []string{"SURFACEID", "FACEID", "ENCLAVE"}
+
+func (item SurfaceItem) attribute(column string) (string, bool) {
+	switch column {
+	case "SURFACEID":
+		return formatInt(item.SurfaceID), true
+	case "FACEID":
+		return formatInt(item.FaceID), true
+	case "ENCLAVE":
+		return formatInt(item.Enclave), true
+	}
+	return "", false
+}
@@ -1,14 +1,13 @@
 package ptvvisum
 
-import (
-	"fmt"
-	"strconv"
-)
+import "strconv"
 
 // SurfaceSection represents $SURFACE section
 type SurfaceSection struct {
 	BaseSection
 	Surfaces []Surface
+
+	byID index[Surface, int]
 }
 
 // Surface represents a single surface in the network
@@ -18,12 +17,7 @@ type Surface struct {
 
 // GetSurfaceByID retrieves a surface by its ID
 func (s *SurfaceSection) GetSurfaceByID(id int) (Surface, bool) {
-	for _, surface := range s.Surfaces {
-		if surface.ID == id {
-			return surface, true
-		}
-	}
-	return Surface{}, false
+	return s.byID.findFirst(s.Surfaces, id, func(surface Surface) int { return surface.ID })
 }
 
 // Contains checks if a surface ID exists in the section
@@ -32,6 +26,11 @@ func (s *SurfaceSection) Contains(id int) bool {
 	return found
 }
 
+// Reindex drops the lookup index after surface IDs have been changed in place, it is built again on next use
+func (s *SurfaceSection) Reindex() {
+	s.byID.reset()
+}
+
 // Count returns the number of surfaces in the section
 func (s *SurfaceSection) Count() int {
 	return len(s.Surfaces)
@@ -47,20 +46,27 @@ func (s *SurfaceSection) GetAllIDs() []int {
 }
 
 // getSurface extracts data from SURFACE section row
-func getSurface(values []string) (Surface, error) {
-	if len(values) < 1 {
-		return Surface{}, fmt.Errorf("invalid SURFACE data: %v", values)
-	}
-
+func getSurface(values []string, headers []string) (Surface, error) {
 	// Parse ID (required field)
-	if values[0] == "" {
-		return Surface{}, fmt.Errorf("missing required field ID")
+	value := columnValue(values, headers, "ID", 0)
+	if value == "" {
+		return Surface{}, missingFieldError("ID")
 	}
 
-	id, err := strconv.Atoi(values[0])
+	id, err := strconv.Atoi(value)
 	if err != nil {
-		return Surface{}, fmt.Errorf("error parsing ID: %w", err)
+		return Surface{}, parseFieldError("ID", err)
 	}
 
 	return Surface{ID: id}, nil
 }
+
+// surfaceColumns are the columns written when the section has no headers
+var surfaceColumns = []string{"ID"}
+
+func (surface Surface) attribute(column string) (string, bool) {
+	if column == "ID" {
+		return formatInt(surface.ID), true
+	}
+	return "", false
+}
@@ -0,0 +1,113 @@
+package ptvvisum
+
+import (
+	"strconv"
+	"strings"
+)
+
+// TerritorySection represents $TERRITORY section
+type TerritorySection struct {
+	BaseSection
+	Territories []Territory
+
+	byID index[Territory, int]
+}
+
+// Territory represents an area used to evaluate indicators, e.g. a district or a municipality
+type Territory struct {
+	No        int     // Territory number
+	Code      string  // Territory code
+	Name      string  // Territory name
+	XCoord    float64 // X-coordinate of the centroid
+	YCoord    float64 // Y-coordinate of the centroid
+	SurfaceID int     // ID of the surface that defines the territory boundary
+}
+
+// GetTerritoryByID retrieves a territory by its number
+func (s *TerritorySection) GetTerritoryByID(no int) (Territory, bool) {
+	return s.byID.findFirst(s.Territories, no, func(territory Territory) int { return territory.No })
+}
+
+// GetSurfaceGeometry builds the polygon of a territory, outer boundaries and inner holes (enclaves) separately.
+// Both are empty for territories without surface
+func (s *TerritorySection) GetSurfaceGeometry(no int, data *PTVData) (outer [][][2]float64, inner [][][2]float64) {
+	territory, found := s.GetTerritoryByID(no)
+	if !found || territory.SurfaceID == 0 || data.SurfaceItem == nil {
+		return nil, nil
+	}
+	return data.SurfaceItem.GetSurfaceGeometry(territory.SurfaceID, data)
+}
+
+// Reindex drops the lookup index after numbers of territories have been changed in place, it is built again on next use
+func (s *TerritorySection) Reindex() {
+	s.byID.reset()
+}
+
+// Count returns the number of territories in the section
+func (s *TerritorySection) Count() int {
+	return len(s.Territories)
+}
+
+// getTerritory extracts data from TERRITORY section row
+func getTerritory(values []string, headers []string) (Territory, error) {
+	var territory Territory
+	var err error
+
+	// Parse NO (required field)
+	value := columnValue(values, headers, "NO", 0)
+	if value == "" {
+		return Territory{}, missingFieldError("NO")
+	}
+	territory.No, err = strconv.Atoi(value)
+	if err != nil {
+		return Territory{}, parseFieldError("NO", err)
+	}
+
+	territory.Code = columnValue(values, headers, "CODE", 1)
+	territory.Name = columnValue(values, headers, "NAME", 2)
+
+	// Parse XCOORD and YCOORD (optional)
+	if value := columnValue(values, headers, "XCOORD", 3); value != "" {
+		territory.XCoord, err = strconv.ParseFloat(strings.Replace(value, ",", ".", -1), 64)
+		if err != nil {
+			return Territory{}, parseFieldError("XCOORD", err)
+		}
+	}
+	if value := columnValue(values, headers, "YCOORD", 4); value != "" {
+		territory.YCoord, err = strconv.ParseFloat(strings.Replace(value, ",", ".", -1), 64)
+		if err != nil {
+			return Territory{}, parseFieldError("YCOORD", err)
+		}
+	}
+
+	// Parse SURFACEID (optional, empty for territories without boundary)
+	if value := columnValue(values, headers, "SURFACEID", 5); value != "" {
+		territory.SurfaceID, err = strconv.Atoi(value)
+		if err != nil {
+			return Territory{}, parseFieldError("SURFACEID", err)
+		}
+	}
+
+	return territory, nil
+}
+
+// territoryColumns are the columns written when the section has no headers
+var territoryColumns = []string{"NO", "CODE", "NAME", "XCOORD", "YCOORD", "SURFACEID"}
+
+func (territory Territory) attribute(column string) (string, bool) {
+	switch column {
+	case "NO":
+		return formatInt(territory.No), true
+	case "CODE":
+		return territory.Code, true
+	case "NAME":
+		return territory.Name, true
+	case "XCOORD":
+		return formatFloat(territory.XCoord), true
+	case "YCOORD":
+		return formatFloat(territory.YCoord), true
+	case "SURFACEID":
+		return formatOptionalInt(territory.SurfaceID), true
+	}
+	return "", false
+}
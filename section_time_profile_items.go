@@ -0,0 +1,260 @@
+package ptvvisum
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// TimeProfileItemSection represents $TIMEPROFILEITEM section
+type TimeProfileItemSection struct {
+	BaseSection
+	Items []TimeProfileItem
+
+	byTimeProfile index[TimeProfileItem, TimeProfileKey]
+}
+
+// TimeProfileItem represents the run time of a time profile at a route point of its line route
+type TimeProfileItem struct {
+	LineName             string   // Name of the line
+	LineRouteName        string   // Name of the line route
+	DirectionCode        string   // Code of the direction of the line route
+	TimeProfileName      string   // Name of the time profile
+	Index                int      // Position of the item in the time profile
+	LRItemIndex          int      // Index of the line route item the item sits on
+	Alight               int      // Whether passengers may alight (0/1)
+	Board                int      // Whether passengers may board (0/1)
+	Arr                  Duration // Arrival offset from the start of the profile
+	Dep                  Duration // Departure offset from the start of the profile
+	NumFarePoints        int      // Number of fare points
+	NumFarePointsBoard   int      // Number of fare points when boarding
+	NumFarePointsThrough int      // Number of fare points when passing through
+	NumFarePointsAlight  int      // Number of fare points when alighting
+	AddVal               int      // Additional value
+}
+
+// TimeProfileKey returns the key of the time profile the item belongs to
+func (item TimeProfileItem) TimeProfileKey() TimeProfileKey {
+	return TimeProfileKey{
+		LineRouteKey:    LineRouteKey{LineName: item.LineName, LineRouteName: item.LineRouteName, DirectionCode: item.DirectionCode},
+		TimeProfileName: item.TimeProfileName,
+	}
+}
+
+// GetItemsByTimeProfile retrieves all items of a time profile in profile order
+func (s *TimeProfileItemSection) GetItemsByTimeProfile(key TimeProfileKey) []TimeProfileItem {
+	result := s.byTimeProfile.find(s.Items, key, TimeProfileItem.TimeProfileKey)
+
+	// Sort by index to ensure correct order
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Index < result[j].Index
+	})
+
+	return result
+}
+
+// GetItem retrieves the item of a time profile at the given index
+func (s *TimeProfileItemSection) GetItem(key TimeProfileKey, index int) (TimeProfileItem, bool) {
+	for _, i := range s.byTimeProfile.lookup(s.Items, key, TimeProfileItem.TimeProfileKey) {
+		if s.Items[i].Index == index {
+			return s.Items[i], true
+		}
+	}
+	return TimeProfileItem{}, false
+}
+
+// GetLineRouteItem retrieves the line route item a time profile item sits on
+func (s *TimeProfileItemSection) GetLineRouteItem(key TimeProfileKey, index int, data *PTVData) (LineRouteItem, bool) {
+	item, found := s.GetItem(key, index)
+	if !found || data.LineRouteItem == nil {
+		return LineRouteItem{}, false
+	}
+	return data.LineRouteItem.GetItem(key.LineRouteKey, item.LRItemIndex)
+}
+
+// GetRunTime returns the time from the departure at one item of a time profile to the arrival at a later one
+func (s *TimeProfileItemSection) GetRunTime(key TimeProfileKey, fromIndex, toIndex int) (time.Duration, error) {
+	from, found := s.GetItem(key, fromIndex)
+	if !found {
+		return 0, fmt.Errorf("no item %d in time profile %s", fromIndex, key.TimeProfileName)
+	}
+	to, found := s.GetItem(key, toIndex)
+	if !found {
+		return 0, fmt.Errorf("no item %d in time profile %s", toIndex, key.TimeProfileName)
+	}
+	if toIndex <= fromIndex {
+		return 0, fmt.Errorf("item %d does not follow item %d in time profile %s", toIndex, fromIndex, key.TimeProfileName)
+	}
+	return to.Arr.Duration - from.Dep.Duration, nil
+}
+
+// GetRunTimeBetweenStopPoints returns the time from the departure at one stop point of a time profile to the arrival at another one.
+// On routes passing a stop point several times the first departure and the first following arrival are used
+func (s *TimeProfileItemSection) GetRunTimeBetweenStopPoints(key TimeProfileKey, fromStopPointNo, toStopPointNo int, data *PTVData) (time.Duration, error) {
+	if data.LineRouteItem == nil {
+		return 0, fmt.Errorf("no line route items found in the data")
+	}
+	fromIndex := 0
+	for _, item := range s.GetItemsByTimeProfile(key) {
+		routeItem, found := data.LineRouteItem.GetItem(key.LineRouteKey, item.LRItemIndex)
+		if !found {
+			return 0, fmt.Errorf("no line route item %d for time profile %s", item.LRItemIndex, key.TimeProfileName)
+		}
+		switch {
+		case fromIndex == 0 && routeItem.StopPointNo == fromStopPointNo:
+			fromIndex = item.Index
+		case fromIndex != 0 && routeItem.StopPointNo == toStopPointNo:
+			return s.GetRunTime(key, fromIndex, item.Index)
+		}
+	}
+	if fromIndex == 0 {
+		return 0, fmt.Errorf("stop point %d not served by time profile %s", fromStopPointNo, key.TimeProfileName)
+	}
+	return 0, fmt.Errorf("stop point %d not served after stop point %d by time profile %s", toStopPointNo, fromStopPointNo, key.TimeProfileName)
+}
+
+// Reindex drops the lookup index after time profiles of items have been changed in place, it is built again on next use
+func (s *TimeProfileItemSection) Reindex() {
+	s.byTimeProfile.reset()
+}
+
+// Count returns the number of time profile items in the section
+func (s *TimeProfileItemSection) Count() int {
+	return len(s.Items)
+}
+
+// getTimeProfileItem extracts data from TIMEPROFILEITEM section row
+func getTimeProfileItem(values []string, headers []string) (TimeProfileItem, error) {
+	var item TimeProfileItem
+	var err error
+
+	// Parse LINENAME, LINEROUTENAME, DIRECTIONCODE and TIMEPROFILENAME (required fields)
+	item.LineName = columnValue(values, headers, "LINENAME", 0)
+	if item.LineName == "" {
+		return TimeProfileItem{}, missingFieldError("LINENAME")
+	}
+	item.LineRouteName = columnValue(values, headers, "LINEROUTENAME", 1)
+	if item.LineRouteName == "" {
+		return TimeProfileItem{}, missingFieldError("LINEROUTENAME")
+	}
+	item.DirectionCode = columnValue(values, headers, "DIRECTIONCODE", 2)
+	if item.DirectionCode == "" {
+		return TimeProfileItem{}, missingFieldError("DIRECTIONCODE")
+	}
+	item.TimeProfileName = columnValue(values, headers, "TIMEPROFILENAME", 3)
+	if item.TimeProfileName == "" {
+		return TimeProfileItem{}, missingFieldError("TIMEPROFILENAME")
+	}
+
+	// Parse INDEX and LRITEMINDEX (required fields)
+	value := columnValue(values, headers, "INDEX", 4)
+	if value == "" {
+		return TimeProfileItem{}, missingFieldError("INDEX")
+	}
+	item.Index, err = strconv.Atoi(value)
+	if err != nil {
+		return TimeProfileItem{}, parseFieldError("INDEX", err)
+	}
+	value = columnValue(values, headers, "LRITEMINDEX", 5)
+	if value == "" {
+		return TimeProfileItem{}, missingFieldError("LRITEMINDEX")
+	}
+	item.LRItemIndex, err = strconv.Atoi(value)
+	if err != nil {
+		return TimeProfileItem{}, parseFieldError("LRITEMINDEX", err)
+	}
+
+	// Parse ALIGHT and BOARD (optional)
+	if value := columnValue(values, headers, "ALIGHT", 6); value != "" {
+		item.Alight, err = strconv.Atoi(value)
+		if err != nil {
+			return TimeProfileItem{}, parseFieldError("ALIGHT", err)
+		}
+	}
+	if value := columnValue(values, headers, "BOARD", 7); value != "" {
+		item.Board, err = strconv.Atoi(value)
+		if err != nil {
+			return TimeProfileItem{}, parseFieldError("BOARD", err)
+		}
+	}
+
+	// Parse ARR and DEP (optional)
+	if value := columnValue(values, headers, "ARR", 8); value != "" {
+		item.Arr, err = parseDuration(value)
+		if err != nil {
+			return TimeProfileItem{}, parseFieldError("ARR", err)
+		}
+	}
+	if value := columnValue(values, headers, "DEP", 9); value != "" {
+		item.Dep, err = parseDuration(value)
+		if err != nil {
+			return TimeProfileItem{}, parseFieldError("DEP", err)
+		}
+	}
+
+	// Parse fare points (optional)
+	farePoints := []struct {
+		index int
+		dest  *int
+		name  string
+	}{
+		{10, &item.NumFarePoints, "NUMFAREPOINTS"},
+		{11, &item.NumFarePointsBoard, "NUMFAREPOINTSBOARD"},
+		{12, &item.NumFarePointsThrough, "NUMFAREPOINTSTHROUGH"},
+		{13, &item.NumFarePointsAlight, "NUMFAREPOINTSALIGHT"},
+		{14, &item.AddVal, "ADDVAL"},
+	}
+	for _, field := range farePoints {
+		if value := columnValue(values, headers, field.name, field.index); value != "" {
+			*field.dest, err = strconv.Atoi(value)
+			if err != nil {
+				return TimeProfileItem{}, parseFieldError(field.name, err)
+			}
+		}
+	}
+
+	return item, nil
+}
+
+// timeProfileItemColumns are the columns written when the section has no headers
+var timeProfileItemColumns = []string{
+	"LINENAME", "LINEROUTENAME", "DIRECTIONCODE", "TIMEPROFILENAME", "INDEX", "LRITEMINDEX", "ALIGHT", "BOARD", "ARR",
+	"DEP", "NUMFAREPOINTS", "NUMFAREPOINTSBOARD", "NUMFAREPOINTSTHROUGH", "NUMFAREPOINTSALIGHT", "ADDVAL",
+}
+
+func (item TimeProfileItem) attribute(column string) (string, bool) {
+	switch column {
+	case "LINENAME":
+		return item.LineName, true
+	case "LINEROUTENAME":
+		return item.LineRouteName, true
+	case "DIRECTIONCODE":
+		return item.DirectionCode, true
+	case "TIMEPROFILENAME":
+		return item.TimeProfileName, true
+	case "INDEX":
+		return formatInt(item.Index), true
+	case "LRITEMINDEX":
+		return formatInt(item.LRItemIndex), true
+	case "ALIGHT":
+		return formatInt(item.Alight), true
+	case "BOARD":
+		return formatInt(item.Board), true
+	case "ARR":
+		return item.Arr.String(), true
+	case "DEP":
+		return item.Dep.String(), true
+	case "NUMFAREPOINTS":
+		return formatInt(item.NumFarePoints), true
+	case "NUMFAREPOINTSBOARD":
+		return formatInt(item.NumFarePointsBoard), true
+	case "NUMFAREPOINTSTHROUGH":
+		return formatInt(item.NumFarePointsThrough), true
+	case "NUMFAREPOINTSALIGHT":
+		return formatInt(item.NumFarePointsAlight), true
+	case "ADDVAL":
+		return formatInt(item.AddVal), true
+	}
+	return "", false
+}
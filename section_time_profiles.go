@@ -0,0 +1,143 @@
+package ptvvisum
+
+import "strconv"
+
+// TimeProfileSection represents $TIMEPROFILE section
+type TimeProfileSection struct {
+	BaseSection
+	TimeProfiles []TimeProfile
+
+	byKey       index[TimeProfile, TimeProfileKey]
+	byLineRoute index[TimeProfile, LineRouteKey]
+}
+
+// TimeProfileKey identifies a time profile of a line route
+type TimeProfileKey struct {
+	LineRouteKey
+	TimeProfileName string // Name of the time profile
+}
+
+// TimeProfile represents a set of run times of a line route
+type TimeProfile struct {
+	LineName      string // Name of the line
+	LineRouteName string // Name of the line route
+	DirectionCode string // Code of the direction of the line route
+	Name          string // Time profile name
+	VehCombNo     int    // Vehicle combination, 0 if not set
+	RefItemIndex  int    // Index of the reference item
+	FixRefDep     int    // Whether the departure at the reference item is fixed (0/1)
+}
+
+// Key returns the key of the time profile
+func (profile TimeProfile) Key() TimeProfileKey {
+	return TimeProfileKey{LineRouteKey: profile.LineRouteKey(), TimeProfileName: profile.Name}
+}
+
+// LineRouteKey returns the key of the line route the time profile belongs to
+func (profile TimeProfile) LineRouteKey() LineRouteKey {
+	return LineRouteKey{LineName: profile.LineName, LineRouteName: profile.LineRouteName, DirectionCode: profile.DirectionCode}
+}
+
+// GetTimeProfile retrieves a time profile by its key
+func (s *TimeProfileSection) GetTimeProfile(key TimeProfileKey) (TimeProfile, bool) {
+	return s.byKey.findFirst(s.TimeProfiles, key, TimeProfile.Key)
+}
+
+// GetTimeProfilesByLineRoute retrieves all time profiles of a line route
+func (s *TimeProfileSection) GetTimeProfilesByLineRoute(key LineRouteKey) []TimeProfile {
+	return s.byLineRoute.find(s.TimeProfiles, key, TimeProfile.LineRouteKey)
+}
+
+// GetLineRoute retrieves the line route a time profile belongs to
+func (s *TimeProfileSection) GetLineRoute(key TimeProfileKey, data *PTVData) (LineRoute, bool) {
+	if _, found := s.GetTimeProfile(key); !found || data.LineRoute == nil {
+		return LineRoute{}, false
+	}
+	return data.LineRoute.GetLineRoute(key.LineRouteKey)
+}
+
+// Reindex drops the lookup indexes after keys of time profiles have been changed in place, they are built again on next use
+func (s *TimeProfileSection) Reindex() {
+	s.byKey.reset()
+	s.byLineRoute.reset()
+}
+
+// Count returns the number of time profiles in the section
+func (s *TimeProfileSection) Count() int {
+	return len(s.TimeProfiles)
+}
+
+// getTimeProfile extracts data from TIMEPROFILE section row
+func getTimeProfile(values []string, headers []string) (TimeProfile, error) {
+	var profile TimeProfile
+	var err error
+
+	// Parse LINENAME, LINEROUTENAME, DIRECTIONCODE and NAME (required fields)
+	profile.LineName = columnValue(values, headers, "LINENAME", 0)
+	if profile.LineName == "" {
+		return TimeProfile{}, missingFieldError("LINENAME")
+	}
+	profile.LineRouteName = columnValue(values, headers, "LINEROUTENAME", 1)
+	if profile.LineRouteName == "" {
+		return TimeProfile{}, missingFieldError("LINEROUTENAME")
+	}
+	profile.DirectionCode = columnValue(values, headers, "DIRECTIONCODE", 2)
+	if profile.DirectionCode == "" {
+		return TimeProfile{}, missingFieldError("DIRECTIONCODE")
+	}
+	profile.Name = columnValue(values, headers, "NAME", 3)
+	if profile.Name == "" {
+		return TimeProfile{}, missingFieldError("NAME")
+	}
+
+	// Parse VEHCOMBNO (optional)
+	if value := columnValue(values, headers, "VEHCOMBNO", 4); value != "" {
+		profile.VehCombNo, err = strconv.Atoi(value)
+		if err != nil {
+			return TimeProfile{}, parseFieldError("VEHCOMBNO", err)
+		}
+	}
+
+	// Parse REFITEMINDEX (optional)
+	if value := columnValue(values, headers, "REFITEMINDEX", 5); value != "" {
+		profile.RefItemIndex, err = strconv.Atoi(value)
+		if err != nil {
+			return TimeProfile{}, parseFieldError("REFITEMINDEX", err)
+		}
+	}
+
+	// Parse FIXREFDEP (optional)
+	if value := columnValue(values, headers, "FIXREFDEP", 6); value != "" {
+		profile.FixRefDep, err = strconv.Atoi(value)
+		if err != nil {
+			return TimeProfile{}, parseFieldError("FIXREFDEP", err)
+		}
+	}
+
+	return profile, nil
+}
+
+// timeProfileColumns are the columns written when the section has no headers
+var timeProfileColumns = []string{
+	"LINENAME", "LINEROUTENAME", "DIRECTIONCODE", "NAME", "VEHCOMBNO", "REFITEMINDEX", "FIXREFDEP",
+}
+
+func (profile TimeProfile) attribute(column string) (string, bool) {
+	switch column {
+	case "LINENAME":
+		return profile.LineName, true
+	case "LINEROUTENAME":
+		return profile.LineRouteName, true
+	case "DIRECTIONCODE":
+		return profile.DirectionCode, true
+	case "NAME":
+		return profile.Name, true
+	case "VEHCOMBNO":
+		return formatOptionalInt(profile.VehCombNo), true
+	case "REFITEMINDEX":
+		return formatInt(profile.RefItemIndex), true
+	case "FIXREFDEP":
+		return formatInt(profile.FixRefDep), true
+	}
+	return "", false
+}
@@ -0,0 +1,126 @@
+package ptvvisum
+
+import "strconv"
+
+// TransferWalkTimeStopAreaSection represents $TRANSFERWALKTIMESTOPAREA section
+type TransferWalkTimeStopAreaSection struct {
+	BaseSection
+	Transfers []TransferWalkTime
+
+	byFromStopArea index[TransferWalkTime, int]
+}
+
+// TransferWalkTime represents the walk time from one stop area to another for a transport system
+type TransferWalkTime struct {
+	FromStopAreaNo int      // Stop area the transfer starts at
+	ToStopAreaNo   int      // Stop area the transfer ends at
+	TSysCode       string   // Transport system walked with
+	Time           Duration // Walk time
+}
+
+// GetTransfersFromStopArea retrieves all transfers starting at a stop area
+func (s *TransferWalkTimeStopAreaSection) GetTransfersFromStopArea(stopAreaNo int) []TransferWalkTime {
+	return s.byFromStopArea.find(s.Transfers, stopAreaNo, func(transfer TransferWalkTime) int { return transfer.FromStopAreaNo })
+}
+
+// GetTransferWalkTime retrieves the walk time from one stop area directly to another for a transport system
+func (s *TransferWalkTimeStopAreaSection) GetTransferWalkTime(fromStopAreaNo, toStopAreaNo int, tsysCode string) (TransferWalkTime, bool) {
+	for _, transfer := range s.GetTransfersFromStopArea(fromStopAreaNo) {
+		if transfer.ToStopAreaNo == toStopAreaNo && transfer.TSysCode == tsysCode {
+			return transfer, true
+		}
+	}
+	return TransferWalkTime{}, false
+}
+
+// GetTransferGraphs builds a transfer graph for every transport system found in the section
+func (s *TransferWalkTimeStopAreaSection) GetTransferGraphs() map[string]*TransferGraph {
+	graphs := make(map[string]*TransferGraph)
+	for _, transfer := range s.Transfers {
+		graph, ok := graphs[transfer.TSysCode]
+		if !ok {
+			graph = newTransferGraph(transfer.TSysCode)
+			graphs[transfer.TSysCode] = graph
+		}
+		graph.addTransfer(transfer)
+	}
+	return graphs
+}
+
+// GetTransferGraph builds the transfer graph of a transport system, it is empty when the system has no transfers
+func (s *TransferWalkTimeStopAreaSection) GetTransferGraph(tsysCode string) *TransferGraph {
+	graph := newTransferGraph(tsysCode)
+	for _, transfer := range s.Transfers {
+		if transfer.TSysCode == tsysCode {
+			graph.addTransfer(transfer)
+		}
+	}
+	return graph
+}
+
+// Reindex drops the lookup index after stop areas of transfers have been changed in place, it is built again on next use
+func (s *TransferWalkTimeStopAreaSection) Reindex() {
+	s.byFromStopArea.reset()
+}
+
+// Count returns the number of transfer walk times in the section
+func (s *TransferWalkTimeStopAreaSection) Count() int {
+	return len(s.Transfers)
+}
+
+// getTransferWalkTime extracts data from TRANSFERWALKTIMESTOPAREA section row
+func getTransferWalkTime(values []string, headers []string) (TransferWalkTime, error) {
+	var transfer TransferWalkTime
+	var err error
+
+	// Parse FROMSTOPAREANO and TOSTOPAREANO (required fields)
+	value := columnValue(values, headers, "FROMSTOPAREANO", 0)
+	if value == "" {
+		return TransferWalkTime{}, missingFieldError("FROMSTOPAREANO")
+	}
+	transfer.FromStopAreaNo, err = strconv.Atoi(value)
+	if err != nil {
+		return TransferWalkTime{}, parseFieldError("FROMSTOPAREANO", err)
+	}
+	value = columnValue(values, headers, "TOSTOPAREANO", 1)
+	if value == "" {
+		return TransferWalkTime{}, missingFieldError("TOSTOPAREANO")
+	}
+	transfer.ToStopAreaNo, err = strconv.Atoi(value)
+	if err != nil {
+		return TransferWalkTime{}, parseFieldError("TOSTOPAREANO", err)
+	}
+
+	// Parse TSYSCODE (required field)
+	transfer.TSysCode = columnValue(values, headers, "TSYSCODE", 2)
+	if transfer.TSysCode == "" {
+		return TransferWalkTime{}, missingFieldError("TSYSCODE")
+	}
+
+	// Parse TIME (optional)
+	if value := columnValue(values, headers, "TIME", 3); value != "" {
+		transfer.Time, err = parseDuration(value)
+		if err != nil {
+			return TransferWalkTime{}, parseFieldError("TIME", err)
+		}
+	}
+
+	return transfer, nil
+}
+
+// transferWalkTimeColumns are the columns written when the section has no headers
+var transferWalkTimeColumns = []string{"FROMSTOPAREANO", "TOSTOPAREANO", "TSYSCODE", "TIME"}
+
+func (transfer TransferWalkTime) attribute(column string) (string, bool) {
+	switch column {
+	case "FROMSTOPAREANO":
+		return formatInt(transfer.FromStopAreaNo), true
+	case "TOSTOPAREANO":
+		return formatInt(transfer.ToStopAreaNo), true
+	case "TSYSCODE":
+		return transfer.TSysCode, true
+	case "TIME":
+		return transfer.Time.String(), true
+	}
+	return "", false
+}
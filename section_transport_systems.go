@@ -1,7 +1,6 @@
 package ptvvisum
 
 import (
-	"fmt"
 	"strconv"
 	"strings"
 )
@@ -30,98 +29,136 @@ type TransportSystem struct {
 	OccupancyRate            float64 // Occupancy rate
 }
 
-// getTransportSystem extracts data from TSYS section row
-func getTransportSystem(values []string) (TransportSystem, error) {
-	if len(values) < 3 {
-		return TransportSystem{}, fmt.Errorf("insufficient TSYS data (need at least CODE, NAME, TYPE): %v", values)
+// GetTransportSystemByCode retrieves a transport system by its code
+func (s *TSysSection) GetTransportSystemByCode(code string) (TransportSystem, bool) {
+	for _, system := range s.Systems {
+		if system.Code == code {
+			return system, true
+		}
 	}
+	return TransportSystem{}, false
+}
 
+// getTransportSystem extracts data from TSYS section row
+func getTransportSystem(values []string, headers []string) (TransportSystem, error) {
 	// Always initialize these fields
 	ts := TransportSystem{
-		Code: values[0],
-		Name: values[1],
-		Type: values[2],
+		Code: columnValue(values, headers, "CODE", 0),
+		Name: columnValue(values, headers, "NAME", 1),
+		Type: columnValue(values, headers, "TYPE", 2),
 	}
 
 	// Parse PCU (Passenger Car Unit) if available
-	if len(values) > 3 && values[3] != "" {
-		pcu, err := strconv.ParseFloat(strings.Replace(values[3], ",", ".", -1), 64)
+	if value := columnValue(values, headers, "PCU", 3); value != "" {
+		pcu, err := strconv.ParseFloat(strings.Replace(value, ",", ".", -1), 64)
 		if err != nil {
-			return TransportSystem{}, fmt.Errorf("error parsing PCU: %w", err)
+			return TransportSystem{}, parseFieldError("PCU", err)
 		}
 		ts.PCU = pcu
 	}
 
 	// SBA fields (as strings with units)
-	if len(values) > 4 {
-		ts.SBAReactionTime = values[4]
-	}
-
-	if len(values) > 5 {
-		ts.SBAEffVehLength = values[5]
-	}
-
-	if len(values) > 6 {
-		ts.SBAMaxWaitingTime = values[6]
-	}
+	ts.SBAReactionTime = columnValue(values, headers, "SBAREACTIONTIME", 4)
+	ts.SBAEffVehLength = columnValue(values, headers, "SBAEFFVEHLENGTH", 5)
+	ts.SBAMaxWaitingTime = columnValue(values, headers, "SBAMAXWAITINGTIME", 6)
 
 	// Boolean flags (stored as integers)
-	if len(values) > 7 && values[7] != "" {
-		isRoundTrip, err := strconv.Atoi(values[7])
+	if value := columnValue(values, headers, "ISROUNDTRIPSYSTEM", 7); value != "" {
+		isRoundTrip, err := strconv.Atoi(value)
 		if err != nil {
-			return TransportSystem{}, fmt.Errorf("error parsing IsRoundTripSystem: %w", err)
+			return TransportSystem{}, parseFieldError("ISROUNDTRIPSYSTEM", err)
 		}
 		ts.IsRoundTripSystem = isRoundTrip
 	}
 
-	if len(values) > 8 && values[8] != "" {
-		isStation, err := strconv.Atoi(values[8])
+	if value := columnValue(values, headers, "ISSTATIONBASED", 8); value != "" {
+		isStation, err := strconv.Atoi(value)
 		if err != nil {
-			return TransportSystem{}, fmt.Errorf("error parsing IsStationBased: %w", err)
+			return TransportSystem{}, parseFieldError("ISSTATIONBASED", err)
 		}
 		ts.IsStationBased = isStation
 	}
 
-	if len(values) > 9 && values[9] != "" {
-		allowRel, err := strconv.Atoi(values[9])
+	if value := columnValue(values, headers, "ALLOWRELOCATIONS", 9); value != "" {
+		allowRel, err := strconv.Atoi(value)
 		if err != nil {
-			return TransportSystem{}, fmt.Errorf("error parsing AllowRelocations: %w", err)
+			return TransportSystem{}, parseFieldError("ALLOWRELOCATIONS", err)
 		}
 		ts.AllowRelocations = allowRel
 	}
 
 	// Numeric fields
-	if len(values) > 10 && values[10] != "" {
-		maxRel, err := strconv.ParseFloat(strings.Replace(values[10], ",", ".", -1), 64)
+	if value := columnValue(values, headers, "MAXNUMRELOCATIONSPERHOUR", 10); value != "" {
+		maxRel, err := strconv.ParseFloat(strings.Replace(value, ",", ".", -1), 64)
 		if err != nil {
-			return TransportSystem{}, fmt.Errorf("error parsing MaxNumRelocationsPerHour: %w", err)
+			return TransportSystem{}, parseFieldError("MAXNUMRELOCATIONSPERHOUR", err)
 		}
 		ts.MaxNumRelocationsPerHour = maxRel
 	}
 
-	if len(values) > 11 && values[11] != "" {
-		hasDepot, err := strconv.Atoi(values[11])
+	if value := columnValue(values, headers, "HASDEPOT", 11); value != "" {
+		hasDepot, err := strconv.Atoi(value)
 		if err != nil {
-			return TransportSystem{}, fmt.Errorf("error parsing HasDepot: %w", err)
+			return TransportSystem{}, parseFieldError("HASDEPOT", err)
 		}
 		ts.HasDepot = hasDepot
 	}
 
-	if len(values) > 12 && values[12] != "" {
-		numVeh, err := strconv.ParseFloat(strings.Replace(values[12], ",", ".", -1), 64)
+	if value := columnValue(values, headers, "NUMVEHICLESINNETWORK", 12); value != "" {
+		numVeh, err := strconv.ParseFloat(strings.Replace(value, ",", ".", -1), 64)
 		if err != nil {
-			return TransportSystem{}, fmt.Errorf("error parsing NumVehiclesInNetwork: %w", err)
+			return TransportSystem{}, parseFieldError("NUMVEHICLESINNETWORK", err)
 		}
 		ts.NumVehiclesInNetwork = numVeh
 	}
 
-	if len(values) > 13 && values[13] != "" {
-		occRate, err := strconv.ParseFloat(strings.Replace(values[13], ",", ".", -1), 64)
+	if value := columnValue(values, headers, "OCCUPANCYRATE", 13); value != "" {
+		occRate, err := strconv.ParseFloat(strings.Replace(value, ",", ".", -1), 64)
 		if err != nil {
-			return TransportSystem{}, fmt.Errorf("error parsing OccupancyRate: %w", err)
+			return TransportSystem{}, parseFieldError("OCCUPANCYRATE", err)
 		}
 		ts.OccupancyRate = occRate
 	}
 
 	return ts, nil
 }
+
+// transportSystemColumns are the columns written when the section has no headers
+var transportSystemColumns = []string{
+	"CODE", "NAME", "TYPE", "PCU", "SBAREACTIONTIME", "SBAEFFVEHLENGTH", "SBAMAXWAITINGTIME", "ISROUNDTRIPSYSTEM",
+	"ISSTATIONBASED", "ALLOWRELOCATIONS", "MAXNUMRELOCATIONSPERHOUR", "HASDEPOT", "NUMVEHICLESINNETWORK", "OCCUPANCYRATE",
+}
+
+func (ts TransportSystem) attribute(column string) (string, bool) {
+	switch column {
+	case "CODE":
+		return ts.Code, true
+	case "NAME":
+		return ts.Name, true
+	case "TYPE":
+		return ts.Type, true
+	case "PCU":
+		return formatFloat(ts.PCU), true
+	case "SBAREACTIONTIME":
+		return ts.SBAReactionTime, true
+	case "SBAEFFVEHLENGTH":
+		return ts.SBAEffVehLength, true
+	case "SBAMAXWAITINGTIME":
+		return ts.SBAMaxWaitingTime, true
+	case "ISROUNDTRIPSYSTEM":
+		return formatInt(ts.IsRoundTripSystem), true
+	case "ISSTATIONBASED":
+		return formatInt(ts.IsStationBased), true
+	case "ALLOWRELOCATIONS":
+		return formatInt(ts.AllowRelocations), true
+	case "MAXNUMRELOCATIONSPERHOUR":
+		return formatFloat(ts.MaxNumRelocationsPerHour), true
+	case "HASDEPOT":
+		return formatInt(ts.HasDepot), true
+	case "NUMVEHICLESINNETWORK":
+		return formatFloat(ts.NumVehiclesInNetwork), true
+	case "OCCUPANCYRATE":
+		return formatFloat(ts.OccupancyRate), true
+	}
+	return "", false
+}
@@ -1,118 +1,101 @@
 package ptvvisum
 
 import (
-	"fmt"
 	"strconv"
 	"strings"
-
-	"github.com/lddl/go-ptv-visum/utils"
 )
 
 // TurnSection represents $TURN section
 type TurnSection struct {
 	BaseSection
 	Turns []Turn
+
+	byViaNode  index[Turn, int]
+	byFromNode index[Turn, int]
+	byToNode   index[Turn, int]
 }
 
 // Turn represents a single turning movement in the transportation network
 type Turn struct {
-	FromNodeNo                             int     // Origin node ID
-	ViaNodeNo                              int     // Intersection node ID
-	ToNodeNo                               int     // Destination node ID
-	TypeNo                                 int     // Turn type ID (1=left, 2=right, 3=through, 4=U-turn)
-	TSysSet                                string  // Transport systems allowed
-	CapPRT                                 int     // Capacity for private transport
-	T0PRT                                  string  // Default travel time
-	AddVal                                 [3]int  // Additional values 1-3
-	SBAPresetCriticalGap                   string  // SBA critical gap time
-	SBAUsePresetCriticalGap                int     // Flag to use preset critical gap
-	SBAPresetFollowupGap                   string  // SBA followup gap time
-	SBAUsePresetFollowupGap                int     // Flag to use preset followup gap
-	SBAPresetCriticalGapTurnOnRed          string  // Critical gap for turn on red
-	SBAUsePresetCriticalGapTurnOnRed       int     // Flag to use preset critical gap for turn on red
-	SBAPresetFollowupGapTurnOnRed          string  // Followup gap for turn on red
-	SBAUsePresetFollowupGapTurnOnRed       int     // Flag to use preset followup gap for turn on red
-	ICAUsePresetSatFlowRate                int     // Flag to use preset saturation flow rate
-	ICAPresetSatFlowRate                   float64 // Preset saturation flow rate
-	ICAUsePresetCriticalGap                int     // Flag to use preset critical gap for ICA
-	ICAPresetCriticalGap                   string  // Preset critical gap for ICA
-	ICAPresetCriticalGapStageOne           string  // Preset critical gap stage one
-	ICAPresetCriticalGapStageTwo           string  // Preset critical gap stage two
-	ICAUsePresetFollowupTime               int     // Flag to use preset followup time for ICA
-	ICAPresetFollowupTime                  string  // Preset followup time for ICA
-	ICATurningRadius                       string  // Turning radius
-	ICAUsePresentSatFlowAdjustment         int     // Flag to use preset saturation flow adjustment
-	ICAPresetSatFlowAdjustment             float64 // Preset saturation flow adjustment
-	ICAProtectedInnerSatFlowAdjustment     float64 // Protected inner saturation flow adjustment
-	ICAUsePermissiveInnerSatFlowAdjustment int     // Flag to use permissive inner saturation flow adjustment
-	ICAPermissiveInnerSatFlowAdjustment    float64 // Permissive inner saturation flow adjustment
-	ICAUsePedestrianSatFlowAdjustment      int     // Flag to use pedestrian saturation flow adjustment
-	ICAPedestrianSatFlowAdjustment         float64 // Pedestrian saturation flow adjustment
-	ICAUsePresetLaneWidthAdjustment        int     // Flag to use preset lane width adjustment
-	ICAPresetLaneWidthAdjustment           float64 // Preset lane width adjustment
-	ICAUsePresetGradeAdjustment            int     // Flag to use preset grade adjustment
-	ICAPresetGradeAdjustment               float64 // Preset grade adjustment
-	ICAUsePresetTurningRadiusAdjustment    int     // Flag to use preset turning radius adjustment
-	ICAPresetTurningRadiusAdjustment       float64 // Preset turning radius adjustment
-	ICAUpstreamAdj                         float64 // Upstream adjustment factor
-	ICAPHFVolAdj                           float64 // Peak hour factor volume adjustment
-	ICAUnsignalizedDelay                   string  // Unsignalized delay
-	AuxiliarySG                            string  // Auxiliary signal group
-	IsChangeOfDirection                    int     // Flag indicating change of direction
-	VISTROBaseVolInput                     int     // VISTRO base volume input
-	VISTROBaseVolAdjustFactor              float64 // VISTRO base volume adjustment factor
-	ShareHGV                               float64 // Share of heavy goods vehicles
-	VISTROGrowthFactor                     float64 // VISTRO growth factor
-	VISTROInProcessVol                     int     // VISTRO in-process volume
-	VISTRODivTrips                         int     // VISTRO diverted trips
-	VISTROPassByTrips                      int     // VISTRO pass-by trips
-	VISTROSiteAdjustVol                    int     // VISTRO site adjustment volume
-	VISTROOtherVol                         int     // VISTRO other volume
-	VISTRORightTurnOnRedVol                int     // VISTRO right turn on red volume
-	VISTROTurnOnRedPercentage              float64 // VISTRO turn on red percentage
-	VISTROTurnOnRedVolumeCalculationMethod string  // VISTRO turn on red volume calculation method
-	VISTROLRORderNo                        int     // VISTRO left/right order number
-	VISTROOtherAdjustFactor                float64 // VISTRO other adjustment factor
-	VISTROLaneWidth                        string  // VISTRO lane width
-	UseVISTROLaneWidth                     int     // Flag to use VISTRO lane width
-	VISTROOuterControl                     string  // VISTRO outer control type
-	VISTROThruControl                      string  // VISTRO through control type
-	VISTROInnerControl                     string  // VISTRO inner control type
-	VISTROSGNo                             int     // VISTRO signal group number
-	VISTROOVLNo                            int     // VISTRO overlap number
+	FromNodeNo                             int      // Origin node ID
+	ViaNodeNo                              int      // Intersection node ID
+	ToNodeNo                               int      // Destination node ID
+	TypeNo                                 int      // Turn type ID (1=left, 2=right, 3=through, 4=U-turn)
+	TSysSet                                string   // Transport systems allowed
+	CapPRT                                 int      // Capacity for private transport
+	T0PRT                                  Duration // Default travel time
+	AddVal                                 [3]int   // Additional values 1-3
+	SBAPresetCriticalGap                   Duration // SBA critical gap time
+	SBAUsePresetCriticalGap                int      // Flag to use preset critical gap
+	SBAPresetFollowupGap                   Duration // SBA followup gap time
+	SBAUsePresetFollowupGap                int      // Flag to use preset followup gap
+	SBAPresetCriticalGapTurnOnRed          Duration // Critical gap for turn on red
+	SBAUsePresetCriticalGapTurnOnRed       int      // Flag to use preset critical gap for turn on red
+	SBAPresetFollowupGapTurnOnRed          Duration // Followup gap for turn on red
+	SBAUsePresetFollowupGapTurnOnRed       int      // Flag to use preset followup gap for turn on red
+	ICAUsePresetSatFlowRate                int      // Flag to use preset saturation flow rate
+	ICAPresetSatFlowRate                   float64  // Preset saturation flow rate
+	ICAUsePresetCriticalGap                int      // Flag to use preset critical gap for ICA
+	ICAPresetCriticalGap                   Duration // Preset critical gap for ICA
+	ICAPresetCriticalGapStageOne           Duration // Preset critical gap stage one
+	ICAPresetCriticalGapStageTwo           Duration // Preset critical gap stage two
+	ICAUsePresetFollowupTime               int      // Flag to use preset followup time for ICA
+	ICAPresetFollowupTime                  Duration // Preset followup time for ICA
+	ICATurningRadius                       string   // Turning radius
+	ICAUsePresentSatFlowAdjustment         int      // Flag to use preset saturation flow adjustment
+	ICAPresetSatFlowAdjustment             float64  // Preset saturation flow adjustment
+	ICAProtectedInnerSatFlowAdjustment     float64  // Protected inner saturation flow adjustment
+	ICAUsePermissiveInnerSatFlowAdjustment int      // Flag to use permissive inner saturation flow adjustment
+	ICAPermissiveInnerSatFlowAdjustment    float64  // Permissive inner saturation flow adjustment
+	ICAUsePedestrianSatFlowAdjustment      int      // Flag to use pedestrian saturation flow adjustment
+	ICAPedestrianSatFlowAdjustment         float64  // Pedestrian saturation flow adjustment
+	ICAUsePresetLaneWidthAdjustment        int      // Flag to use preset lane width adjustment
+	ICAPresetLaneWidthAdjustment           float64  // Preset lane width adjustment
+	ICAUsePresetGradeAdjustment            int      // Flag to use preset grade adjustment
+	ICAPresetGradeAdjustment               float64  // Preset grade adjustment
+	ICAUsePresetTurningRadiusAdjustment    int      // Flag to use preset turning radius adjustment
+	ICAPresetTurningRadiusAdjustment       float64  // Preset turning radius adjustment
+	ICAUpstreamAdj                         float64  // Upstream adjustment factor
+	ICAPHFVolAdj                           float64  // Peak hour factor volume adjustment
+	ICAUnsignalizedDelay                   Duration // Unsignalized delay
+	AuxiliarySG                            string   // Auxiliary signal group
+	IsChangeOfDirection                    int      // Flag indicating change of direction
+	VISTROBaseVolInput                     int      // VISTRO base volume input
+	VISTROBaseVolAdjustFactor              float64  // VISTRO base volume adjustment factor
+	ShareHGV                               float64  // Share of heavy goods vehicles
+	VISTROGrowthFactor                     float64  // VISTRO growth factor
+	VISTROInProcessVol                     int      // VISTRO in-process volume
+	VISTRODivTrips                         int      // VISTRO diverted trips
+	VISTROPassByTrips                      int      // VISTRO pass-by trips
+	VISTROSiteAdjustVol                    int      // VISTRO site adjustment volume
+	VISTROOtherVol                         int      // VISTRO other volume
+	VISTRORightTurnOnRedVol                int      // VISTRO right turn on red volume
+	VISTROTurnOnRedPercentage              float64  // VISTRO turn on red percentage
+	VISTROTurnOnRedVolumeCalculationMethod string   // VISTRO turn on red volume calculation method
+	VISTROLRORderNo                        int      // VISTRO left/right order number
+	VISTROOtherAdjustFactor                float64  // VISTRO other adjustment factor
+	VISTROLaneWidth                        string   // VISTRO lane width
+	UseVISTROLaneWidth                     int      // Flag to use VISTRO lane width
+	VISTROOuterControl                     string   // VISTRO outer control type
+	VISTROThruControl                      string   // VISTRO through control type
+	VISTROInnerControl                     string   // VISTRO inner control type
+	VISTROSGNo                             int      // VISTRO signal group number
+	VISTROOVLNo                            int      // VISTRO overlap number
 }
 
 // GetTurnsByIntersection retrieves all turns at a specified intersection node
 func (s *TurnSection) GetTurnsByIntersection(nodeNo int) []Turn {
-	var result []Turn
-	for _, turn := range s.Turns {
-		if turn.ViaNodeNo == nodeNo {
-			result = append(result, turn)
-		}
-	}
-	return result
+	return s.byViaNode.find(s.Turns, nodeNo, turnViaNode)
 }
 
 // GetTurnsByOrigin retrieves all turns from a specified origin node
 func (s *TurnSection) GetTurnsByOrigin(nodeNo int) []Turn {
-	var result []Turn
-	for _, turn := range s.Turns {
-		if turn.FromNodeNo == nodeNo {
-			result = append(result, turn)
-		}
-	}
-	return result
+	return s.byFromNode.find(s.Turns, nodeNo, func(turn Turn) int { return turn.FromNodeNo })
 }
 
 // GetTurnsByDestination retrieves all turns to a specified destination node
 func (s *TurnSection) GetTurnsByDestination(nodeNo int) []Turn {
-	var result []Turn
-	for _, turn := range s.Turns {
-		if turn.ToNodeNo == nodeNo {
-			result = append(result, turn)
-		}
-	}
-	return result
+	return s.byToNode.find(s.Turns, nodeNo, func(turn Turn) int { return turn.ToNodeNo })
 }
 
 // GetTurnsByType retrieves all turns of a specified type
@@ -128,8 +111,10 @@ func (s *TurnSection) GetTurnsByType(typeNo int) []Turn {
 
 // GetTurn retrieves a specific turn by its nodes
 func (s *TurnSection) GetTurn(fromNodeNo, viaNodeNo, toNodeNo int) (Turn, bool) {
-	for _, turn := range s.Turns {
-		if turn.FromNodeNo == fromNodeNo && turn.ViaNodeNo == viaNodeNo && turn.ToNodeNo == toNodeNo {
+	// A node has only a few turns, so they are filtered after the lookup by the via node
+	for _, i := range s.byViaNode.lookup(s.Turns, viaNodeNo, turnViaNode) {
+		turn := s.Turns[i]
+		if turn.FromNodeNo == fromNodeNo && turn.ToNodeNo == toNodeNo {
 			return turn, true
 		}
 	}
@@ -171,33 +156,20 @@ func (s *TurnSection) GetUTurns() []Turn {
 	return s.GetTurnsByType(4)
 }
 
-// GetTravelTime extracts the numeric travel time from the T0PRT string (e.g., "10s" -> 10)
+// GetTravelTime returns the T0PRT travel time in seconds
 func (t *Turn) GetTravelTime() float64 {
-	if t.T0PRT == "" {
-		return 0
-	}
-
-	// Extract numeric part
-	numStr := t.T0PRT
-
-	// Find where the numeric part ends
-	for i, c := range t.T0PRT {
-		if !utils.IsDigit(c) && c != '.' && c != ',' {
-			numStr = t.T0PRT[:i]
-			break
-		}
-	}
-
-	// Convert to float
-	numStr = strings.Replace(numStr, ",", ".", 1)
-	value, err := strconv.ParseFloat(numStr, 64)
-	if err != nil {
-		return 0
-	}
+	return t.T0PRT.Seconds()
+}
 
-	return value
+// Reindex drops the lookup indexes after nodes of turns have been changed in place, they are built again on next use
+func (s *TurnSection) Reindex() {
+	s.byViaNode.reset()
+	s.byFromNode.reset()
+	s.byToNode.reset()
 }
 
+func turnViaNode(turn Turn) int { return turn.ViaNodeNo }
+
 // Count returns the number of turns in the section
 func (s *TurnSection) Count() int {
 	return len(s.Turns)
@@ -213,434 +185,604 @@ func (s *TurnSection) CountByIntersection() map[int]int {
 }
 
 // getTurn extracts data from TURN section row
-func getTurn(values []string) (Turn, error) {
-	if len(values) < 7 {
-		return Turn{}, fmt.Errorf("invalid TURN data (insufficient fields): %v", values)
-	}
-
+func getTurn(values []string, headers []string) (Turn, error) {
 	var turn Turn
 	var err error
 
 	// Parse FROMNODENO (required field)
-	if values[0] == "" {
-		return Turn{}, fmt.Errorf("missing required field FROMNODENO")
+	value := columnValue(values, headers, "FROMNODENO", 0)
+	if value == "" {
+		return Turn{}, missingFieldError("FROMNODENO")
 	}
-	turn.FromNodeNo, err = strconv.Atoi(values[0])
+	turn.FromNodeNo, err = strconv.Atoi(value)
 	if err != nil {
-		return Turn{}, fmt.Errorf("error parsing FROMNODENO: %w", err)
+		return Turn{}, parseFieldError("FROMNODENO", err)
 	}
 
 	// Parse VIANODENO (required field)
-	if values[1] == "" {
-		return Turn{}, fmt.Errorf("missing required field VIANODENO")
+	value = columnValue(values, headers, "VIANODENO", 1)
+	if value == "" {
+		return Turn{}, missingFieldError("VIANODENO")
 	}
-	turn.ViaNodeNo, err = strconv.Atoi(values[1])
+	turn.ViaNodeNo, err = strconv.Atoi(value)
 	if err != nil {
-		return Turn{}, fmt.Errorf("error parsing VIANODENO: %w", err)
+		return Turn{}, parseFieldError("VIANODENO", err)
 	}
 
 	// Parse TONODENO (required field)
-	if values[2] == "" {
-		return Turn{}, fmt.Errorf("missing required field TONODENO")
+	value = columnValue(values, headers, "TONODENO", 2)
+	if value == "" {
+		return Turn{}, missingFieldError("TONODENO")
 	}
-	turn.ToNodeNo, err = strconv.Atoi(values[2])
+	turn.ToNodeNo, err = strconv.Atoi(value)
 	if err != nil {
-		return Turn{}, fmt.Errorf("error parsing TONODENO: %w", err)
+		return Turn{}, parseFieldError("TONODENO", err)
 	}
 
-	// Parse TYPENO (required field)
-	if values[3] == "" {
-		return Turn{}, fmt.Errorf("missing required field TYPENO")
-	}
-	turn.TypeNo, err = strconv.Atoi(values[3])
-	if err != nil {
-		return Turn{}, fmt.Errorf("error parsing TYPENO: %w", err)
+	// Parse TYPENO (optional)
+	if value := columnValue(values, headers, "TYPENO", 3); value != "" {
+		turn.TypeNo, err = strconv.Atoi(value)
+		if err != nil {
+			return Turn{}, parseFieldError("TYPENO", err)
+		}
 	}
 
 	// Parse TSYSSET (optional)
-	turn.TSysSet = values[4]
+	turn.TSysSet = columnValue(values, headers, "TSYSSET", 4)
 
 	// Parse CAPPRT (required field)
-	if values[5] != "" {
-		turn.CapPRT, err = strconv.Atoi(values[5])
+	if value := columnValue(values, headers, "CAPPRT", 5); value != "" {
+		turn.CapPRT, err = strconv.Atoi(value)
 		if err != nil {
-			return Turn{}, fmt.Errorf("error parsing CAPPRT: %w", err)
+			return Turn{}, parseFieldError("CAPPRT", err)
 		}
 	}
 
 	// Parse T0PRT (required field)
-	turn.T0PRT = values[6]
+	if value := columnValue(values, headers, "T0PRT", 6); value != "" {
+		turn.T0PRT, err = parseDuration(value)
+		if err != nil {
+			return Turn{}, parseFieldError("T0PRT", err)
+		}
+	}
 
 	// Parse ADDVAL1, ADDVAL2, ADDVAL3 (if available)
-	if len(values) > 7 && values[7] != "" {
-		turn.AddVal[0], err = strconv.Atoi(values[7])
+	if value := columnValue(values, headers, "ADDVAL1", 7); value != "" {
+		turn.AddVal[0], err = strconv.Atoi(value)
 		if err != nil {
-			return Turn{}, fmt.Errorf("error parsing ADDVAL1: %w", err)
+			return Turn{}, parseFieldError("ADDVAL1", err)
 		}
 	}
 
-	if len(values) > 8 && values[8] != "" {
-		turn.AddVal[1], err = strconv.Atoi(values[8])
+	if value := columnValue(values, headers, "ADDVAL2", 8); value != "" {
+		turn.AddVal[1], err = strconv.Atoi(value)
 		if err != nil {
-			return Turn{}, fmt.Errorf("error parsing ADDVAL2: %w", err)
+			return Turn{}, parseFieldError("ADDVAL2", err)
 		}
 	}
 
-	if len(values) > 9 && values[9] != "" {
-		turn.AddVal[2], err = strconv.Atoi(values[9])
+	if value := columnValue(values, headers, "ADDVAL3", 9); value != "" {
+		turn.AddVal[2], err = strconv.Atoi(value)
 		if err != nil {
-			return Turn{}, fmt.Errorf("error parsing ADDVAL3: %w", err)
+			return Turn{}, parseFieldError("ADDVAL3", err)
 		}
 	}
 
 	// Parse SBA fields (if available)
-	if len(values) > 10 {
-		turn.SBAPresetCriticalGap = values[10]
+	if value := columnValue(values, headers, "SBAPRESETCRITICALGAP", 10); value != "" {
+		turn.SBAPresetCriticalGap, err = parseDuration(value)
+		if err != nil {
+			return Turn{}, parseFieldError("SBAPRESETCRITICALGAP", err)
+		}
 	}
 
-	if len(values) > 11 && values[11] != "" {
-		turn.SBAUsePresetCriticalGap, err = strconv.Atoi(values[11])
+	if value := columnValue(values, headers, "SBAUSEPRESETCRITICALGAP", 11); value != "" {
+		turn.SBAUsePresetCriticalGap, err = strconv.Atoi(value)
 		if err != nil {
-			return Turn{}, fmt.Errorf("error parsing SBAUSEPRESETCRITICALGAP: %w", err)
+			return Turn{}, parseFieldError("SBAUSEPRESETCRITICALGAP", err)
 		}
 	}
 
-	if len(values) > 12 {
-		turn.SBAPresetFollowupGap = values[12]
+	if value := columnValue(values, headers, "SBAPRESETFOLLOWUPGAP", 12); value != "" {
+		turn.SBAPresetFollowupGap, err = parseDuration(value)
+		if err != nil {
+			return Turn{}, parseFieldError("SBAPRESETFOLLOWUPGAP", err)
+		}
 	}
 
-	if len(values) > 13 && values[13] != "" {
-		turn.SBAUsePresetFollowupGap, err = strconv.Atoi(values[13])
+	if value := columnValue(values, headers, "SBAUSEPRESETFOLLOWUPGAP", 13); value != "" {
+		turn.SBAUsePresetFollowupGap, err = strconv.Atoi(value)
 		if err != nil {
-			return Turn{}, fmt.Errorf("error parsing SBAUSEPRESETFOLLOWUPGAP: %w", err)
+			return Turn{}, parseFieldError("SBAUSEPRESETFOLLOWUPGAP", err)
 		}
 	}
 
-	if len(values) > 14 {
-		turn.SBAPresetCriticalGapTurnOnRed = values[14]
+	if value := columnValue(values, headers, "SBAPRESETCRITICALGAPTURNONRED", 14); value != "" {
+		turn.SBAPresetCriticalGapTurnOnRed, err = parseDuration(value)
+		if err != nil {
+			return Turn{}, parseFieldError("SBAPRESETCRITICALGAPTURNONRED", err)
+		}
 	}
 
-	if len(values) > 15 && values[15] != "" {
-		turn.SBAUsePresetCriticalGapTurnOnRed, err = strconv.Atoi(values[15])
+	if value := columnValue(values, headers, "SBAUSEPRESETCRITICALGAPTURNONRED", 15); value != "" {
+		turn.SBAUsePresetCriticalGapTurnOnRed, err = strconv.Atoi(value)
 		if err != nil {
-			return Turn{}, fmt.Errorf("error parsing SBAUSEPRESETCRITICALGAPTURNONRED: %w", err)
+			return Turn{}, parseFieldError("SBAUSEPRESETCRITICALGAPTURNONRED", err)
 		}
 	}
 
-	if len(values) > 16 {
-		turn.SBAPresetFollowupGapTurnOnRed = values[16]
+	if value := columnValue(values, headers, "SBAPRESETFOLLOWUPGAPTURNONRED", 16); value != "" {
+		turn.SBAPresetFollowupGapTurnOnRed, err = parseDuration(value)
+		if err != nil {
+			return Turn{}, parseFieldError("SBAPRESETFOLLOWUPGAPTURNONRED", err)
+		}
 	}
 
-	if len(values) > 17 && values[17] != "" {
-		turn.SBAUsePresetFollowupGapTurnOnRed, err = strconv.Atoi(values[17])
+	if value := columnValue(values, headers, "SBAUSEPRESETFOLLOWUPGAPTURNONRED", 17); value != "" {
+		turn.SBAUsePresetFollowupGapTurnOnRed, err = strconv.Atoi(value)
 		if err != nil {
-			return Turn{}, fmt.Errorf("error parsing SBAUSEPRESETFOLLOWUPGAPTURNONRED: %w", err)
+			return Turn{}, parseFieldError("SBAUSEPRESETFOLLOWUPGAPTURNONRED", err)
 		}
 	}
 
 	// Parse ICA fields (if available)
-	if len(values) > 18 && values[18] != "" {
-		turn.ICAUsePresetSatFlowRate, err = strconv.Atoi(values[18])
+	if value := columnValue(values, headers, "ICAUSEPRESETSATFLOWRATE", 18); value != "" {
+		turn.ICAUsePresetSatFlowRate, err = strconv.Atoi(value)
 		if err != nil {
-			return Turn{}, fmt.Errorf("error parsing ICAUSEPRESETSATFLOWRATE: %w", err)
+			return Turn{}, parseFieldError("ICAUSEPRESETSATFLOWRATE", err)
 		}
 	}
 
-	if len(values) > 19 && values[19] != "" {
-		turn.ICAPresetSatFlowRate, err = strconv.ParseFloat(strings.Replace(values[19], ",", ".", -1), 64)
+	if value := columnValue(values, headers, "ICAPRESETSATFLOWRATE", 19); value != "" {
+		turn.ICAPresetSatFlowRate, err = strconv.ParseFloat(strings.Replace(value, ",", ".", -1), 64)
 		if err != nil {
-			return Turn{}, fmt.Errorf("error parsing ICAPRESETSATFLOWRATE: %w", err)
+			return Turn{}, parseFieldError("ICAPRESETSATFLOWRATE", err)
 		}
 	}
 
-	if len(values) > 20 && values[20] != "" {
-		turn.ICAUsePresetCriticalGap, err = strconv.Atoi(values[20])
+	if value := columnValue(values, headers, "ICAUSEPRESETCRITICALGAP", 20); value != "" {
+		turn.ICAUsePresetCriticalGap, err = strconv.Atoi(value)
 		if err != nil {
-			return Turn{}, fmt.Errorf("error parsing ICAUSEPRESETCRITICALGAP: %w", err)
+			return Turn{}, parseFieldError("ICAUSEPRESETCRITICALGAP", err)
 		}
 	}
 
-	if len(values) > 21 {
-		turn.ICAPresetCriticalGap = values[21]
-	}
-
-	if len(values) > 22 {
-		turn.ICAPresetCriticalGapStageOne = values[22]
+	if value := columnValue(values, headers, "ICAPRESETCRITICALGAP", 21); value != "" {
+		turn.ICAPresetCriticalGap, err = parseDuration(value)
+		if err != nil {
+			return Turn{}, parseFieldError("ICAPRESETCRITICALGAP", err)
+		}
 	}
-
-	if len(values) > 23 {
-		turn.ICAPresetCriticalGapStageTwo = values[23]
+	if value := columnValue(values, headers, "ICAPRESETCRITICALGAPSTAGEONE", 22); value != "" {
+		turn.ICAPresetCriticalGapStageOne, err = parseDuration(value)
+		if err != nil {
+			return Turn{}, parseFieldError("ICAPRESETCRITICALGAPSTAGEONE", err)
+		}
 	}
-
-	if len(values) > 24 && values[24] != "" {
-		turn.ICAUsePresetFollowupTime, err = strconv.Atoi(values[24])
+	if value := columnValue(values, headers, "ICAPRESETCRITICALGAPSTAGETWO", 23); value != "" {
+		turn.ICAPresetCriticalGapStageTwo, err = parseDuration(value)
 		if err != nil {
-			return Turn{}, fmt.Errorf("error parsing ICAUSEPRESETFOLLOWUPTIME: %w", err)
+			return Turn{}, parseFieldError("ICAPRESETCRITICALGAPSTAGETWO", err)
 		}
 	}
 
-	if len(values) > 25 {
-		turn.ICAPresetFollowupTime = values[25]
+	if value := columnValue(values, headers, "ICAUSEPRESETFOLLOWUPTIME", 24); value != "" {
+		turn.ICAUsePresetFollowupTime, err = strconv.Atoi(value)
+		if err != nil {
+			return Turn{}, parseFieldError("ICAUSEPRESETFOLLOWUPTIME", err)
+		}
 	}
 
-	if len(values) > 26 {
-		turn.ICATurningRadius = values[26]
+	if value := columnValue(values, headers, "ICAPRESETFOLLOWUPTIME", 25); value != "" {
+		turn.ICAPresetFollowupTime, err = parseDuration(value)
+		if err != nil {
+			return Turn{}, parseFieldError("ICAPRESETFOLLOWUPTIME", err)
+		}
 	}
+	turn.ICATurningRadius = columnValue(values, headers, "ICATURNINGRADIUS", 26)
 
 	// Parse additional ICA fields (if available)
-	if len(values) > 27 && values[27] != "" {
-		turn.ICAUsePresentSatFlowAdjustment, err = strconv.Atoi(values[27])
+	if value := columnValue(values, headers, "ICAUSEPRESETSATFLOWADJUSTMENT", 27); value != "" {
+		turn.ICAUsePresentSatFlowAdjustment, err = strconv.Atoi(value)
 		if err != nil {
-			return Turn{}, fmt.Errorf("error parsing ICAUSEPRESETSATFLOWADJUSTMENT: %w", err)
+			return Turn{}, parseFieldError("ICAUSEPRESETSATFLOWADJUSTMENT", err)
 		}
 	}
 
-	if len(values) > 28 && values[28] != "" {
-		turn.ICAPresetSatFlowAdjustment, err = strconv.ParseFloat(strings.Replace(values[28], ",", ".", -1), 64)
+	if value := columnValue(values, headers, "ICAPRESETSATFLOWADJUSTMENT", 28); value != "" {
+		turn.ICAPresetSatFlowAdjustment, err = strconv.ParseFloat(strings.Replace(value, ",", ".", -1), 64)
 		if err != nil {
-			return Turn{}, fmt.Errorf("error parsing ICAPRESETSATFLOWADJUSTMENT: %w", err)
+			return Turn{}, parseFieldError("ICAPRESETSATFLOWADJUSTMENT", err)
 		}
 	}
 
-	if len(values) > 29 && values[29] != "" {
-		turn.ICAProtectedInnerSatFlowAdjustment, err = strconv.ParseFloat(strings.Replace(values[29], ",", ".", -1), 64)
+	if value := columnValue(values, headers, "ICAPROTECTEDINNERSATFLOWADJUSTMENT", 29); value != "" {
+		turn.ICAProtectedInnerSatFlowAdjustment, err = strconv.ParseFloat(strings.Replace(value, ",", ".", -1), 64)
 		if err != nil {
-			return Turn{}, fmt.Errorf("error parsing ICAPROTECTEDINNERSATFLOWADJUSTMENT: %w", err)
+			return Turn{}, parseFieldError("ICAPROTECTEDINNERSATFLOWADJUSTMENT", err)
 		}
 	}
 
-	if len(values) > 30 && values[30] != "" {
-		turn.ICAUsePermissiveInnerSatFlowAdjustment, err = strconv.Atoi(values[30])
+	if value := columnValue(values, headers, "ICAUSEPERMISSIVEINNERSATFLOWADJUSTMENT", 30); value != "" {
+		turn.ICAUsePermissiveInnerSatFlowAdjustment, err = strconv.Atoi(value)
 		if err != nil {
-			return Turn{}, fmt.Errorf("error parsing ICAUSEPERMISSIVEINNERSATFLOWADJUSTMENT: %w", err)
+			return Turn{}, parseFieldError("ICAUSEPERMISSIVEINNERSATFLOWADJUSTMENT", err)
 		}
 	}
 
-	if len(values) > 31 && values[31] != "" {
-		turn.ICAPermissiveInnerSatFlowAdjustment, err = strconv.ParseFloat(strings.Replace(values[31], ",", ".", -1), 64)
+	if value := columnValue(values, headers, "ICAPERMISSIVEINNERSATFLOWADJUSTMENT", 31); value != "" {
+		turn.ICAPermissiveInnerSatFlowAdjustment, err = strconv.ParseFloat(strings.Replace(value, ",", ".", -1), 64)
 		if err != nil {
-			return Turn{}, fmt.Errorf("error parsing ICAPERMISSIVEINNERSATFLOWADJUSTMENT: %w", err)
+			return Turn{}, parseFieldError("ICAPERMISSIVEINNERSATFLOWADJUSTMENT", err)
 		}
 	}
 
-	if len(values) > 32 && values[32] != "" {
-		turn.ICAUsePedestrianSatFlowAdjustment, err = strconv.Atoi(values[32])
+	if value := columnValue(values, headers, "ICAUSEPEDESTRIANSATFLOWADJUSTMENT", 32); value != "" {
+		turn.ICAUsePedestrianSatFlowAdjustment, err = strconv.Atoi(value)
 		if err != nil {
-			return Turn{}, fmt.Errorf("error parsing ICAUSEPEDESTRIANSATFLOWADJUSTMENT: %w", err)
+			return Turn{}, parseFieldError("ICAUSEPEDESTRIANSATFLOWADJUSTMENT", err)
 		}
 	}
 
-	if len(values) > 33 && values[33] != "" {
-		turn.ICAPedestrianSatFlowAdjustment, err = strconv.ParseFloat(strings.Replace(values[33], ",", ".", -1), 64)
+	if value := columnValue(values, headers, "ICAPEDESTRIANSATFLOWADJUSTMENT", 33); value != "" {
+		turn.ICAPedestrianSatFlowAdjustment, err = strconv.ParseFloat(strings.Replace(value, ",", ".", -1), 64)
 		if err != nil {
-			return Turn{}, fmt.Errorf("error parsing ICAPEDESTRIANSATFLOWADJUSTMENT: %w", err)
+			return Turn{}, parseFieldError("ICAPEDESTRIANSATFLOWADJUSTMENT", err)
 		}
 	}
 
-	if len(values) > 34 && values[34] != "" {
-		turn.ICAUsePresetLaneWidthAdjustment, err = strconv.Atoi(values[34])
+	if value := columnValue(values, headers, "ICAUSEPRESETLANEWIDTHADJUSTMENT", 34); value != "" {
+		turn.ICAUsePresetLaneWidthAdjustment, err = strconv.Atoi(value)
 		if err != nil {
-			return Turn{}, fmt.Errorf("error parsing ICAUSEPRESETLANEWIDTHADJUSTMENT: %w", err)
+			return Turn{}, parseFieldError("ICAUSEPRESETLANEWIDTHADJUSTMENT", err)
 		}
 	}
 
-	if len(values) > 35 && values[35] != "" {
-		turn.ICAPresetLaneWidthAdjustment, err = strconv.ParseFloat(strings.Replace(values[35], ",", ".", -1), 64)
+	if value := columnValue(values, headers, "ICAPRESETLANEWIDTHADJUSTMENT", 35); value != "" {
+		turn.ICAPresetLaneWidthAdjustment, err = strconv.ParseFloat(strings.Replace(value, ",", ".", -1), 64)
 		if err != nil {
-			return Turn{}, fmt.Errorf("error parsing ICAPRESETLANEWIDTHADJUSTMENT: %w", err)
+			return Turn{}, parseFieldError("ICAPRESETLANEWIDTHADJUSTMENT", err)
 		}
 	}
 
-	if len(values) > 36 && values[36] != "" {
-		turn.ICAUsePresetGradeAdjustment, err = strconv.Atoi(values[36])
+	if value := columnValue(values, headers, "ICAUSEPRESETGRADEADJUSTMENT", 36); value != "" {
+		turn.ICAUsePresetGradeAdjustment, err = strconv.Atoi(value)
 		if err != nil {
-			return Turn{}, fmt.Errorf("error parsing ICAUSEPRESETGRADEADJUSTMENT: %w", err)
+			return Turn{}, parseFieldError("ICAUSEPRESETGRADEADJUSTMENT", err)
 		}
 	}
 
-	if len(values) > 37 && values[37] != "" {
-		turn.ICAPresetGradeAdjustment, err = strconv.ParseFloat(strings.Replace(values[37], ",", ".", -1), 64)
+	if value := columnValue(values, headers, "ICAPRESETGRADEADJUSTMENT", 37); value != "" {
+		turn.ICAPresetGradeAdjustment, err = strconv.ParseFloat(strings.Replace(value, ",", ".", -1), 64)
 		if err != nil {
-			return Turn{}, fmt.Errorf("error parsing ICAPRESETGRADEADJUSTMENT: %w", err)
+			return Turn{}, parseFieldError("ICAPRESETGRADEADJUSTMENT", err)
 		}
 	}
 
-	if len(values) > 38 && values[38] != "" {
-		turn.ICAUsePresetTurningRadiusAdjustment, err = strconv.Atoi(values[38])
+	if value := columnValue(values, headers, "ICAUSEPRESETTURNINGRADIUSADJUSTMENT", 38); value != "" {
+		turn.ICAUsePresetTurningRadiusAdjustment, err = strconv.Atoi(value)
 		if err != nil {
-			return Turn{}, fmt.Errorf("error parsing ICAUSEPRESETTURNINGRADIUSADJUSTMENT: %w", err)
+			return Turn{}, parseFieldError("ICAUSEPRESETTURNINGRADIUSADJUSTMENT", err)
 		}
 	}
 
-	if len(values) > 39 && values[39] != "" {
-		turn.ICAPresetTurningRadiusAdjustment, err = strconv.ParseFloat(strings.Replace(values[39], ",", ".", -1), 64)
+	if value := columnValue(values, headers, "ICAPRESETTURNINGRADIUSADJUSTMENT", 39); value != "" {
+		turn.ICAPresetTurningRadiusAdjustment, err = strconv.ParseFloat(strings.Replace(value, ",", ".", -1), 64)
 		if err != nil {
-			return Turn{}, fmt.Errorf("error parsing ICAPRESETTURNINGRADIUSADJUSTMENT: %w", err)
+			return Turn{}, parseFieldError("ICAPRESETTURNINGRADIUSADJUSTMENT", err)
 		}
 	}
 
-	if len(values) > 40 && values[40] != "" {
-		turn.ICAUpstreamAdj, err = strconv.ParseFloat(strings.Replace(values[40], ",", ".", -1), 64)
+	if value := columnValue(values, headers, "ICAUPSTREAMADJ", 40); value != "" {
+		turn.ICAUpstreamAdj, err = strconv.ParseFloat(strings.Replace(value, ",", ".", -1), 64)
 		if err != nil {
-			return Turn{}, fmt.Errorf("error parsing ICAUPSTREAMADJ: %w", err)
+			return Turn{}, parseFieldError("ICAUPSTREAMADJ", err)
 		}
 	}
 
-	if len(values) > 41 && values[41] != "" {
-		turn.ICAPHFVolAdj, err = strconv.ParseFloat(strings.Replace(values[41], ",", ".", -1), 64)
+	if value := columnValue(values, headers, "ICAPHFVOLADJ", 41); value != "" {
+		turn.ICAPHFVolAdj, err = strconv.ParseFloat(strings.Replace(value, ",", ".", -1), 64)
 		if err != nil {
-			return Turn{}, fmt.Errorf("error parsing ICAPHFVOLADJ: %w", err)
+			return Turn{}, parseFieldError("ICAPHFVOLADJ", err)
 		}
 	}
 
-	if len(values) > 42 {
-		turn.ICAUnsignalizedDelay = values[42]
+	if value := columnValue(values, headers, "ICAUNSIGNALIZEDDELAY", 42); value != "" {
+		turn.ICAUnsignalizedDelay, err = parseDuration(value)
+		if err != nil {
+			return Turn{}, parseFieldError("ICAUNSIGNALIZEDDELAY", err)
+		}
 	}
 
 	// Parse remaining fields
-	if len(values) > 43 {
-		turn.AuxiliarySG = values[43]
-	}
+	turn.AuxiliarySG = columnValue(values, headers, "AUXILIARYSG", 43)
 
-	if len(values) > 44 && values[44] != "" {
-		turn.IsChangeOfDirection, err = strconv.Atoi(values[44])
+	if value := columnValue(values, headers, "ISCHANGEOFDIRECTION", 44); value != "" {
+		turn.IsChangeOfDirection, err = strconv.Atoi(value)
 		if err != nil {
-			return Turn{}, fmt.Errorf("error parsing ISCHANGEOFDIRECTION: %w", err)
+			return Turn{}, parseFieldError("ISCHANGEOFDIRECTION", err)
 		}
 	}
 
 	// Parse VISTRO fields
-	if len(values) > 45 && values[45] != "" {
-		turn.VISTROBaseVolInput, err = strconv.Atoi(values[45])
+	if value := columnValue(values, headers, "VISTROBASEVOLINPUT", 45); value != "" {
+		turn.VISTROBaseVolInput, err = strconv.Atoi(value)
 		if err != nil {
-			return Turn{}, fmt.Errorf("error parsing VISTROBASEVOLINPUT: %w", err)
+			return Turn{}, parseFieldError("VISTROBASEVOLINPUT", err)
 		}
 	}
 
-	if len(values) > 46 && values[46] != "" {
-		turn.VISTROBaseVolAdjustFactor, err = strconv.ParseFloat(strings.Replace(values[46], ",", ".", -1), 64)
+	if value := columnValue(values, headers, "VISTROBASEVOLADJUSTFACTOR", 46); value != "" {
+		turn.VISTROBaseVolAdjustFactor, err = strconv.ParseFloat(strings.Replace(value, ",", ".", -1), 64)
 		if err != nil {
-			return Turn{}, fmt.Errorf("error parsing VISTROBASEVOLADJUSTFACTOR: %w", err)
+			return Turn{}, parseFieldError("VISTROBASEVOLADJUSTFACTOR", err)
 		}
 	}
 
-	if len(values) > 47 && values[47] != "" {
-		turn.ShareHGV, err = strconv.ParseFloat(strings.Replace(values[47], ",", ".", -1), 64)
+	if value := columnValue(values, headers, "SHAREHGV", 47); value != "" {
+		turn.ShareHGV, err = strconv.ParseFloat(strings.Replace(value, ",", ".", -1), 64)
 		if err != nil {
-			return Turn{}, fmt.Errorf("error parsing SHAREHGV: %w", err)
+			return Turn{}, parseFieldError("SHAREHGV", err)
 		}
 	}
 
-	if len(values) > 48 && values[48] != "" {
-		turn.VISTROGrowthFactor, err = strconv.ParseFloat(strings.Replace(values[48], ",", ".", -1), 64)
+	if value := columnValue(values, headers, "VISTROGROWTHFACTOR", 48); value != "" {
+		turn.VISTROGrowthFactor, err = strconv.ParseFloat(strings.Replace(value, ",", ".", -1), 64)
 		if err != nil {
-			return Turn{}, fmt.Errorf("error parsing VISTROGROWTHFACTOR: %w", err)
+			return Turn{}, parseFieldError("VISTROGROWTHFACTOR", err)
 		}
 	}
 
-	if len(values) > 49 && values[49] != "" {
-		turn.VISTROInProcessVol, err = strconv.Atoi(values[49])
+	if value := columnValue(values, headers, "VISTROINPROCESSVOL", 49); value != "" {
+		turn.VISTROInProcessVol, err = strconv.Atoi(value)
 		if err != nil {
-			return Turn{}, fmt.Errorf("error parsing VISTROINPROCESSVOL: %w", err)
+			return Turn{}, parseFieldError("VISTROINPROCESSVOL", err)
 		}
 	}
 
-	if len(values) > 50 && values[50] != "" {
-		turn.VISTRODivTrips, err = strconv.Atoi(values[50])
+	if value := columnValue(values, headers, "VISTRODIVTRIPS", 50); value != "" {
+		turn.VISTRODivTrips, err = strconv.Atoi(value)
 		if err != nil {
-			return Turn{}, fmt.Errorf("error parsing VISTRODIVTRIPS: %w", err)
+			return Turn{}, parseFieldError("VISTRODIVTRIPS", err)
 		}
 	}
 
-	if len(values) > 51 && values[51] != "" {
-		turn.VISTROPassByTrips, err = strconv.Atoi(values[51])
+	if value := columnValue(values, headers, "VISTROPASSBYTRIPS", 51); value != "" {
+		turn.VISTROPassByTrips, err = strconv.Atoi(value)
 		if err != nil {
-			return Turn{}, fmt.Errorf("error parsing VISTROPASSBYTRIPS: %w", err)
+			return Turn{}, parseFieldError("VISTROPASSBYTRIPS", err)
 		}
 	}
 
-	if len(values) > 52 && values[52] != "" {
-		turn.VISTROSiteAdjustVol, err = strconv.Atoi(values[52])
+	if value := columnValue(values, headers, "VISTROSITEADJUSTVOL", 52); value != "" {
+		turn.VISTROSiteAdjustVol, err = strconv.Atoi(value)
 		if err != nil {
-			return Turn{}, fmt.Errorf("error parsing VISTROSITEADJUSTVOL: %w", err)
+			return Turn{}, parseFieldError("VISTROSITEADJUSTVOL", err)
 		}
 	}
 
-	if len(values) > 53 && values[53] != "" {
-		turn.VISTROOtherVol, err = strconv.Atoi(values[53])
+	if value := columnValue(values, headers, "VISTROOTHERVOL", 53); value != "" {
+		turn.VISTROOtherVol, err = strconv.Atoi(value)
 		if err != nil {
-			return Turn{}, fmt.Errorf("error parsing VISTROOTHERVOL: %w", err)
+			return Turn{}, parseFieldError("VISTROOTHERVOL", err)
 		}
 	}
 
-	if len(values) > 54 && values[54] != "" {
-		turn.VISTRORightTurnOnRedVol, err = strconv.Atoi(values[54])
+	if value := columnValue(values, headers, "VISTRORIGHTTURNONREDVOL", 54); value != "" {
+		turn.VISTRORightTurnOnRedVol, err = strconv.Atoi(value)
 		if err != nil {
-			return Turn{}, fmt.Errorf("error parsing VISTRORIGHTTURNONREDVOL: %w", err)
+			return Turn{}, parseFieldError("VISTRORIGHTTURNONREDVOL", err)
 		}
 	}
 
-	if len(values) > 55 && values[55] != "" {
-		turn.VISTROTurnOnRedPercentage, err = strconv.ParseFloat(strings.Replace(values[55], ",", ".", -1), 64)
+	if value := columnValue(values, headers, "VISTROTURNONREDPERCENTAGE", 55); value != "" {
+		turn.VISTROTurnOnRedPercentage, err = strconv.ParseFloat(strings.Replace(value, ",", ".", -1), 64)
 		if err != nil {
-			return Turn{}, fmt.Errorf("error parsing VISTROTURNONREDPERCENTAGE: %w", err)
+			return Turn{}, parseFieldError("VISTROTURNONREDPERCENTAGE", err)
 		}
 	}
 
-	if len(values) > 56 {
-		turn.VISTROTurnOnRedVolumeCalculationMethod = values[56]
-	}
+	turn.VISTROTurnOnRedVolumeCalculationMethod = columnValue(values, headers, "VISTROTURNONREDVOLUMECALCULATIONMETHOD", 56)
 
-	if len(values) > 57 && values[57] != "" {
-		turn.VISTROLRORderNo, err = strconv.Atoi(values[57])
+	if value := columnValue(values, headers, "VISTROLRORDERNO", 57); value != "" {
+		turn.VISTROLRORderNo, err = strconv.Atoi(value)
 		if err != nil {
-			return Turn{}, fmt.Errorf("error parsing VISTROLRORDERNO: %w", err)
+			return Turn{}, parseFieldError("VISTROLRORDERNO", err)
 		}
 	}
 
-	if len(values) > 58 && values[58] != "" {
-		turn.VISTROOtherAdjustFactor, err = strconv.ParseFloat(strings.Replace(values[58], ",", ".", -1), 64)
+	if value := columnValue(values, headers, "VISTROOTHERADJUSTFACTOR", 58); value != "" {
+		turn.VISTROOtherAdjustFactor, err = strconv.ParseFloat(strings.Replace(value, ",", ".", -1), 64)
 		if err != nil {
-			return Turn{}, fmt.Errorf("error parsing VISTROOTHERADJUSTFACTOR: %w", err)
+			return Turn{}, parseFieldError("VISTROOTHERADJUSTFACTOR", err)
 		}
 	}
 
-	if len(values) > 59 {
-		turn.VISTROLaneWidth = values[59]
-	}
+	turn.VISTROLaneWidth = columnValue(values, headers, "VISTROLANEWIDTH", 59)
 
-	if len(values) > 60 && values[60] != "" {
-		turn.UseVISTROLaneWidth, err = strconv.Atoi(values[60])
+	if value := columnValue(values, headers, "USEVISTROLANEWIDTH", 60); value != "" {
+		turn.UseVISTROLaneWidth, err = strconv.Atoi(value)
 		if err != nil {
-			return Turn{}, fmt.Errorf("error parsing USEVISTROLANEWIDTH: %w", err)
+			return Turn{}, parseFieldError("USEVISTROLANEWIDTH", err)
 		}
 	}
 
-	if len(values) > 61 {
-		turn.VISTROOuterControl = values[61]
-	}
-
-	if len(values) > 62 {
-		turn.VISTROThruControl = values[62]
-	}
-
-	if len(values) > 63 {
-		turn.VISTROInnerControl = values[63]
-	}
+	turn.VISTROOuterControl = columnValue(values, headers, "VISTROOUTERCONTROL", 61)
+	turn.VISTROThruControl = columnValue(values, headers, "VISTROTHRUCONTROL", 62)
+	turn.VISTROInnerControl = columnValue(values, headers, "VISTROINNERCONTROL", 63)
 
-	if len(values) > 64 && values[64] != "" {
-		turn.VISTROSGNo, err = strconv.Atoi(values[64])
+	if value := columnValue(values, headers, "VISTROSGNO", 64); value != "" {
+		turn.VISTROSGNo, err = strconv.Atoi(value)
 		if err != nil {
-			return Turn{}, fmt.Errorf("error parsing VISTROSGNO: %w", err)
+			return Turn{}, parseFieldError("VISTROSGNO", err)
 		}
 	}
 
-	if len(values) > 65 && values[65] != "" {
-		turn.VISTROOVLNo, err = strconv.Atoi(values[65])
+	if value := columnValue(values, headers, "VISTROOVLNO", 65); value != "" {
+		turn.VISTROOVLNo, err = strconv.Atoi(value)
 		if err != nil {
-			return Turn{}, fmt.Errorf("error parsing VISTROOVLNO: %w", err)
+			return Turn{}, parseFieldError("VISTROOVLNO", err)
 		}
 	}
 
 	return turn, nil
 }
+
+// turnColumns are the columns written when the section has no headers
+var turnColumns = []string{
+	"FROMNODENO", "VIANODENO", "TONODENO", "TYPENO", "TSYSSET", "CAPPRT", "T0PRT", "ADDVAL1", "ADDVAL2",
+	"ADDVAL3", "SBAPRESETCRITICALGAP", "SBAUSEPRESETCRITICALGAP", "SBAPRESETFOLLOWUPGAP",
+	"SBAUSEPRESETFOLLOWUPGAP", "SBAPRESETCRITICALGAPTURNONRED", "SBAUSEPRESETCRITICALGAPTURNONRED",
+	"SBAPRESETFOLLOWUPGAPTURNONRED", "SBAUSEPRESETFOLLOWUPGAPTURNONRED", "ICAUSEPRESETSATFLOWRATE",
+	"ICAPRESETSATFLOWRATE", "ICAUSEPRESETCRITICALGAP", "ICAPRESETCRITICALGAP", "ICAPRESETCRITICALGAPSTAGEONE",
+	"ICAPRESETCRITICALGAPSTAGETWO", "ICAUSEPRESETFOLLOWUPTIME", "ICAPRESETFOLLOWUPTIME", "ICATURNINGRADIUS",
+	"ICAUSEPRESETSATFLOWADJUSTMENT", "ICAPRESETSATFLOWADJUSTMENT", "ICAPROTECTEDINNERSATFLOWADJUSTMENT",
+	"ICAUSEPERMISSIVEINNERSATFLOWADJUSTMENT", "ICAPERMISSIVEINNERSATFLOWADJUSTMENT",
+	"ICAUSEPEDESTRIANSATFLOWADJUSTMENT", "ICAPEDESTRIANSATFLOWADJUSTMENT", "ICAUSEPRESETLANEWIDTHADJUSTMENT",
+	"ICAPRESETLANEWIDTHADJUSTMENT", "ICAUSEPRESETGRADEADJUSTMENT", "ICAPRESETGRADEADJUSTMENT",
+	"ICAUSEPRESETTURNINGRADIUSADJUSTMENT", "ICAPRESETTURNINGRADIUSADJUSTMENT", "ICAUPSTREAMADJ", "ICAPHFVOLADJ",
+	"ICAUNSIGNALIZEDDELAY", "AUXILIARYSG", "ISCHANGEOFDIRECTION", "VISTROBASEVOLINPUT",
+	"VISTROBASEVOLADJUSTFACTOR", "SHAREHGV", "VISTROGROWTHFACTOR", "VISTROINPROCESSVOL", "VISTRODIVTRIPS",
+	"VISTROPASSBYTRIPS", "VISTROSITEADJUSTVOL", "VISTROOTHERVOL", "VISTRORIGHTTURNONREDVOL",
+	"VISTROTURNONREDPERCENTAGE", "VISTROTURNONREDVOLUMECALCULATIONMETHOD", "VISTROLRORDERNO",
+	"VISTROOTHERADJUSTFACTOR", "VISTROLANEWIDTH", "USEVISTROLANEWIDTH", "VISTROOUTERCONTROL", "VISTROTHRUCONTROL",
+	"VISTROINNERCONTROL", "VISTROSGNO", "VISTROOVLNO",
+}
+
+func (turn Turn) attribute(column string) (string, bool) {
+	switch column {
+	case "FROMNODENO":
+		return formatInt(turn.FromNodeNo), true
+	case "VIANODENO":
+		return formatInt(turn.ViaNodeNo), true
+	case "TONODENO":
+		return formatInt(turn.ToNodeNo), true
+	case "TYPENO":
+		return formatInt(turn.TypeNo), true
+	case "TSYSSET":
+		return turn.TSysSet, true
+	case "CAPPRT":
+		return formatInt(turn.CapPRT), true
+	case "T0PRT":
+		return turn.T0PRT.String(), true
+	case "ADDVAL1":
+		return formatInt(turn.AddVal[0]), true
+	case "ADDVAL2":
+		return formatInt(turn.AddVal[1]), true
+	case "ADDVAL3":
+		return formatInt(turn.AddVal[2]), true
+	case "SBAPRESETCRITICALGAP":
+		return turn.SBAPresetCriticalGap.String(), true
+	case "SBAUSEPRESETCRITICALGAP":
+		return formatInt(turn.SBAUsePresetCriticalGap), true
+	case "SBAPRESETFOLLOWUPGAP":
+		return turn.SBAPresetFollowupGap.String(), true
+	case "SBAUSEPRESETFOLLOWUPGAP":
+		return formatInt(turn.SBAUsePresetFollowupGap), true
+	case "SBAPRESETCRITICALGAPTURNONRED":
+		return turn.SBAPresetCriticalGapTurnOnRed.String(), true
+	case "SBAUSEPRESETCRITICALGAPTURNONRED":
+		return formatInt(turn.SBAUsePresetCriticalGapTurnOnRed), true
+	case "SBAPRESETFOLLOWUPGAPTURNONRED":
+		return turn.SBAPresetFollowupGapTurnOnRed.String(), true
+	case "SBAUSEPRESETFOLLOWUPGAPTURNONRED":
+		return formatInt(turn.SBAUsePresetFollowupGapTurnOnRed), true
+	case "ICAUSEPRESETSATFLOWRATE":
+		return formatInt(turn.ICAUsePresetSatFlowRate), true
+	case "ICAPRESETSATFLOWRATE":
+		return formatFloat(turn.ICAPresetSatFlowRate), true
+	case "ICAUSEPRESETCRITICALGAP":
+		return formatInt(turn.ICAUsePresetCriticalGap), true
+	case "ICAPRESETCRITICALGAP":
+		return turn.ICAPresetCriticalGap.String(), true
+	case "ICAPRESETCRITICALGAPSTAGEONE":
+		return turn.ICAPresetCriticalGapStageOne.String(), true
+	case "ICAPRESETCRITICALGAPSTAGETWO":
+		return turn.ICAPresetCriticalGapStageTwo.String(), true
+	case "ICAUSEPRESETFOLLOWUPTIME":
+		return formatInt(turn.ICAUsePresetFollowupTime), true
+	case "ICAPRESETFOLLOWUPTIME":
+		return turn.ICAPresetFollowupTime.String(), true
+	case "ICATURNINGRADIUS":
+		return turn.ICATurningRadius, true
+	case "ICAUSEPRESETSATFLOWADJUSTMENT":
+		return formatInt(turn.ICAUsePresentSatFlowAdjustment), true
+	case "ICAPRESETSATFLOWADJUSTMENT":
+		return formatFloat(turn.ICAPresetSatFlowAdjustment), true
+	case "ICAPROTECTEDINNERSATFLOWADJUSTMENT":
+		return formatFloat(turn.ICAProtectedInnerSatFlowAdjustment), true
+	case "ICAUSEPERMISSIVEINNERSATFLOWADJUSTMENT":
+		return formatInt(turn.ICAUsePermissiveInnerSatFlowAdjustment), true
+	case "ICAPERMISSIVEINNERSATFLOWADJUSTMENT":
+		return formatFloat(turn.ICAPermissiveInnerSatFlowAdjustment), true
+	case "ICAUSEPEDESTRIANSATFLOWADJUSTMENT":
+		return formatInt(turn.ICAUsePedestrianSatFlowAdjustment), true
+	case "ICAPEDESTRIANSATFLOWADJUSTMENT":
+		return formatFloat(turn.ICAPedestrianSatFlowAdjustment), true
+	case "ICAUSEPRESETLANEWIDTHADJUSTMENT":
+		return formatInt(turn.ICAUsePresetLaneWidthAdjustment), true
+	case "ICAPRESETLANEWIDTHADJUSTMENT":
+		return formatFloat(turn.ICAPresetLaneWidthAdjustment), true
+	case "ICAUSEPRESETGRADEADJUSTMENT":
+		return formatInt(turn.ICAUsePresetGradeAdjustment), true
+	case "ICAPRESETGRADEADJUSTMENT":
+		return formatFloat(turn.ICAPresetGradeAdjustment), true
+	case "ICAUSEPRESETTURNINGRADIUSADJUSTMENT":
+		return formatInt(turn.ICAUsePresetTurningRadiusAdjustment), true
+	case "ICAPRESETTURNINGRADIUSADJUSTMENT":
+		return formatFloat(turn.ICAPresetTurningRadiusAdjustment), true
+	case "ICAUPSTREAMADJ":
+		return formatFloat(turn.ICAUpstreamAdj), true
+	case "ICAPHFVOLADJ":
+		return formatFloat(turn.ICAPHFVolAdj), true
+	case "ICAUNSIGNALIZEDDELAY":
+		return turn.ICAUnsignalizedDelay.String(), true
+	case "AUXILIARYSG":
+		return turn.AuxiliarySG, true
+	case "ISCHANGEOFDIRECTION":
+		return formatInt(turn.IsChangeOfDirection), true
+	case "VISTROBASEVOLINPUT":
+		return formatInt(turn.VISTROBaseVolInput), true
+	case "VISTROBASEVOLADJUSTFACTOR":
+		return formatFloat(turn.VISTROBaseVolAdjustFactor), true
+	case "SHAREHGV":
+		return formatFloat(turn.ShareHGV), true
+	case "VISTROGROWTHFACTOR":
+		return formatFloat(turn.VISTROGrowthFactor), true
+	case "VISTROINPROCESSVOL":
+		return formatInt(turn.VISTROInProcessVol), true
+	case "VISTRODIVTRIPS":
+		return formatInt(turn.VISTRODivTrips), true
+	case "VISTROPASSBYTRIPS":
+		return formatInt(turn.VISTROPassByTrips), true
+	case "VISTROSITEADJUSTVOL":
+		return formatInt(turn.VISTROSiteAdjustVol), true
+	case "VISTROOTHERVOL":
+		return formatInt(turn.VISTROOtherVol), true
+	case "VISTRORIGHTTURNONREDVOL":
+		return formatInt(turn.VISTRORightTurnOnRedVol), true
+	case "VISTROTURNONREDPERCENTAGE":
+		return formatFloat(turn.VISTROTurnOnRedPercentage), true
+	case "VISTROTURNONREDVOLUMECALCULATIONMETHOD":
+		return turn.VISTROTurnOnRedVolumeCalculationMethod, true
+	case "VISTROLRORDERNO":
+		return formatInt(turn.VISTROLRORderNo), true
+	case "VISTROOTHERADJUSTFACTOR":
+		return formatFloat(turn.VISTROOtherAdjustFactor), true
+	case "VISTROLANEWIDTH":
+		return turn.VISTROLaneWidth, true
+	case "USEVISTROLANEWIDTH":
+		return formatInt(turn.UseVISTROLaneWidth), true
+	case "VISTROOUTERCONTROL":
+		return turn.VISTROOuterControl, true
+	case "VISTROTHRUCONTROL":
+		return turn.VISTROThruControl, true
+	case "VISTROINNERCONTROL":
+		return turn.VISTROInnerControl, true
+	case "VISTROSGNO":
+		return formatInt(turn.VISTROSGNo), true
+	case "VISTROOVLNO":
+		return formatInt(turn.VISTROOVLNo), true
+	}
+	return "", false
+}
@@ -1,7 +1,5 @@
 package ptvvisum
 
-import "fmt"
-
 // UserAttDefSection represents $USERATTDEF section
 type UserAttDefSection struct {
 	BaseSection
@@ -33,67 +31,90 @@ type UserAttDef struct {
 }
 
 // getUserAttDef extracts data from USERATTDEF section row
-func getUserAttDef(values []string) (UserAttDef, error) {
-	// Check if we have at least the required fields
-	if len(values) < 5 {
-		return UserAttDef{}, fmt.Errorf("invalid USERATTDEF data: %v", values)
-	}
-
-	// Create attribute with initial required fields
+func getUserAttDef(values []string, headers []string) (UserAttDef, error) {
 	attr := UserAttDef{
-		ObjID:     values[0],
-		AttID:     values[1],
-		Code:      values[2],
-		Name:      values[3],
-		ValueType: values[4],
+		ObjID:              columnValue(values, headers, "OBJID", 0),
+		AttID:              columnValue(values, headers, "ATTID", 1),
+		Code:               columnValue(values, headers, "CODE", 2),
+		Name:               columnValue(values, headers, "NAME", 3),
+		ValueType:          columnValue(values, headers, "VALUETYPE", 4),
+		MinValue:           columnValue(values, headers, "MINVALUE", 5),
+		MaxValue:           columnValue(values, headers, "MAXVALUE", 6),
+		DefaultValue:       columnValue(values, headers, "DEFAULTVALUE", 7),
+		DefaultStringValue: columnValue(values, headers, "DEFAULTSTRINGVALUE", 8),
+		Comment:            columnValue(values, headers, "COMMENT", 9),
+		MaxStringLength:    columnValue(values, headers, "MAXSTRINGLENGTH", 10),
+		NumDecPlaces:       columnValue(values, headers, "NUMDECPLACES", 11),
+		DataSourceType:     columnValue(values, headers, "DATASOURCETYPE", 12),
+		Formula:            columnValue(values, headers, "FORMULA", 13),
+		ScaledByLength:     columnValue(values, headers, "SCALEDBYLENGTH", 14),
+		CrossSectionLogic:  columnValue(values, headers, "CROSSSECTIONLOGIC", 15),
+		CSLIgnoreClosed:    columnValue(values, headers, "CSLIGNORECLOSED", 16),
+		SubAttrs:           columnValue(values, headers, "SUBATTRS", 17),
+		CanBeEmpty:         columnValue(values, headers, "CANBEEMPTY", 18),
+		OperationReference: columnValue(values, headers, "OPERATIONREFERENCE", 19),
 	}
 
-	// Add optional fields if available
-	if len(values) > 5 {
-		attr.MinValue = values[5]
-	}
-	if len(values) > 6 {
-		attr.MaxValue = values[6]
-	}
-	if len(values) > 7 {
-		attr.DefaultValue = values[7]
-	}
-	if len(values) > 8 {
-		attr.DefaultStringValue = values[8]
-	}
-	if len(values) > 9 {
-		attr.Comment = values[9]
-	}
-	if len(values) > 10 {
-		attr.MaxStringLength = values[10]
-	}
-	if len(values) > 11 {
-		attr.NumDecPlaces = values[11]
-	}
-	if len(values) > 12 {
-		attr.DataSourceType = values[12]
+	// Attribute is identified by object type and attribute ID
+	if attr.ObjID == "" {
+		return UserAttDef{}, missingFieldError("OBJID")
 	}
-	if len(values) > 13 {
-		attr.Formula = values[13]
-	}
-	if len(values) > 14 {
-		attr.ScaledByLength = values[14]
-	}
-	if len(values) > 15 {
-		attr.CrossSectionLogic = values[15]
-	}
-	if len(values) > 16 {
-		attr.CSLIgnoreClosed = values[16]
-	}
-	if len(values) > 17 {
-		attr.SubAttrs = values[17]
-	}
-	if len(values) > 18 {
-		attr.CanBeEmpty = values[18]
-	}
-	if len(values) > 19 {
-		attr.OperationReference = values[19]
+	if attr.AttID == "" {
+		return UserAttDef{}, missingFieldError("ATTID")
 	}
 
 	return attr, nil
 }
+
+// userAttDefColumns are the columns written when the section has no headers
+var userAttDefColumns = []string{
+	"OBJID", "ATTID", "CODE", "NAME", "VALUETYPE", "MINVALUE", "MAXVALUE", "DEFAULTVALUE",
+	"DEFAULTSTRINGVALUE", "COMMENT", "MAXSTRINGLENGTH", "NUMDECPLACES", "DATASOURCETYPE", "FORMULA",
+	"SCALEDBYLENGTH", "CROSSSECTIONLOGIC", "CSLIGNORECLOSED", "SUBATTRS", "CANBEEMPTY", "OPERATIONREFERENCE",
+}
+
+func (a UserAttDef) attribute(column string) (string, bool) {
+	switch column {
+	case "OBJID":
+		return a.ObjID, true
+	case "ATTID":
+		return a.AttID, true
+	case "CODE":
+		return a.Code, true
+	case "NAME":
+		return a.Name, true
+	case "VALUETYPE":
+		return a.ValueType, true
+	case "MINVALUE":
+		return a.MinValue, true
+	case "MAXVALUE":
+		return a.MaxValue, true
+	case "DEFAULTVALUE":
+		return a.DefaultValue, true
+	case "DEFAULTSTRINGVALUE":
+		return a.DefaultStringValue, true
+	case "COMMENT":
+		return a.Comment, true
+	case "MAXSTRINGLENGTH":
+		return a.MaxStringLength, true
+	case "NUMDECPLACES":
+		return a.NumDecPlaces, true
+	case "DATASOURCETYPE":
+		return a.DataSourceType, true
+	case "FORMULA":
+		return a.Formula, true
+	case "SCALEDBYLENGTH":
+		return a.ScaledByLength, true
+	case "CROSSSECTIONLOGIC":
+		return a.CrossSectionLogic, true
+	case "CSLIGNORECLOSED":
+		return a.CSLIgnoreClosed, true
+	case "SUBATTRS":
+		return a.SubAttrs, true
+	case "CANBEEMPTY":
+		return a.CanBeEmpty, true
+	case "OPERATIONREFERENCE":
+		return a.OperationReference, true
+	}
+	return "", false
+}
@@ -1,14 +1,18 @@
 package ptvvisum
 
 import (
-	"fmt"
+	"errors"
 	"strconv"
+	"strings"
+	"time"
 )
 
 // ValidDaysSection represents $VALIDDAYS section
 type ValidDaysSection struct {
 	BaseSection
 	Days []ValidDay
+
+	byID index[ValidDay, int]
 }
 
 // ValidDay represents a single valid day entry
@@ -16,50 +20,108 @@ type ValidDay struct {
 	No            int
 	Code          string
 	Name          string
-	DayVector     int
+	DayVector     int    // DAYVECTOR as a number, 0 when it is too long to fit
+	DayVectorText string // DAYVECTOR as written, one character per day of the calendar period, "1" when the day is valid
 	PrfacHourCost float64
 	PrfacSupply   float64
 }
 
-// getValidDay extracts data from VALIDDAYS section row
-func getValidDay(values []string) (ValidDay, error) {
-	if len(values) < 6 {
-		return ValidDay{}, fmt.Errorf("invalid VALIDDAYS data: %v", values)
+// GetValidDayByID retrieves valid days by their number
+func (s *ValidDaysSection) GetValidDayByID(no int) (ValidDay, bool) {
+	return s.byID.findFirst(s.Days, no, func(day ValidDay) int { return day.No })
+}
+
+// IsValidOn reports whether the valid days include a calendar day of the calendar period
+func (s *ValidDaysSection) IsValidOn(no int, day time.Time, data *PTVData) bool {
+	validDay, found := s.GetValidDayByID(no)
+	if !found {
+		return false
+	}
+	// A network without calendar period has a single reference day
+	dayIndex := 0
+	if data.CalendarPeriod != nil {
+		if dayIndex, found = data.CalendarPeriod.GetDayIndex(day); !found {
+			return false
+		}
 	}
+	return dayIndex < len(validDay.DayVectorText) && validDay.DayVectorText[dayIndex] == '1'
+}
+
+// Reindex drops the lookup index after numbers of valid days have been changed in place, it is built again on next use
+func (s *ValidDaysSection) Reindex() {
+	s.byID.reset()
+}
 
+// getValidDay extracts data from VALIDDAYS section row
+func getValidDay(values []string, headers []string) (ValidDay, error) {
 	// Parse No (required field)
-	no, err := strconv.Atoi(values[0])
+	value := columnValue(values, headers, "NO", 0)
+	if value == "" {
+		return ValidDay{}, missingFieldError("NO")
+	}
+	no, err := strconv.Atoi(value)
 	if err != nil {
-		return ValidDay{}, fmt.Errorf("error parsing No: %w", err)
+		return ValidDay{}, parseFieldError("NO", err)
 	}
 
 	// Initialize with string values
 	day := ValidDay{
 		No:   no,
-		Code: values[1],
-		Name: values[2],
+		Code: columnValue(values, headers, "CODE", 1),
+		Name: columnValue(values, headers, "NAME", 2),
 	}
 
-	// Parse DayVector (required field)
-	dayVector, err := strconv.Atoi(values[3])
-	if err != nil {
-		return ValidDay{}, fmt.Errorf("error parsing DayVector: %w", err)
+	// The text keeps leading zeros, which are significant, and yearly vectors hundreds of days long.
+	// The number is 0 for the latter
+	day.DayVectorText = columnValue(values, headers, "DAYVECTOR", 3)
+	if day.DayVectorText != "" {
+		day.DayVector, err = strconv.Atoi(day.DayVectorText)
+		if errors.Is(err, strconv.ErrRange) {
+			day.DayVector = 0
+		} else if err != nil {
+			return ValidDay{}, parseFieldError("DAYVECTOR", err)
+		}
 	}
-	day.DayVector = dayVector
 
-	// Parse PrfacHourCost (required field)
-	prfacHourCost, err := strconv.ParseFloat(values[4], 64)
-	if err != nil {
-		return ValidDay{}, fmt.Errorf("error parsing PrfacHourCost: %w", err)
+	// Parse PrfacHourCost (optional, 0 if missing)
+	if value := columnValue(values, headers, "PRFACHOURCOST", 4); value != "" {
+		day.PrfacHourCost, err = strconv.ParseFloat(strings.Replace(value, ",", ".", -1), 64)
+		if err != nil {
+			return ValidDay{}, parseFieldError("PRFACHOURCOST", err)
+		}
 	}
-	day.PrfacHourCost = prfacHourCost
 
-	// Parse PrfacSupply (required field)
-	prfacSupply, err := strconv.ParseFloat(values[5], 64)
-	if err != nil {
-		return ValidDay{}, fmt.Errorf("error parsing PrfacSupply: %w", err)
+	// Parse PrfacSupply (optional, 0 if missing)
+	if value := columnValue(values, headers, "PRFACSUPPLY", 5); value != "" {
+		day.PrfacSupply, err = strconv.ParseFloat(strings.Replace(value, ",", ".", -1), 64)
+		if err != nil {
+			return ValidDay{}, parseFieldError("PRFACSUPPLY", err)
+		}
 	}
-	day.PrfacSupply = prfacSupply
 
 	return day, nil
 }
+
+// validDayColumns are the columns written when the section has no headers
+var validDayColumns = []string{"NO", "CODE", "NAME", "DAYVECTOR", "PRFACHOURCOST", "PRFACSUPPLY"}
+
+func (d ValidDay) attribute(column string) (string, bool) {
+	switch column {
+	case "NO":
+		return formatInt(d.No), true
+	case "CODE":
+		return d.Code, true
+	case "NAME":
+		return d.Name, true
+	case "DAYVECTOR":
+		if d.DayVectorText == "" && d.DayVector != 0 {
+			return formatInt(d.DayVector), true
+		}
+		return d.DayVectorText, true
+	case "PRFACHOURCOST":
+		return formatFloat(d.PrfacHourCost), true
+	case "PRFACSUPPLY":
+		return formatFloat(d.PrfacSupply), true
+	}
+	return "", false
+}
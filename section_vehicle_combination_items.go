@@ -1,9 +1,6 @@
 package ptvvisum
 
-import (
-	"fmt"
-	"strconv"
-)
+import "strconv"
 
 // VehUnitToVehCombSection represents $VEHUNITTOVEHCOMB section
 type VehUnitToVehCombSection struct {
@@ -19,40 +16,54 @@ type VehUnitToVehCombMapping struct {
 }
 
 // getVehUnitToVehCombMapping extracts data from VEHUNITTOVEHCOMB section row
-func getVehUnitToVehCombMapping(values []string) (VehUnitToVehCombMapping, error) {
-	if len(values) < 3 {
-		return VehUnitToVehCombMapping{}, fmt.Errorf("invalid VEHUNITTOVEHCOMB data: %v", values)
-	}
-
+func getVehUnitToVehCombMapping(values []string, headers []string) (VehUnitToVehCombMapping, error) {
 	var mapping VehUnitToVehCombMapping
 	var err error
 
 	// Parse VehCombNo (required field)
-	if values[0] == "" {
-		return VehUnitToVehCombMapping{}, fmt.Errorf("missing required field VEHCOMBNO")
+	value := columnValue(values, headers, "VEHCOMBNO", 0)
+	if value == "" {
+		return VehUnitToVehCombMapping{}, missingFieldError("VEHCOMBNO")
 	}
-	mapping.VehCombNo, err = strconv.Atoi(values[0])
+	mapping.VehCombNo, err = strconv.Atoi(value)
 	if err != nil {
-		return VehUnitToVehCombMapping{}, fmt.Errorf("error parsing VehCombNo: %w", err)
+		return VehUnitToVehCombMapping{}, parseFieldError("VEHCOMBNO", err)
 	}
 
 	// Parse VehUnitNo (required field)
-	if values[1] == "" {
-		return VehUnitToVehCombMapping{}, fmt.Errorf("missing required field VEHUNITNO")
+	value = columnValue(values, headers, "VEHUNITNO", 1)
+	if value == "" {
+		return VehUnitToVehCombMapping{}, missingFieldError("VEHUNITNO")
 	}
-	mapping.VehUnitNo, err = strconv.Atoi(values[1])
+	mapping.VehUnitNo, err = strconv.Atoi(value)
 	if err != nil {
-		return VehUnitToVehCombMapping{}, fmt.Errorf("error parsing VehUnitNo: %w", err)
+		return VehUnitToVehCombMapping{}, parseFieldError("VEHUNITNO", err)
 	}
 
 	// Parse NumVehUnits (required field)
-	if values[2] == "" {
-		return VehUnitToVehCombMapping{}, fmt.Errorf("missing required field NUMVEHUNITS")
+	value = columnValue(values, headers, "NUMVEHUNITS", 2)
+	if value == "" {
+		return VehUnitToVehCombMapping{}, missingFieldError("NUMVEHUNITS")
 	}
-	mapping.NumVehUnits, err = strconv.Atoi(values[2])
+	mapping.NumVehUnits, err = strconv.Atoi(value)
 	if err != nil {
-		return VehUnitToVehCombMapping{}, fmt.Errorf("error parsing NumVehUnits: %w", err)
+		return VehUnitToVehCombMapping{}, parseFieldError("NUMVEHUNITS", err)
 	}
 
 	return mapping, nil
 }
+
+// vehUnitToVehCombColumns are the columns written when the section has no headers
+var vehUnitToVehCombColumns = []string{"VEHCOMBNO", "VEHUNITNO", "NUMVEHUNITS"}
+
+func (m VehUnitToVehCombMapping) attribute(column string) (string, bool) {
+	switch column {
+	case "VEHCOMBNO":
+		return formatInt(m.VehCombNo), true
+	case "VEHUNITNO":
+		return formatInt(m.VehUnitNo), true
+	case "NUMVEHUNITS":
+		return formatInt(m.NumVehUnits), true
+	}
+	return "", false
+}
@@ -1,7 +1,6 @@
 package ptvvisum
 
 import (
-	"fmt"
 	"strconv"
 	"strings"
 )
@@ -26,28 +25,35 @@ type VehicleCombination struct {
 	CostRateHourDepot   float64 // Cost rate per hour at depot
 }
 
-// getVehicleCombination extracts data from VEHCOMB section row
-func getVehicleCombination(values []string) (VehicleCombination, error) {
-	if len(values) < 10 {
-		return VehicleCombination{}, fmt.Errorf("invalid VEHCOMB data: %v", values)
+// GetVehicleCombinationByID retrieves a vehicle combination by its number
+func (s *VehCombSection) GetVehicleCombinationByID(no int) (VehicleCombination, bool) {
+	for _, combination := range s.Combinations {
+		if combination.No == no {
+			return combination, true
+		}
 	}
+	return VehicleCombination{}, false
+}
 
+// getVehicleCombination extracts data from VEHCOMB section row
+func getVehicleCombination(values []string, headers []string) (VehicleCombination, error) {
 	var comb VehicleCombination
 	var err error
 
 	// Parse NO (required field)
-	if values[0] == "" {
-		return VehicleCombination{}, fmt.Errorf("missing required field NO")
+	value := columnValue(values, headers, "NO", 0)
+	if value == "" {
+		return VehicleCombination{}, missingFieldError("NO")
 	}
-	comb.No, err = strconv.Atoi(values[0])
+	comb.No, err = strconv.Atoi(value)
 	if err != nil {
-		return VehicleCombination{}, fmt.Errorf("error parsing No: %w", err)
+		return VehicleCombination{}, parseFieldError("NO", err)
 	}
 
 	// Set string values
-	comb.Code = values[1]
-	comb.VehCombSet = values[2]
-	comb.Name = values[3]
+	comb.Code = columnValue(values, headers, "CODE", 1)
+	comb.VehCombSet = columnValue(values, headers, "VEHCOMBSET", 2)
+	comb.Name = columnValue(values, headers, "NAME", 3)
 
 	// Parse float fields
 	floatFields := []struct {
@@ -55,22 +61,54 @@ func getVehicleCombination(values []string) (VehicleCombination, error) {
 		dest  *float64
 		name  string
 	}{
-		{4, &comb.CostRateHourService, "CostRateHourService"},
-		{5, &comb.CostRateHourEmpty, "CostRateHourEmpty"},
-		{6, &comb.CostRateKmService, "CostRateKmService"},
-		{7, &comb.CostRateKmEmpty, "CostRateKmEmpty"},
-		{8, &comb.CostRateHourLayover, "CostRateHourLayover"},
-		{9, &comb.CostRateHourDepot, "CostRateHourDepot"},
+		{4, &comb.CostRateHourService, "COSTRATEHOURSERVICE"},
+		{5, &comb.CostRateHourEmpty, "COSTRATEHOUREMPTY"},
+		{6, &comb.CostRateKmService, "COSTRATEKMSERVICE"},
+		{7, &comb.CostRateKmEmpty, "COSTRATEKMEMPTY"},
+		{8, &comb.CostRateHourLayover, "COSTRATEHOURLAYOVER"},
+		{9, &comb.CostRateHourDepot, "COSTRATEHOURDEPOT"},
 	}
 
 	for _, field := range floatFields {
-		if values[field.index] != "" {
-			*field.dest, err = strconv.ParseFloat(strings.Replace(values[field.index], ",", ".", -1), 64)
+		if value := columnValue(values, headers, field.name, field.index); value != "" {
+			*field.dest, err = strconv.ParseFloat(strings.Replace(value, ",", ".", -1), 64)
 			if err != nil {
-				return VehicleCombination{}, fmt.Errorf("error parsing %s: %w", field.name, err)
+				return VehicleCombination{}, parseFieldError(field.name, err)
 			}
 		}
 	}
 
 	return comb, nil
 }
+
+// vehicleCombinationColumns are the columns written when the section has no headers
+var vehicleCombinationColumns = []string{
+	"NO", "CODE", "VEHCOMBSET", "NAME", "COSTRATEHOURSERVICE", "COSTRATEHOUREMPTY", "COSTRATEKMSERVICE",
+	"COSTRATEKMEMPTY", "COSTRATEHOURLAYOVER", "COSTRATEHOURDEPOT",
+}
+
+func (c VehicleCombination) attribute(column string) (string, bool) {
+	switch column {
+	case "NO":
+		return formatInt(c.No), true
+	case "CODE":
+		return c.Code, true
+	case "VEHCOMBSET":
+		return c.VehCombSet, true
+	case "NAME":
+		return c.Name, true
+	case "COSTRATEHOURSERVICE":
+		return formatFloat(c.CostRateHourService), true
+	case "COSTRATEHOUREMPTY":
+		return formatFloat(c.CostRateHourEmpty), true
+	case "COSTRATEKMSERVICE":
+		return formatFloat(c.CostRateKmService), true
+	case "COSTRATEKMEMPTY":
+		return formatFloat(c.CostRateKmEmpty), true
+	case "COSTRATEHOURLAYOVER":
+		return formatFloat(c.CostRateHourLayover), true
+	case "COSTRATEHOURDEPOT":
+		return formatFloat(c.CostRateHourDepot), true
+	}
+	return "", false
+}
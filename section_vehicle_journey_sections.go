@@ -0,0 +1,186 @@
+package ptvvisum
+
+import (
+	"sort"
+	"strconv"
+)
+
+// VehJourneySectionSection represents $VEHJOURNEYSECTION section
+type VehJourneySectionSection struct {
+	BaseSection
+	Sections []VehicleJourneySection
+
+	byVehJourney index[VehicleJourneySection, int]
+}
+
+// VehicleJourneySection represents a part of a vehicle journey with its own valid days and vehicle combination
+type VehicleJourneySection struct {
+	VehJourneyNo            int      // Vehicle journey the section belongs to
+	No                      int      // Section number within the vehicle journey
+	FromTProfItemIndex      int      // Time profile item the section starts at
+	ToTProfItemIndex        int      // Time profile item the section ends at
+	ValidDaysNo             int      // Valid days the section runs on
+	VehCombNo               int      // Vehicle combination, 0 if not set
+	VehCombSet              string   // Vehicle combination set
+	IsOptionalReinforcement int      // Whether the section is an optional reinforcement (0/1)
+	PrePrepTime             Duration // Preparation time before the section
+	UseSpecPrePrepTime      int      // Whether vehicle unit specific preparation times are used (0/1)
+	PostPrepTime            Duration // Preparation time after the section
+	UseSpecPostPrepTime     int      // Whether vehicle unit specific post-preparation times are used (0/1)
+	OperatingPeriodNo       int      // Operating period number, 0 if not set
+}
+
+// GetSectionsByVehicleJourney retrieves all sections of a vehicle journey ordered by their number
+func (s *VehJourneySectionSection) GetSectionsByVehicleJourney(vehJourneyNo int) []VehicleJourneySection {
+	result := s.byVehJourney.find(s.Sections, vehJourneyNo, func(section VehicleJourneySection) int { return section.VehJourneyNo })
+
+	// Sort by number to ensure correct order
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].No < result[j].No
+	})
+
+	return result
+}
+
+// GetSection retrieves a section of a vehicle journey by its number
+func (s *VehJourneySectionSection) GetSection(vehJourneyNo, no int) (VehicleJourneySection, bool) {
+	for _, i := range s.byVehJourney.lookup(s.Sections, vehJourneyNo, func(section VehicleJourneySection) int { return section.VehJourneyNo }) {
+		if s.Sections[i].No == no {
+			return s.Sections[i], true
+		}
+	}
+	return VehicleJourneySection{}, false
+}
+
+// GetValidDays retrieves the valid days a section of a vehicle journey runs on
+func (s *VehJourneySectionSection) GetValidDays(vehJourneyNo, no int, data *PTVData) (ValidDay, bool) {
+	section, found := s.GetSection(vehJourneyNo, no)
+	if !found || data.ValidDays == nil {
+		return ValidDay{}, false
+	}
+	return data.ValidDays.GetValidDayByID(section.ValidDaysNo)
+}
+
+// GetVehicleCombination retrieves the vehicle combination used on a section of a vehicle journey
+func (s *VehJourneySectionSection) GetVehicleCombination(vehJourneyNo, no int, data *PTVData) (VehicleCombination, bool) {
+	section, found := s.GetSection(vehJourneyNo, no)
+	if !found || data.VehComb == nil {
+		return VehicleCombination{}, false
+	}
+	return data.VehComb.GetVehicleCombinationByID(section.VehCombNo)
+}
+
+// Reindex drops the lookup index after vehicle journeys of sections have been changed in place, it is built again on next use
+func (s *VehJourneySectionSection) Reindex() {
+	s.byVehJourney.reset()
+}
+
+// Count returns the number of vehicle journey sections in the section
+func (s *VehJourneySectionSection) Count() int {
+	return len(s.Sections)
+}
+
+// getVehicleJourneySection extracts data from VEHJOURNEYSECTION section row.
+// Vehicle unit specific preparation times SPECPREPREPTIME(n) and SPECPOSTPREPTIME(n) are kept untyped
+func getVehicleJourneySection(values []string, headers []string) (VehicleJourneySection, error) {
+	var section VehicleJourneySection
+	var err error
+
+	// Parse VEHJOURNEYNO and NO (required fields)
+	value := columnValue(values, headers, "VEHJOURNEYNO", 0)
+	if value == "" {
+		return VehicleJourneySection{}, missingFieldError("VEHJOURNEYNO")
+	}
+	section.VehJourneyNo, err = strconv.Atoi(value)
+	if err != nil {
+		return VehicleJourneySection{}, parseFieldError("VEHJOURNEYNO", err)
+	}
+	value = columnValue(values, headers, "NO", 1)
+	if value == "" {
+		return VehicleJourneySection{}, missingFieldError("NO")
+	}
+	section.No, err = strconv.Atoi(value)
+	if err != nil {
+		return VehicleJourneySection{}, parseFieldError("NO", err)
+	}
+
+	// Parse integer fields (optional)
+	intFields := []struct {
+		index int
+		dest  *int
+		name  string
+	}{
+		{2, &section.FromTProfItemIndex, "FROMTPROFITEMINDEX"},
+		{3, &section.ToTProfItemIndex, "TOTPROFITEMINDEX"},
+		{4, &section.ValidDaysNo, "VALIDDAYSNO"},
+		{5, &section.VehCombNo, "VEHCOMBNO"},
+		{7, &section.IsOptionalReinforcement, "ISOPTIONALREINFORCEMENT"},
+		{9, &section.UseSpecPrePrepTime, "USESPECPREPREPTIME"},
+		{28, &section.UseSpecPostPrepTime, "USESPECPOSTPREPTIME"},
+		{46, &section.OperatingPeriodNo, "OPERATINGPERIODNO"},
+	}
+	for _, field := range intFields {
+		if value := columnValue(values, headers, field.name, field.index); value != "" {
+			*field.dest, err = strconv.Atoi(value)
+			if err != nil {
+				return VehicleJourneySection{}, parseFieldError(field.name, err)
+			}
+		}
+	}
+
+	section.VehCombSet = columnValue(values, headers, "VEHCOMBSET", 6)
+
+	// Parse PREPREPTIME and POSTPREPTIME (optional)
+	if value := columnValue(values, headers, "PREPREPTIME", 8); value != "" {
+		section.PrePrepTime, err = parseDuration(value)
+		if err != nil {
+			return VehicleJourneySection{}, parseFieldError("PREPREPTIME", err)
+		}
+	}
+	if value := columnValue(values, headers, "POSTPREPTIME", 27); value != "" {
+		section.PostPrepTime, err = parseDuration(value)
+		if err != nil {
+			return VehicleJourneySection{}, parseFieldError("POSTPREPTIME", err)
+		}
+	}
+
+	return section, nil
+}
+
+// vehicleJourneySectionColumns are the columns written when the section has no headers
+var vehicleJourneySectionColumns = []string{
+	"VEHJOURNEYNO", "NO", "FROMTPROFITEMINDEX", "TOTPROFITEMINDEX", "VALIDDAYSNO", "VEHCOMBNO", "VEHCOMBSET",
+	"ISOPTIONALREINFORCEMENT", "PREPREPTIME", "USESPECPREPREPTIME", "POSTPREPTIME", "USESPECPOSTPREPTIME", "OPERATINGPERIODNO",
+}
+
+func (section VehicleJourneySection) attribute(column string) (string, bool) {
+	switch column {
+	case "VEHJOURNEYNO":
+		return formatInt(section.VehJourneyNo), true
+	case "NO":
+		return formatInt(section.No), true
+	case "FROMTPROFITEMINDEX":
+		return formatInt(section.FromTProfItemIndex), true
+	case "TOTPROFITEMINDEX":
+		return formatInt(section.ToTProfItemIndex), true
+	case "VALIDDAYSNO":
+		return formatInt(section.ValidDaysNo), true
+	case "VEHCOMBNO":
+		return formatOptionalInt(section.VehCombNo), true
+	case "VEHCOMBSET":
+		return section.VehCombSet, true
+	case "ISOPTIONALREINFORCEMENT":
+		return formatInt(section.IsOptionalReinforcement), true
+	case "PREPREPTIME":
+		return section.PrePrepTime.String(), true
+	case "USESPECPREPREPTIME":
+		return formatInt(section.UseSpecPrePrepTime), true
+	case "POSTPREPTIME":
+		return section.PostPrepTime.String(), true
+	case "USESPECPOSTPREPTIME":
+		return formatInt(section.UseSpecPostPrepTime), true
+	case "OPERATINGPERIODNO":
+		return formatOptionalInt(section.OperatingPeriodNo), true
+	}
+	return "", false
+}
@@ -0,0 +1,272 @@
+package ptvvisum
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// VehJourneySection represents $VEHJOURNEY section
+type VehJourneySection struct {
+	BaseSection
+	Journeys []VehicleJourney
+
+	byID          index[VehicleJourney, int]
+	byTimeProfile index[VehicleJourney, TimeProfileKey]
+}
+
+// VehicleJourney represents a single trip of a line running along a time profile
+type VehicleJourney struct {
+	No                 int      // Vehicle journey number
+	Name               string   // Vehicle journey name
+	Dep                Duration // Departure at the first time profile item, from midnight of the service day
+	LineName           string   // Name of the line
+	LineRouteName      string   // Name of the line route
+	DirectionCode      string   // Code of the direction of the line route
+	TimeProfileName    string   // Name of the time profile
+	FromTProfItemIndex int      // Time profile item the journey starts at
+	ToTProfItemIndex   int      // Time profile item the journey ends at
+	OperatorNo         int      // Operator number, 0 if not set
+	AddVal             [3]int   // Additional values 1-3
+	ServTripPatNo      int      // Service trip pattern number
+}
+
+// StopTime is the arrival and departure of a vehicle journey at a stop point, from midnight of the service day
+type StopTime struct {
+	TimeProfileItemIndex int           // Index of the time profile item
+	StopPointNo          int           // Stop point served
+	Arr                  time.Duration // Arrival, may exceed 24 hours for journeys running past midnight
+	Dep                  time.Duration // Departure, may exceed 24 hours for journeys running past midnight
+}
+
+// TimeProfileKey returns the key of the time profile the journey runs along
+func (journey VehicleJourney) TimeProfileKey() TimeProfileKey {
+	return TimeProfileKey{
+		LineRouteKey:    LineRouteKey{LineName: journey.LineName, LineRouteName: journey.LineRouteName, DirectionCode: journey.DirectionCode},
+		TimeProfileName: journey.TimeProfileName,
+	}
+}
+
+// GetVehicleJourneyByID retrieves a vehicle journey by its number
+func (s *VehJourneySection) GetVehicleJourneyByID(no int) (VehicleJourney, bool) {
+	return s.byID.findFirst(s.Journeys, no, func(journey VehicleJourney) int { return journey.No })
+}
+
+// GetVehicleJourneysByTimeProfile retrieves all vehicle journeys running along a time profile
+func (s *VehJourneySection) GetVehicleJourneysByTimeProfile(key TimeProfileKey) []VehicleJourney {
+	return s.byTimeProfile.find(s.Journeys, key, VehicleJourney.TimeProfileKey)
+}
+
+// GetTimeProfile retrieves the time profile a vehicle journey runs along
+func (s *VehJourneySection) GetTimeProfile(no int, data *PTVData) (TimeProfile, bool) {
+	journey, found := s.GetVehicleJourneyByID(no)
+	if !found || data.TimeProfile == nil {
+		return TimeProfile{}, false
+	}
+	return data.TimeProfile.GetTimeProfile(journey.TimeProfileKey())
+}
+
+// GetTimeProfileItems retrieves the time profile items from the first to the last one served by a vehicle journey
+func (s *VehJourneySection) GetTimeProfileItems(no int, data *PTVData) []TimeProfileItem {
+	journey, found := s.GetVehicleJourneyByID(no)
+	if !found || data.TimeProfileItem == nil {
+		return nil
+	}
+	var result []TimeProfileItem
+	for _, item := range data.TimeProfileItem.GetItemsByTimeProfile(journey.TimeProfileKey()) {
+		if item.Index >= journey.FromTProfItemIndex && item.Index <= journey.ToTProfItemIndex {
+			result = append(result, item)
+		}
+	}
+	return result
+}
+
+// GetStopTimes returns the arrival and departure of a vehicle journey at each stop point it serves.
+// Run times come from the time profile, shifted by the departure of the journey at its first item
+func (s *VehJourneySection) GetStopTimes(no int, data *PTVData) ([]StopTime, error) {
+	journey, found := s.GetVehicleJourneyByID(no)
+	if !found {
+		return nil, fmt.Errorf("no vehicle journey %d", no)
+	}
+	if data.LineRouteItem == nil {
+		return nil, fmt.Errorf("no line route items found in the data")
+	}
+	items := s.GetTimeProfileItems(no, data)
+	if len(items) == 0 {
+		return nil, fmt.Errorf("no time profile items for vehicle journey %d", no)
+	}
+
+	key := journey.TimeProfileKey()
+	offset := journey.Dep.Duration - items[0].Dep.Duration
+	var stopTimes []StopTime
+	for _, item := range items {
+		routeItem, found := data.LineRouteItem.GetItem(key.LineRouteKey, item.LRItemIndex)
+		if !found {
+			return nil, fmt.Errorf("no line route item %d for vehicle journey %d", item.LRItemIndex, no)
+		}
+		if routeItem.StopPointNo == 0 {
+			continue
+		}
+		stopTimes = append(stopTimes, StopTime{
+			TimeProfileItemIndex: item.Index,
+			StopPointNo:          routeItem.StopPointNo,
+			Arr:                  offset + item.Arr.Duration,
+			Dep:                  offset + item.Dep.Duration,
+		})
+	}
+	return stopTimes, nil
+}
+
+// GetVehicleJourneysOnDay retrieves all vehicle journeys with at least one section running on a calendar day
+func (s *VehJourneySection) GetVehicleJourneysOnDay(day time.Time, data *PTVData) []VehicleJourney {
+	if data.VehJourneySection == nil || data.ValidDays == nil {
+		return nil
+	}
+	var result []VehicleJourney
+	for _, journey := range s.Journeys {
+		for _, section := range data.VehJourneySection.GetSectionsByVehicleJourney(journey.No) {
+			if data.ValidDays.IsValidOn(section.ValidDaysNo, day, data) {
+				result = append(result, journey)
+				break
+			}
+		}
+	}
+	return result
+}
+
+// Reindex drops the lookup indexes after keys of vehicle journeys have been changed in place, they are built again on next use
+func (s *VehJourneySection) Reindex() {
+	s.byID.reset()
+	s.byTimeProfile.reset()
+}
+
+// Count returns the number of vehicle journeys in the section
+func (s *VehJourneySection) Count() int {
+	return len(s.Journeys)
+}
+
+// getVehicleJourney extracts data from VEHJOURNEY section row
+func getVehicleJourney(values []string, headers []string) (VehicleJourney, error) {
+	var journey VehicleJourney
+	var err error
+
+	// Parse NO (required field)
+	value := columnValue(values, headers, "NO", 0)
+	if value == "" {
+		return VehicleJourney{}, missingFieldError("NO")
+	}
+	journey.No, err = strconv.Atoi(value)
+	if err != nil {
+		return VehicleJourney{}, parseFieldError("NO", err)
+	}
+
+	journey.Name = columnValue(values, headers, "NAME", 1)
+
+	// Parse DEP (optional)
+	if value := columnValue(values, headers, "DEP", 2); value != "" {
+		journey.Dep, err = parseDuration(value)
+		if err != nil {
+			return VehicleJourney{}, parseFieldError("DEP", err)
+		}
+	}
+
+	// Parse LINENAME, LINEROUTENAME, DIRECTIONCODE and TIMEPROFILENAME (required fields)
+	journey.LineName = columnValue(values, headers, "LINENAME", 3)
+	if journey.LineName == "" {
+		return VehicleJourney{}, missingFieldError("LINENAME")
+	}
+	journey.LineRouteName = columnValue(values, headers, "LINEROUTENAME", 4)
+	if journey.LineRouteName == "" {
+		return VehicleJourney{}, missingFieldError("LINEROUTENAME")
+	}
+	journey.DirectionCode = columnValue(values, headers, "DIRECTIONCODE", 5)
+	if journey.DirectionCode == "" {
+		return VehicleJourney{}, missingFieldError("DIRECTIONCODE")
+	}
+	journey.TimeProfileName = columnValue(values, headers, "TIMEPROFILENAME", 6)
+	if journey.TimeProfileName == "" {
+		return VehicleJourney{}, missingFieldError("TIMEPROFILENAME")
+	}
+
+	// Parse FROMTPROFITEMINDEX and TOTPROFITEMINDEX (optional)
+	if value := columnValue(values, headers, "FROMTPROFITEMINDEX", 7); value != "" {
+		journey.FromTProfItemIndex, err = strconv.Atoi(value)
+		if err != nil {
+			return VehicleJourney{}, parseFieldError("FROMTPROFITEMINDEX", err)
+		}
+	}
+	if value := columnValue(values, headers, "TOTPROFITEMINDEX", 8); value != "" {
+		journey.ToTProfItemIndex, err = strconv.Atoi(value)
+		if err != nil {
+			return VehicleJourney{}, parseFieldError("TOTPROFITEMINDEX", err)
+		}
+	}
+
+	// Parse OPERATORNO (optional)
+	if value := columnValue(values, headers, "OPERATORNO", 9); value != "" {
+		journey.OperatorNo, err = strconv.Atoi(value)
+		if err != nil {
+			return VehicleJourney{}, parseFieldError("OPERATORNO", err)
+		}
+	}
+
+	// Parse ADDVAL1, ADDVAL2, ADDVAL3 (optional)
+	for i, column := range []string{"ADDVAL1", "ADDVAL2", "ADDVAL3"} {
+		if value := columnValue(values, headers, column, 10+i); value != "" {
+			journey.AddVal[i], err = strconv.Atoi(value)
+			if err != nil {
+				return VehicleJourney{}, parseFieldError(column, err)
+			}
+		}
+	}
+
+	// Parse SERVTRIPPATNO (optional)
+	if value := columnValue(values, headers, "SERVTRIPPATNO", 13); value != "" {
+		journey.ServTripPatNo, err = strconv.Atoi(value)
+		if err != nil {
+			return VehicleJourney{}, parseFieldError("SERVTRIPPATNO", err)
+		}
+	}
+
+	return journey, nil
+}
+
+// vehicleJourneyColumns are the columns written when the section has no headers
+var vehicleJourneyColumns = []string{
+	"NO", "NAME", "DEP", "LINENAME", "LINEROUTENAME", "DIRECTIONCODE", "TIMEPROFILENAME", "FROMTPROFITEMINDEX",
+	"TOTPROFITEMINDEX", "OPERATORNO", "ADDVAL1", "ADDVAL2", "ADDVAL3", "SERVTRIPPATNO",
+}
+
+func (journey VehicleJourney) attribute(column string) (string, bool) {
+	switch column {
+	case "NO":
+		return formatInt(journey.No), true
+	case "NAME":
+		return journey.Name, true
+	case "DEP":
+		return journey.Dep.String(), true
+	case "LINENAME":
+		return journey.LineName, true
+	case "LINEROUTENAME":
+		return journey.LineRouteName, true
+	case "DIRECTIONCODE":
+		return journey.DirectionCode, true
+	case "TIMEPROFILENAME":
+		return journey.TimeProfileName, true
+	case "FROMTPROFITEMINDEX":
+		return formatInt(journey.FromTProfItemIndex), true
+	case "TOTPROFITEMINDEX":
+		return formatInt(journey.ToTProfItemIndex), true
+	case "OPERATORNO":
+		return formatOptionalInt(journey.OperatorNo), true
+	case "ADDVAL1":
+		return formatInt(journey.AddVal[0]), true
+	case "ADDVAL2":
+		return formatInt(journey.AddVal[1]), true
+	case "ADDVAL3":
+		return formatInt(journey.AddVal[2]), true
+	case "SERVTRIPPATNO":
+		return formatInt(journey.ServTripPatNo), true
+	}
+	return "", false
+}
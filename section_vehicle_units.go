@@ -1,7 +1,6 @@
 package ptvvisum
 
 import (
-	"fmt"
 	"strconv"
 	"strings"
 )
@@ -31,27 +30,24 @@ type VehicleUnit struct {
 }
 
 // getVehicleUnit extracts data from VEHUNIT section row
-func getVehicleUnit(values []string) (VehicleUnit, error) {
-	if len(values) < 14 {
-		return VehicleUnit{}, fmt.Errorf("invalid VEHUNIT data: %v", values)
-	}
-
+func getVehicleUnit(values []string, headers []string) (VehicleUnit, error) {
 	var unit VehicleUnit
 	var err error
 
 	// Parse NO (required field)
-	if values[0] == "" {
-		return VehicleUnit{}, fmt.Errorf("missing required field NO")
+	value := columnValue(values, headers, "NO", 0)
+	if value == "" {
+		return VehicleUnit{}, missingFieldError("NO")
 	}
-	unit.No, err = strconv.Atoi(values[0])
+	unit.No, err = strconv.Atoi(value)
 	if err != nil {
-		return VehicleUnit{}, fmt.Errorf("error parsing No: %w", err)
+		return VehicleUnit{}, parseFieldError("NO", err)
 	}
 
 	// Set string values
-	unit.Code = values[1]
-	unit.Name = values[2]
-	unit.TSysSet = values[3]
+	unit.Code = columnValue(values, headers, "CODE", 1)
+	unit.Name = columnValue(values, headers, "NAME", 2)
+	unit.TSysSet = columnValue(values, headers, "TSYSSET", 3)
 
 	// Parse integer fields
 	intFields := []struct {
@@ -59,16 +55,16 @@ func getVehicleUnit(values []string) (VehicleUnit, error) {
 		dest  *int
 		name  string
 	}{
-		{4, &unit.Powered, "Powered"},
-		{5, &unit.SeatCap, "SeatCap"},
-		{6, &unit.TotalCap, "TotalCap"},
+		{4, &unit.Powered, "POWERED"},
+		{5, &unit.SeatCap, "SEATCAP"},
+		{6, &unit.TotalCap, "TOTALCAP"},
 	}
 
 	for _, field := range intFields {
-		if values[field.index] != "" {
-			*field.dest, err = strconv.Atoi(values[field.index])
+		if value := columnValue(values, headers, field.name, field.index); value != "" {
+			*field.dest, err = strconv.Atoi(value)
 			if err != nil {
-				return VehicleUnit{}, fmt.Errorf("error parsing %s: %w", field.name, err)
+				return VehicleUnit{}, parseFieldError(field.name, err)
 			}
 		}
 	}
@@ -79,23 +75,63 @@ func getVehicleUnit(values []string) (VehicleUnit, error) {
 		dest  *float64
 		name  string
 	}{
-		{7, &unit.CostRateHourService, "CostRateHourService"},
-		{8, &unit.CostRateHourEmpty, "CostRateHourEmpty"},
-		{9, &unit.CostRateHourLayover, "CostRateHourLayover"},
-		{10, &unit.CostRateHourDepot, "CostRateHourDepot"},
-		{11, &unit.CostRateKmService, "CostRateKmService"},
-		{12, &unit.CostRateKmEmpty, "CostRateKmEmpty"},
-		{13, &unit.CostRateVehUnit, "CostRateVehUnit"},
+		{7, &unit.CostRateHourService, "COSTRATEHOURSERVICE"},
+		{8, &unit.CostRateHourEmpty, "COSTRATEHOUREMPTY"},
+		{9, &unit.CostRateHourLayover, "COSTRATEHOURLAYOVER"},
+		{10, &unit.CostRateHourDepot, "COSTRATEHOURDEPOT"},
+		{11, &unit.CostRateKmService, "COSTRATEKMSERVICE"},
+		{12, &unit.CostRateKmEmpty, "COSTRATEKMEMPTY"},
+		{13, &unit.CostRateVehUnit, "COSTRATEVEHUNIT"},
 	}
 
 	for _, field := range floatFields {
-		if values[field.index] != "" {
-			*field.dest, err = strconv.ParseFloat(strings.Replace(values[field.index], ",", ".", -1), 64)
+		if value := columnValue(values, headers, field.name, field.index); value != "" {
+			*field.dest, err = strconv.ParseFloat(strings.Replace(value, ",", ".", -1), 64)
 			if err != nil {
-				return VehicleUnit{}, fmt.Errorf("error parsing %s: %w", field.name, err)
+				return VehicleUnit{}, parseFieldError(field.name, err)
 			}
 		}
 	}
 
 	return unit, nil
 }
+
+// vehicleUnitColumns are the columns written when the section has no headers
+var vehicleUnitColumns = []string{
+	"NO", "CODE", "NAME", "TSYSSET", "POWERED", "SEATCAP", "TOTALCAP", "COSTRATEHOURSERVICE", "COSTRATEHOUREMPTY",
+	"COSTRATEHOURLAYOVER", "COSTRATEHOURDEPOT", "COSTRATEKMSERVICE", "COSTRATEKMEMPTY", "COSTRATEVEHUNIT",
+}
+
+func (u VehicleUnit) attribute(column string) (string, bool) {
+	switch column {
+	case "NO":
+		return formatInt(u.No), true
+	case "CODE":
+		return u.Code, true
+	case "NAME":
+		return u.Name, true
+	case "TSYSSET":
+		return u.TSysSet, true
+	case "POWERED":
+		return formatInt(u.Powered), true
+	case "SEATCAP":
+		return formatInt(u.SeatCap), true
+	case "TOTALCAP":
+		return formatInt(u.TotalCap), true
+	case "COSTRATEHOURSERVICE":
+		return formatFloat(u.CostRateHourService), true
+	case "COSTRATEHOUREMPTY":
+		return formatFloat(u.CostRateHourEmpty), true
+	case "COSTRATEHOURLAYOVER":
+		return formatFloat(u.CostRateHourLayover), true
+	case "COSTRATEHOURDEPOT":
+		return formatFloat(u.CostRateHourDepot), true
+	case "COSTRATEKMSERVICE":
+		return formatFloat(u.CostRateKmService), true
+	case "COSTRATEKMEMPTY":
+		return formatFloat(u.CostRateKmEmpty), true
+	case "COSTRATEVEHUNIT":
+		return formatFloat(u.CostRateVehUnit), true
+	}
+	return "", false
+}
@@ -1,7 +1,5 @@
 package ptvvisum
 
-import "fmt"
-
 // VersionSection represents $VERSION section
 type VersionSection struct {
 	BaseSection
@@ -11,13 +9,35 @@ type VersionSection struct {
 	Unit     string
 }
 
-func getVersion(values []string) (version string, sileType string, language string, unit string, err error) {
-	if len(values) < 4 {
-		return "", "", "", "", fmt.Errorf("invalid VERSION data: %v", values)
+func getVersion(values []string, headers []string) (version string, sileType string, language string, unit string, err error) {
+	version = columnValue(values, headers, "VERSNR", 0)
+	if version == "" {
+		return "", "", "", "", missingFieldError("VERSNR")
 	}
-	version = values[0]
-	sileType = values[1]
-	language = values[2]
-	unit = values[3]
+	sileType = columnValue(values, headers, "FILETYPE", 1)
+	language = columnValue(values, headers, "LANGUAGE", 2)
+	unit = columnValue(values, headers, "UNIT", 3)
 	return
 }
+
+// versionColumns are the columns written when the section has no headers
+var versionColumns = []string{"VERSNR", "FILETYPE", "LANGUAGE", "UNIT"}
+
+// versionRecord adapts VersionSection to the single row written for it
+type versionRecord struct {
+	*VersionSection
+}
+
+func (r versionRecord) attribute(column string) (string, bool) {
+	switch column {
+	case "VERSNR":
+		return r.Version, true
+	case "FILETYPE":
+		return r.FileType, true
+	case "LANGUAGE":
+		return r.Language, true
+	case "UNIT":
+		return r.Unit, true
+	}
+	return "", false
+}
@@ -1,7 +1,6 @@
 package ptvvisum
 
 import (
-	"fmt"
 	"strconv"
 	"strings"
 )
@@ -10,6 +9,10 @@ import (
 type ZoneSection struct {
 	BaseSection
 	Zones []Zone
+
+	byID      index[Zone, int]
+	byCode    index[Zone, string]
+	bySurface index[Zone, int]
 }
 
 // Zone represents a single zone in the transportation model
@@ -38,22 +41,12 @@ type Zone struct {
 
 // GetZoneByID retrieves a zone by its ID
 func (s *ZoneSection) GetZoneByID(id int) (Zone, bool) {
-	for _, zone := range s.Zones {
-		if zone.No == id {
-			return zone, true
-		}
-	}
-	return Zone{}, false
+	return s.byID.findFirst(s.Zones, id, func(zone Zone) int { return zone.No })
 }
 
 // GetZoneByCode retrieves a zone by its code
 func (s *ZoneSection) GetZoneByCode(code string) (Zone, bool) {
-	for _, zone := range s.Zones {
-		if zone.Code == code {
-			return zone, true
-		}
-	}
-	return Zone{}, false
+	return s.byCode.findFirst(s.Zones, code, func(zone Zone) string { return zone.Code })
 }
 
 // GetZonesByType retrieves all zones of a specified type
@@ -69,13 +62,7 @@ func (s *ZoneSection) GetZonesByType(typeNo int) []Zone {
 
 // GetZonesBySurface retrieves all zones associated with a particular surface
 func (s *ZoneSection) GetZonesBySurface(surfaceID int) []Zone {
-	var result []Zone
-	for _, zone := range s.Zones {
-		if zone.SurfaceID == surfaceID {
-			result = append(result, zone)
-		}
-	}
-	return result
+	return s.bySurface.find(s.Zones, surfaceID, func(zone Zone) int { return zone.SurfaceID })
 }
 
 // CalculateBoundingBox returns the min/max coordinates of all zones
@@ -125,176 +112,230 @@ func (s *ZoneSection) GetTotalEmployment() int {
 	return total
 }
 
+// Reindex drops the lookup indexes after numbers, codes or surfaces of zones have been changed in place, they are built again on next use
+func (s *ZoneSection) Reindex() {
+	s.byID.reset()
+	s.byCode.reset()
+	s.bySurface.reset()
+}
+
 // Count returns the number of zones in the section
 func (s *ZoneSection) Count() int {
 	return len(s.Zones)
 }
 
 // getZone extracts data from ZONE section row
-func getZone(values []string) (Zone, error) {
-	if len(values) < 7 {
-		return Zone{}, fmt.Errorf("invalid ZONE data (insufficient fields): %v", values)
-	}
-
+func getZone(values []string, headers []string) (Zone, error) {
 	var zone Zone
 	var err error
 
 	// Parse NO (required field)
-	if values[0] == "" {
-		return Zone{}, fmt.Errorf("missing required field NO")
+	value := columnValue(values, headers, "NO", 0)
+	if value == "" {
+		return Zone{}, missingFieldError("NO")
 	}
-	zone.No, err = strconv.Atoi(values[0])
+	zone.No, err = strconv.Atoi(value)
 	if err != nil {
-		return Zone{}, fmt.Errorf("error parsing NO: %w", err)
+		return Zone{}, parseFieldError("NO", err)
 	}
 
 	// Parse CODE (optional)
-	zone.Code = values[1]
+	zone.Code = columnValue(values, headers, "CODE", 1)
 
 	// Parse NAME (optional)
-	zone.Name = values[2]
+	zone.Name = columnValue(values, headers, "NAME", 2)
 
 	// Parse MAINZONENO (optional)
-	if values[3] != "" {
-		zone.MainZoneNo, err = strconv.Atoi(values[3])
+	if value := columnValue(values, headers, "MAINZONENO", 3); value != "" {
+		zone.MainZoneNo, err = strconv.Atoi(value)
 		if err != nil {
-			return Zone{}, fmt.Errorf("error parsing MAINZONENO: %w", err)
+			return Zone{}, parseFieldError("MAINZONENO", err)
 		}
 	}
 
 	// Parse TYPENO (optional)
-	if values[4] != "" {
-		zone.TypeNo, err = strconv.Atoi(values[4])
+	if value := columnValue(values, headers, "TYPENO", 4); value != "" {
+		zone.TypeNo, err = strconv.Atoi(value)
 		if err != nil {
-			return Zone{}, fmt.Errorf("error parsing TYPENO: %w", err)
+			return Zone{}, parseFieldError("TYPENO", err)
 		}
 	}
 
 	// Parse XCOORD (required field)
-	if values[5] == "" {
-		return Zone{}, fmt.Errorf("missing required field XCOORD")
+	value = columnValue(values, headers, "XCOORD", 5)
+	if value == "" {
+		return Zone{}, missingFieldError("XCOORD")
 	}
-	zone.XCoord, err = strconv.ParseFloat(strings.Replace(values[5], ",", ".", -1), 64)
+	zone.XCoord, err = strconv.ParseFloat(strings.Replace(value, ",", ".", -1), 64)
 	if err != nil {
-		return Zone{}, fmt.Errorf("error parsing XCOORD: %w", err)
+		return Zone{}, parseFieldError("XCOORD", err)
 	}
 
 	// Parse YCOORD (required field)
-	if values[6] == "" {
-		return Zone{}, fmt.Errorf("missing required field YCOORD")
+	value = columnValue(values, headers, "YCOORD", 6)
+	if value == "" {
+		return Zone{}, missingFieldError("YCOORD")
 	}
-	zone.YCoord, err = strconv.ParseFloat(strings.Replace(values[6], ",", ".", -1), 64)
+	zone.YCoord, err = strconv.ParseFloat(strings.Replace(value, ",", ".", -1), 64)
 	if err != nil {
-		return Zone{}, fmt.Errorf("error parsing YCOORD: %w", err)
+		return Zone{}, parseFieldError("YCOORD", err)
 	}
 
 	// Parse SURFACEID (optional)
-	if len(values) > 7 && values[7] != "" {
-		zone.SurfaceID, err = strconv.Atoi(values[7])
+	if value := columnValue(values, headers, "SURFACEID", 7); value != "" {
+		zone.SurfaceID, err = strconv.Atoi(value)
 		if err != nil {
-			return Zone{}, fmt.Errorf("error parsing SURFACEID: %w", err)
+			return Zone{}, parseFieldError("SURFACEID", err)
 		}
 	}
 
 	// Parse RELATIVESTATE (optional)
-	if len(values) > 8 && values[8] != "" {
-		zone.RelativeState, err = strconv.Atoi(values[8])
+	if value := columnValue(values, headers, "RELATIVESTATE", 8); value != "" {
+		zone.RelativeState, err = strconv.Atoi(value)
 		if err != nil {
-			return Zone{}, fmt.Errorf("error parsing RELATIVESTATE: %w", err)
+			return Zone{}, parseFieldError("RELATIVESTATE", err)
 		}
 	}
 
 	// Parse SHAREPRTORIG (optional)
-	if len(values) > 9 && values[9] != "" {
-		zone.SharePRTOrig, err = strconv.ParseFloat(strings.Replace(values[9], ",", ".", -1), 64)
+	if value := columnValue(values, headers, "SHAREPRTORIG", 9); value != "" {
+		zone.SharePRTOrig, err = strconv.ParseFloat(strings.Replace(value, ",", ".", -1), 64)
 		if err != nil {
-			return Zone{}, fmt.Errorf("error parsing SHAREPRTORIG: %w", err)
+			return Zone{}, parseFieldError("SHAREPRTORIG", err)
 		}
 	}
 
 	// Parse SHAREPRTDEST (optional)
-	if len(values) > 10 && values[10] != "" {
-		zone.SharePRTDest, err = strconv.ParseFloat(strings.Replace(values[10], ",", ".", -1), 64)
+	if value := columnValue(values, headers, "SHAREPRTDEST", 10); value != "" {
+		zone.SharePRTDest, err = strconv.ParseFloat(strings.Replace(value, ",", ".", -1), 64)
 		if err != nil {
-			return Zone{}, fmt.Errorf("error parsing SHAREPRTDEST: %w", err)
+			return Zone{}, parseFieldError("SHAREPRTDEST", err)
 		}
 	}
 
 	// Parse SHAREPUT (optional)
-	if len(values) > 11 && values[11] != "" {
-		zone.SharePUT, err = strconv.ParseFloat(strings.Replace(values[11], ",", ".", -1), 64)
+	if value := columnValue(values, headers, "SHAREPUT", 11); value != "" {
+		zone.SharePUT, err = strconv.ParseFloat(strings.Replace(value, ",", ".", -1), 64)
 		if err != nil {
-			return Zone{}, fmt.Errorf("error parsing SHAREPUT: %w", err)
+			return Zone{}, parseFieldError("SHAREPUT", err)
 		}
 	}
 
 	// Parse METHODCONNSHARES (optional)
-	if len(values) > 12 && values[12] != "" {
-		zone.MethodConnShares, err = strconv.Atoi(values[12])
+	if value := columnValue(values, headers, "METHODCONNSHARES", 12); value != "" {
+		zone.MethodConnShares, err = strconv.Atoi(value)
 		if err != nil {
-			return Zone{}, fmt.Errorf("error parsing METHODCONNSHARES: %w", err)
+			return Zone{}, parseFieldError("METHODCONNSHARES", err)
 		}
 	}
 
-	// Parse socioeconomic data - using your file indices
+	// Parse socioeconomic data - user-defined attributes looked up by column name
 	// POPULATION
-	if len(values) > 46 && values[46] != "" {
-		zone.Population, err = strconv.Atoi(strings.Replace(values[46], ",", "", -1))
+	if value := columnValue(values, headers, "POPULATION", 46); value != "" {
+		zone.Population, err = strconv.Atoi(strings.Replace(value, ",", "", -1))
 		if err != nil {
-			// Just log warning and continue, as this is optional
-			fmt.Printf("Warning: could not parse POPULATION for zone %d: %v\n", zone.No, err)
+			return Zone{}, parseFieldError("POPULATION", err)
 		}
 	}
 
 	// WORKPLACES
-	if len(values) > 57 && values[57] != "" {
-		zone.Employment, err = strconv.Atoi(strings.Replace(values[57], ",", "", -1))
+	if value := columnValue(values, headers, "WORKPLACES", 61); value != "" {
+		zone.Employment, err = strconv.Atoi(strings.Replace(value, ",", "", -1))
 		if err != nil {
-			// Just log warning and continue, as this is optional
-			fmt.Printf("Warning: could not parse WORKPLACES for zone %d: %v\n", zone.No, err)
+			return Zone{}, parseFieldError("WORKPLACES", err)
 		}
 	}
 
 	// WORKERS
-	if len(values) > 56 && values[56] != "" {
-		zone.Workers, err = strconv.Atoi(strings.Replace(values[56], ",", "", -1))
+	if value := columnValue(values, headers, "WORKERS", 59); value != "" {
+		zone.Workers, err = strconv.Atoi(strings.Replace(value, ",", "", -1))
 		if err != nil {
-			// Just log warning and continue, as this is optional
-			fmt.Printf("Warning: could not parse WORKERS for zone %d: %v\n", zone.No, err)
+			return Zone{}, parseFieldError("WORKERS", err)
 		}
 	}
 
 	// STUDENTS
-	if len(values) > 49 && values[49] != "" {
-		zone.Students, err = strconv.Atoi(strings.Replace(values[49], ",", "", -1))
+	if value := columnValue(values, headers, "STUDENTS", 49); value != "" {
+		zone.Students, err = strconv.Atoi(strings.Replace(value, ",", "", -1))
 		if err != nil {
-			// Just log warning and continue, as this is optional
-			fmt.Printf("Warning: could not parse STUDENTS for zone %d: %v\n", zone.No, err)
+			return Zone{}, parseFieldError("STUDENTS", err)
 		}
 	}
 
 	// STUDYPLACES
-	if len(values) > 50 && values[50] != "" {
-		zone.StudyPlaces, err = strconv.Atoi(strings.Replace(values[50], ",", "", -1))
+	if value := columnValue(values, headers, "STUDYPLACES", 50); value != "" {
+		zone.StudyPlaces, err = strconv.Atoi(strings.Replace(value, ",", "", -1))
 		if err != nil {
-			// Just log warning and continue, as this is optional
-			fmt.Printf("Warning: could not parse STUDYPLACES for zone %d: %v\n", zone.No, err)
+			return Zone{}, parseFieldError("STUDYPLACES", err)
 		}
 	}
 
 	// POPDENS - Population density
-	if len(values) > 45 && values[45] != "" {
-		zone.PopDens, err = strconv.ParseFloat(strings.Replace(values[45], ",", ".", -1), 64)
+	if value := columnValue(values, headers, "POPDENS", 45); value != "" {
+		zone.PopDens, err = strconv.ParseFloat(strings.Replace(value, ",", ".", -1), 64)
 		if err != nil {
-			// Just log warning and continue, as this is optional
-			fmt.Printf("Warning: could not parse POPDENS for zone %d: %v\n", zone.No, err)
+			return Zone{}, parseFieldError("POPDENS", err)
 		}
 	}
 
 	// COMMENT
-	if len(values) > 29 && values[29] != "" {
-		zone.Comment = values[29]
-	}
+	zone.Comment = columnValue(values, headers, "COMMENT", 29)
 
 	return zone, nil
 }
+
+// zoneColumns are the columns written when the section has no headers
+var zoneColumns = []string{
+	"NO", "CODE", "NAME", "MAINZONENO", "TYPENO", "XCOORD", "YCOORD", "SURFACEID", "RELATIVESTATE", "SHAREPRTORIG",
+	"SHAREPRTDEST", "SHAREPUT", "METHODCONNSHARES", "COMMENT", "POPDENS", "POPULATION", "STUDENTS", "STUDYPLACES",
+	"WORKERS", "WORKPLACES",
+}
+
+func (zone Zone) attribute(column string) (string, bool) {
+	switch column {
+	case "NO":
+		return formatInt(zone.No), true
+	case "CODE":
+		return zone.Code, true
+	case "NAME":
+		return zone.Name, true
+	case "MAINZONENO":
+		return formatInt(zone.MainZoneNo), true
+	case "TYPENO":
+		return formatInt(zone.TypeNo), true
+	case "XCOORD":
+		return formatFloat(zone.XCoord), true
+	case "YCOORD":
+		return formatFloat(zone.YCoord), true
+	case "SURFACEID":
+		if zone.SurfaceID == 0 {
+			return "", true
+		}
+		return formatInt(zone.SurfaceID), true
+	case "RELATIVESTATE":
+		return formatInt(zone.RelativeState), true
+	case "SHAREPRTORIG":
+		return formatFloat(zone.SharePRTOrig), true
+	case "SHAREPRTDEST":
+		return formatFloat(zone.SharePRTDest), true
+	case "SHAREPUT":
+		return formatFloat(zone.SharePUT), true
+	case "METHODCONNSHARES":
+		return formatInt(zone.MethodConnShares), true
+	case "POPULATION":
+		return formatInt(zone.Population), true
+	case "WORKPLACES":
+		return formatInt(zone.Employment), true
+	case "WORKERS":
+		return formatInt(zone.Workers), true
+	case "STUDENTS":
+		return formatInt(zone.Students), true
+	case "STUDYPLACES":
+		return formatInt(zone.StudyPlaces), true
+	case "POPDENS":
+		return formatFloat(zone.PopDens), true
+	case "COMMENT":
+		return zone.Comment, true
+	}
+	return "", false
+}
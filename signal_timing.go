@@ -0,0 +1,63 @@
+package ptvvisum
+
+import (
+	"sort"
+	"time"
+)
+
+// greenWindow is a part of the cycle a movement may proceed in, end is exclusive
+type greenWindow struct {
+	start, end time.Duration
+}
+
+// mergeGreenWindows merges green and amber of the signal groups within one cycle.
+// It returns the total time released and the number of separate windows, a window running over
+// the end of the cycle into its start counts once. Without a cycle time the windows are not wrapped
+func mergeGreenWindows(groups []SignalGroup, cycleTime time.Duration) (time.Duration, int) {
+	var windows []greenWindow
+	for _, group := range groups {
+		start := group.GtStart.Duration
+		length := group.GreenTime(cycleTime) + group.Amber.Duration
+		if cycleTime <= 0 {
+			windows = append(windows, greenWindow{start, start + length})
+			continue
+		}
+		if length >= cycleTime {
+			return cycleTime, 1
+		}
+		start %= cycleTime
+		if start < 0 {
+			start += cycleTime
+		}
+		if end := start + length; end > cycleTime {
+			windows = append(windows, greenWindow{start, cycleTime}, greenWindow{0, end - cycleTime})
+		} else {
+			windows = append(windows, greenWindow{start, end})
+		}
+	}
+
+	sort.Slice(windows, func(i, j int) bool { return windows[i].start < windows[j].start })
+	var merged []greenWindow
+	for _, window := range windows {
+		if window.end <= window.start {
+			continue
+		}
+		if last := len(merged) - 1; last >= 0 && window.start <= merged[last].end {
+			if window.end > merged[last].end {
+				merged[last].end = window.end
+			}
+			continue
+		}
+		merged = append(merged, window)
+	}
+
+	var total time.Duration
+	for _, window := range merged {
+		total += window.end - window.start
+	}
+	count := len(merged)
+	if cycleTime > 0 && count > 1 && merged[0].start == 0 && merged[count-1].end == cycleTime {
+		count--
+	}
+	return total, count
+}
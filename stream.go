@@ -0,0 +1,384 @@
+package ptvvisum
+
+import "io"
+
+// Visitor holds the callbacks invoked by StreamPTV and the encoding of the streamed file.
+// Every callback is optional: rows of sections without a callback are not parsed into typed records.
+// Returning an error from a callback stops reading and StreamPTV returns that error
+type Visitor struct {
+	// Encoding of the file. If empty it is detected by the byte order mark and files without one are read as UTF-8
+	Encoding Encoding
+
+	// OnSection is called for every section header before its rows are visited
+	OnSection func(section Section) error
+	// OnRow is called with the raw values of every data row, including rows of sections without typed support
+	OnRow func(section Section, values []string) error
+
+	OnVersion               func(version VersionSection) error
+	OnInfoLine              func(line InfoLine) error
+	OnPOICategory           func(category POICategory) error
+	OnUserAttDef            func(attribute UserAttDef) error
+	OnCalendarPeriod        func(period CalendarPeriod) error
+	OnValidDay              func(day ValidDay) error
+	OnNetwork               func(network NetworkData) error
+	OnTransportSystem       func(system TransportSystem) error
+	OnMode                  func(mode Mode) error
+	OnDemandSegment         func(segment DemandSegment) error
+	OnBlockItemType         func(itemType BlockItemType) error
+	OnFareModel             func(fareModel FareModelSection) error
+	OnVehicleUnit           func(unit VehicleUnit) error
+	OnVehicleComb           func(combination VehicleCombination) error
+	OnVehUnitToVehComb      func(mapping VehUnitToVehCombMapping) error
+	OnDirection             func(direction Direction) error
+	OnPoint                 func(point Point) error
+	OnEdge                  func(edge Edge) error
+	OnEdgeItem              func(item EdgeItem) error
+	OnFace                  func(face Face) error
+	OnFaceItem              func(item FaceItem) error
+	OnSurface               func(surface Surface) error
+	OnSurfaceItem           func(item SurfaceItem) error
+	OnNode                  func(node Node) error
+	OnZone                  func(zone Zone) error
+	OnLinkType              func(linkType LinkType) error
+	OnLink                  func(link Link) error
+	OnLinkPolyPoint         func(point LinkPolyPoint) error
+	OnTurn                  func(turn Turn) error
+	OnConnector             func(connector Connector) error
+	OnStop                  func(stop Stop) error
+	OnStopArea              func(area StopArea) error
+	OnStopPoint             func(point StopPoint) error
+	OnLine                  func(line Line) error
+	OnLineRoute             func(route LineRoute) error
+	OnLineRouteItem         func(item LineRouteItem) error
+	OnTimeProfile           func(profile TimeProfile) error
+	OnTimeProfileItem       func(item TimeProfileItem) error
+	OnVehicleJourney        func(journey VehicleJourney) error
+	OnVehicleJourneySection func(section VehicleJourneySection) error
+	OnTransferWalkTime      func(transfer TransferWalkTime) error
+	OnPOI                   func(poi POI) error // Called for the rows of every $POIOFCAT_<n> section
+	OnBlockVersion          func(version BlockVersion) error
+	OnBlock                 func(block Block) error
+	OnBlockItem             func(item BlockItem) error
+	OnLeg                   func(leg Leg) error
+	OnLane                  func(lane Lane) error
+	OnLaneTurn              func(turn LaneTurn) error
+	OnCrosswalk             func(crosswalk Crosswalk) error
+	OnSignalControl         func(control SignalControl) error
+	OnSignalControlToNode   func(item SignalControlToNode) error
+	OnSignalGroup           func(group SignalGroup) error
+	OnSignalGroupToTurn     func(item SignalGroupToTurn) error
+	OnSignalGroupToLaneTurn func(item SignalGroupToLaneTurn) error
+	OnStage                 func(stage Stage) error
+	OnSignalGroupToStage    func(item SignalGroupToStage) error
+	OnMainNode              func(mainNode MainNode) error
+	OnMainTurn              func(turn MainTurn) error
+	OnTerritory             func(territory Territory) error
+	OnScreenline            func(screenline Screenline) error
+	OnScreenlinePoint       func(point ScreenlinePoint) error
+	OnCountLocation         func(location CountLocation) error
+	OnDetector              func(detector Detector) error
+}
+
+// StreamPTV parses a PTV Visum network file and passes every record to the visitor as soon as it is read.
+// Unlike ReadPTVFromFile nothing is kept in memory, so it suits files which are too large to be loaded at once.
+// The encoding is taken from Visitor.Encoding
+func StreamPTV(reader io.Reader, visitor Visitor) error {
+	onSection := func(section *BaseSection) (bool, error) {
+		if visitor.OnSection != nil {
+			if err := visitor.OnSection(section); err != nil {
+				return false, err
+			}
+		}
+		// Rows nobody is interested in are skipped without being split,
+		// rows of sections without typed support only reach OnRow
+		if visitor.OnRow == nil && !visitor.handles(section.name) {
+			return false, nil
+		}
+		return true, nil
+	}
+
+	onRow := func(section *BaseSection, line int, values []string) error {
+		if visitor.OnRow != nil {
+			if err := visitor.OnRow(section, values); err != nil {
+				return err
+			}
+		}
+		if !visitor.handles(section.name) {
+			return nil
+		}
+		record, err := decodeRow(section, values)
+		if err != nil {
+			return newParseError(section, line, values, err)
+		}
+		return visitor.visit(record)
+	}
+
+	return scanPTV(reader, visitor.Encoding, onSection, onRow)
+}
+
+// handles reports whether the visitor has a callback for records of the named section
+func (v *Visitor) handles(name string) bool {
+	switch name {
+	case "VERSION":
+		return v.OnVersion != nil
+	case "INFO":
+		return v.OnInfoLine != nil
+	case "POICATEGORY":
+		return v.OnPOICategory != nil
+	case "USERATTDEF":
+		return v.OnUserAttDef != nil
+	case "CALENDARPERIOD":
+		return v.OnCalendarPeriod != nil
+	case "VALIDDAYS":
+		return v.OnValidDay != nil
+	case "NETWORK":
+		return v.OnNetwork != nil
+	case "TSYS":
+		return v.OnTransportSystem != nil
+	case "MODE":
+		return v.OnMode != nil
+	case "DEMANDSEGMENT":
+		return v.OnDemandSegment != nil
+	case "BLOCKITEMTYPE":
+		return v.OnBlockItemType != nil
+	case "FAREMODEL":
+		return v.OnFareModel != nil
+	case "VEHUNIT":
+		return v.OnVehicleUnit != nil
+	case "VEHCOMB":
+		return v.OnVehicleComb != nil
+	case "VEHUNITTOVEHCOMB":
+		return v.OnVehUnitToVehComb != nil
+	case "DIRECTION":
+		return v.OnDirection != nil
+	case "POINT":
+		return v.OnPoint != nil
+	case "EDGE":
+		return v.OnEdge != nil
+	case "EDGEITEM":
+		return v.OnEdgeItem != nil
+	case "FACE":
+		return v.OnFace != nil
+	case "FACEITEM":
+		return v.OnFaceItem != nil
+	case "SURFACE":
+		return v.OnSurface != nil
+	case "SURFACEITEM":
+		return v.OnSurfaceItem != nil
+	case "NODE":
+		return v.OnNode != nil
+	case "ZONE":
+		return v.OnZone != nil
+	case "LINKTYPE":
+		return v.OnLinkType != nil
+	case "LINK":
+		return v.OnLink != nil
+	case "LINKPOLY":
+		return v.OnLinkPolyPoint != nil
+	case "TURN":
+		return v.OnTurn != nil
+	case "CONNECTOR":
+		return v.OnConnector != nil
+	case "STOP":
+		return v.OnStop != nil
+	case "STOPAREA":
+		return v.OnStopArea != nil
+	case "STOPPOINT":
+		return v.OnStopPoint != nil
+	case "LINE":
+		return v.OnLine != nil
+	case "LINEROUTE":
+		return v.OnLineRoute != nil
+	case "LINEROUTEITEM":
+		return v.OnLineRouteItem != nil
+	case "TIMEPROFILE":
+		return v.OnTimeProfile != nil
+	case "TIMEPROFILEITEM":
+		return v.OnTimeProfileItem != nil
+	case "VEHJOURNEY":
+		return v.OnVehicleJourney != nil
+	case "VEHJOURNEYSECTION":
+		return v.OnVehicleJourneySection != nil
+	case "TRANSFERWALKTIMESTOPAREA":
+		return v.OnTransferWalkTime != nil
+	case "BLOCKVERSION":
+		return v.OnBlockVersion != nil
+	case "BLOCK":
+		return v.OnBlock != nil
+	case "BLOCKITEM":
+		return v.OnBlockItem != nil
+	case "LEG":
+		return v.OnLeg != nil
+	case "LANE":
+		return v.OnLane != nil
+	case "LANETURN":
+		return v.OnLaneTurn != nil
+	case "CROSSWALK":
+		return v.OnCrosswalk != nil
+	case "SIGNALCONTROL":
+		return v.OnSignalControl != nil
+	case "SIGNALCONTROLTONODE":
+		return v.OnSignalControlToNode != nil
+	case "SIGNALGROUP":
+		return v.OnSignalGroup != nil
+	case "SIGNALGROUPTOTURN":
+		return v.OnSignalGroupToTurn != nil
+	case "SIGNALGROUPTOLANETURN":
+		return v.OnSignalGroupToLaneTurn != nil
+	case "STAGE":
+		return v.OnStage != nil
+	case "SIGNALGROUPTOSTAGE":
+		return v.OnSignalGroupToStage != nil
+	case "MAINNODE":
+		return v.OnMainNode != nil
+	case "MAINTURN":
+		return v.OnMainTurn != nil
+	case "TERRITORY":
+		return v.OnTerritory != nil
+	case "SCREENLINE":
+		return v.OnScreenline != nil
+	case "SCREENLINEPOLY":
+		return v.OnScreenlinePoint != nil
+	case "COUNTLOCATION":
+		return v.OnCountLocation != nil
+	case "DETECTOR":
+		return v.OnDetector != nil
+	}
+	if _, ok := poiCategoryNo(name); ok {
+		return v.OnPOI != nil
+	}
+	return false
+}
+
+// visit passes a record produced by decodeRow to the matching callback
+func (v *Visitor) visit(record any) error {
+	switch record := record.(type) {
+	case VersionSection:
+		return v.OnVersion(record)
+	case InfoLine:
+		return v.OnInfoLine(record)
+	case POICategory:
+		return v.OnPOICategory(record)
+	case UserAttDef:
+		return v.OnUserAttDef(record)
+	case CalendarPeriod:
+		return v.OnCalendarPeriod(record)
+	case ValidDay:
+		return v.OnValidDay(record)
+	case NetworkData:
+		return v.OnNetwork(record)
+	case TransportSystem:
+		return v.OnTransportSystem(record)
+	case Mode:
+		return v.OnMode(record)
+	case DemandSegment:
+		return v.OnDemandSegment(record)
+	case BlockItemType:
+		return v.OnBlockItemType(record)
+	case FareModelSection:
+		return v.OnFareModel(record)
+	case VehicleUnit:
+		return v.OnVehicleUnit(record)
+	case VehicleCombination:
+		return v.OnVehicleComb(record)
+	case VehUnitToVehCombMapping:
+		return v.OnVehUnitToVehComb(record)
+	case Direction:
+		return v.OnDirection(record)
+	case Point:
+		return v.OnPoint(record)
+	case Edge:
+		return v.OnEdge(record)
+	case EdgeItem:
+		return v.OnEdgeItem(record)
+	case Face:
+		return v.OnFace(record)
+	case FaceItem:
+		return v.OnFaceItem(record)
+	case Surface:
+		return v.OnSurface(record)
+	case SurfaceItem:
+		return v.OnSurfaceItem(record)
+	case Node:
+		return v.OnNode(record)
+	case Zone:
+		return v.OnZone(record)
+	case LinkType:
+		return v.OnLinkType(record)
+	case Link:
+		return v.OnLink(record)
+	case LinkPolyPoint:
+		return v.OnLinkPolyPoint(record)
+	case Turn:
+		return v.OnTurn(record)
+	case Connector:
+		return v.OnConnector(record)
+	case Stop:
+		return v.OnStop(record)
+	case StopArea:
+		return v.OnStopArea(record)
+	case StopPoint:
+		return v.OnStopPoint(record)
+	case Line:
+		return v.OnLine(record)
+	case LineRoute:
+		return v.OnLineRoute(record)
+	case LineRouteItem:
+		return v.OnLineRouteItem(record)
+	case TimeProfile:
+		return v.OnTimeProfile(record)
+	case TimeProfileItem:
+		return v.OnTimeProfileItem(record)
+	case VehicleJourney:
+		return v.OnVehicleJourney(record)
+	case VehicleJourneySection:
+		return v.OnVehicleJourneySection(record)
+	case TransferWalkTime:
+		return v.OnTransferWalkTime(record)
+	case POI:
+		return v.OnPOI(record)
+	case BlockVersion:
+		return v.OnBlockVersion(record)
+	case Block:
+		return v.OnBlock(record)
+	case BlockItem:
+		return v.OnBlockItem(record)
+	case Leg:
+		return v.OnLeg(record)
+	case Lane:
+		return v.OnLane(record)
+	case LaneTurn:
+		return v.OnLaneTurn(record)
+	case Crosswalk:
+		return v.OnCrosswalk(record)
+	case SignalControl:
+		return v.OnSignalControl(record)
+	case SignalControlToNode:
+		return v.OnSignalControlToNode(record)
+	case SignalGroup:
+		return v.OnSignalGroup(record)
+	case SignalGroupToTurn:
+		return v.OnSignalGroupToTurn(record)
+	case SignalGroupToLaneTurn:
+		return v.OnSignalGroupToLaneTurn(record)
+	case Stage:
+		return v.OnStage(record)
+	case SignalGroupToStage:
+		return v.OnSignalGroupToStage(record)
+	case MainNode:
+		return v.OnMainNode(record)
+	case MainTurn:
+		return v.OnMainTurn(record)
+	case Territory:
+		return v.OnTerritory(record)
+	case Screenline:
+		return v.OnScreenline(record)
+	case ScreenlinePoint:
+		return v.OnScreenlinePoint(record)
+	case CountLocation:
+		return v.OnCountLocation(record)
+	case Detector:
+		return v.OnDetector(record)
+	}
+	return nil
+}
@@ -0,0 +1,123 @@
+package ptvvisum
+
+import (
+	"bufio"
+	"io"
+	"strings"
+	"unicode"
+)
+
+// rowTokenizer splits data rows into values following the Visum quoting rules:
+// values are separated by ";", a value enclosed in double quotes may contain ";", line breaks and doubled quotes ("").
+// Quotes inside unquoted values are taken literally, e.g. Station "Central" stays as is,
+// and so is a value whose closing quote is followed by more text, e.g. "Central" Station.
+// A quoted value may continue on the following lines, so a row is fed line by line until it is complete
+type rowTokenizer struct {
+	values   []string
+	value    strings.Builder
+	raw      strings.Builder // Current quoted value as written in the file, used when it turns out not to be quoted
+	quoted   bool            // Current value started with a quote
+	inQuotes bool            // Between the opening and the closing quote
+}
+
+// pending reports whether the last fed line ended inside a quoted value
+func (t *rowTokenizer) pending() bool {
+	return t.inQuotes
+}
+
+// feed tokenizes the next line of a row, the line terminator must be already removed.
+// It returns false while a quoted value is still open
+func (t *rowTokenizer) feed(line string) bool {
+	if t.inQuotes {
+		t.value.WriteByte('\n')
+		t.raw.WriteByte('\n')
+	} else {
+		if !strings.Contains(line, `"`) {
+			// Nothing to unquote
+			t.values = strings.Split(strings.TrimSpace(line), ";")
+			return true
+		}
+		line = strings.TrimLeftFunc(line, unicode.IsSpace)
+	}
+
+	for i := 0; i < len(line); i++ {
+		c := line[i]
+		switch {
+		case t.inQuotes && c == '"':
+			if i+1 < len(line) && line[i+1] == '"' {
+				t.value.WriteByte('"')
+				t.raw.WriteString(`""`)
+				i++
+				continue
+			}
+			t.inQuotes = false
+			// Only a quote followed by a separator or the end of line closes the value,
+			// otherwise the value was not quoted and is read literally from its opening quote
+			rest := strings.TrimLeftFunc(line[i+1:], unicode.IsSpace)
+			if rest != "" && rest[0] != ';' {
+				t.value.Reset()
+				t.value.WriteString(t.raw.String())
+				t.value.WriteByte(c)
+				t.quoted = false
+				continue
+			}
+			i = len(line) - len(rest) - 1
+		case t.inQuotes:
+			t.value.WriteByte(c)
+			t.raw.WriteByte(c)
+		case c == ';':
+			t.endValue()
+		case c == '"' && !t.quoted && strings.TrimSpace(t.value.String()) == "":
+			t.value.Reset()
+			t.raw.Reset()
+			t.raw.WriteByte(c)
+			t.quoted = true
+			t.inQuotes = true
+		default:
+			t.value.WriteByte(c)
+		}
+	}
+	if t.inQuotes {
+		return false
+	}
+	quoted := t.quoted
+	t.endValue()
+	if !quoted {
+		// Trailing spaces of the row are not part of the last value
+		last := len(t.values) - 1
+		t.values[last] = strings.TrimRightFunc(t.values[last], unicode.IsSpace)
+	}
+	return true
+}
+
+func (t *rowTokenizer) endValue() {
+	t.values = append(t.values, t.value.String())
+	t.value.Reset()
+	t.quoted = false
+}
+
+// row returns the values of the completed row and resets the tokenizer for the next one
+func (t *rowTokenizer) row() []string {
+	values := t.values
+	t.values = nil
+	return values
+}
+
+// readLine reads a single line of any length without its line terminator.
+// io.EOF is returned only when there is nothing left to read
+func readLine(r *bufio.Reader) (string, error) {
+	line, err := r.ReadString('\n')
+	if err == io.EOF && line != "" {
+		err = nil
+	}
+	line = strings.TrimSuffix(line, "\n")
+	return strings.TrimSuffix(line, "\r"), err
+}
+
+// quoteValue encloses a value in double quotes when it can't be written as is
+func quoteValue(value string) string {
+	if !strings.ContainsAny(value, ";\r\n") && !strings.HasPrefix(strings.TrimSpace(value), `"`) {
+		return value
+	}
+	return `"` + strings.ReplaceAll(value, `"`, `""`) + `"`
+}
@@ -0,0 +1,127 @@
+package ptvvisum
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// tokenize feeds the lines of a single row to a tokenizer and returns its values
+func tokenize(t *testing.T, lines ...string) []string {
+	t.Helper()
+	var tokenizer rowTokenizer
+	for i, line := range lines {
+		complete := tokenizer.feed(line)
+		if last := i == len(lines)-1; complete != last {
+			t.Fatalf("feed(%q) = %v, want %v", line, complete, last)
+		}
+	}
+	return tokenizer.row()
+}
+
+func TestRowTokenizer(t *testing.T) {
+	tests := []struct {
+		name  string
+		lines []string
+		want  []string
+	}{
+		{"plain", []string{"1;a;Central Station;1;2"}, []string{"1", "a", "Central Station", "1", "2"}},
+		{"empty values", []string{"1;;;2;"}, []string{"1", "", "", "2", ""}},
+		{"trailing spaces", []string{"1;a;b  "}, []string{"1", "a", "b"}},
+		{"separator in quotes", []string{`1;"North; platform 1";2`}, []string{"1", "North; platform 1", "2"}},
+		{"doubled quotes", []string{`1;"Station ""North""";2`}, []string{"1", `Station "North"`, "2"}},
+		{"only doubled quotes", []string{`1;"""";2`}, []string{"1", `"`, "2"}},
+		{"quoted last value", []string{`1;"a;b"`}, []string{"1", "a;b"}},
+		{"space before separator", []string{`1;"a;b" ;2`}, []string{"1", "a;b", "2"}},
+		{"quotes inside unquoted value", []string{`1;Ноябрьск-2 "Железнодорожный вокзал";2`}, []string{"1", `Ноябрьск-2 "Железнодорожный вокзал"`, "2"}},
+		{"text after closing quote", []string{`1;a;"Central" Station;1;2`}, []string{"1", "a", `"Central" Station`, "1", "2"}},
+		{"text after closing quote at end", []string{`1;"Central" Station`}, []string{"1", `"Central" Station`}},
+		{"doubled quotes before text after closing quote", []string{`1;"a""b" c;2`}, []string{"1", `"a""b" c`, "2"}},
+		{"multiline", []string{`1;"first`, "second", `third";2`}, []string{"1", "first\nsecond\nthird", "2"}},
+		{"multiline with separators", []string{`1;"a;`, `;b";2`}, []string{"1", "a;\n;b", "2"}},
+		{"multiline empty line", []string{`1;"a`, "", `b"`}, []string{"1", "a\n\nb"}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := tokenize(t, test.lines...); !reflect.DeepEqual(got, test.want) {
+				t.Errorf("got %q, want %q", got, test.want)
+			}
+		})
+	}
+}
+
+// scanRows reads a network file and returns the rows of all sections
+func scanRows(input string) ([][]string, error) {
+	var rows [][]string
+	err := scanPTV(strings.NewReader(input), EncodingAuto,
+		func(section *BaseSection) (bool, error) { return true, nil },
+		func(section *BaseSection, line int, values []string) error {
+			rows = append(rows, values)
+			return nil
+		})
+	return rows, err
+}
+
+func TestScanPTVLongLine(t *testing.T) {
+	long := strings.Repeat("x", 200*1024)
+	rows, err := scanRows("$VISION\n$NODE:NO;NAME\n1;" + long + "\n2;\"" + long + ";" + long + "\"\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 2 {
+		t.Fatalf("got %d rows, want 2", len(rows))
+	}
+	if rows[0][1] != long {
+		t.Errorf("unquoted value has length %d, want %d", len(rows[0][1]), len(long))
+	}
+	if want := long + ";" + long; rows[1][1] != want {
+		t.Errorf("quoted value has length %d, want %d", len(rows[1][1]), len(want))
+	}
+}
+
+func TestScanPTVMultilineValue(t *testing.T) {
+	rows, err := scanRows("$VISION\n$NODE:NO;NAME\n1;\"a\n\n$b\n* c\"\n2;d\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := [][]string{{"1", "a\n\n$b\n* c"}, {"2", "d"}}
+	if !reflect.DeepEqual(rows, want) {
+		t.Errorf("got %q, want %q", rows, want)
+	}
+}
+
+func TestScanPTVTextAfterClosingQuote(t *testing.T) {
+	rows, err := scanRows("$VISION\n$STOP:NO;CODE;NAME;XCOORD;YCOORD\n1;a;\"Central\" Station;1;2\n2;b;North;3;4\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := [][]string{{"1", "a", `"Central" Station`, "1", "2"}, {"2", "b", "North", "3", "4"}}
+	if !reflect.DeepEqual(rows, want) {
+		t.Errorf("got %q, want %q", rows, want)
+	}
+}
+
+func TestScanPTVUnterminatedQuote(t *testing.T) {
+	_, err := scanRows("$VISION\n$NODE:NO;NAME\n1;a\n2;\"b\n3;c\n")
+	var parseErr *ParseError
+	if !errors.As(err, &parseErr) {
+		t.Fatalf("got %v, want ParseError", err)
+	}
+	if parseErr.Line != 4 || parseErr.Section != "NODE" {
+		t.Errorf("got line %d section %s, want line 4 section NODE", parseErr.Line, parseErr.Section)
+	}
+}
+
+func TestQuoteValue(t *testing.T) {
+	for _, value := range []string{"plain", "a;b", "line\nbreak", `"quoted"`, `Station "Central"`, `"Central" Station`, ""} {
+		var tokenizer rowTokenizer
+		lines := strings.Split("1;"+quoteValue(value), "\n")
+		for _, line := range lines {
+			tokenizer.feed(line)
+		}
+		if got := tokenizer.row(); len(got) != 2 || got[1] != value {
+			t.Errorf("quoteValue(%q) reads back as %q", value, got)
+		}
+	}
+}
@@ -0,0 +1,109 @@
+package ptvvisum
+
+import (
+	"container/heap"
+	"time"
+)
+
+// TransferGraph is a directed graph of stop areas weighted by the walk times of one transport system
+type TransferGraph struct {
+	TSysCode string
+	// Edges maps a stop area to the stop areas reachable from it and the walk time to each of them,
+	// a stop area maps to itself when the file gives a walk time within it
+	Edges map[int]map[int]time.Duration
+}
+
+func newTransferGraph(tsysCode string) *TransferGraph {
+	return &TransferGraph{TSysCode: tsysCode, Edges: make(map[int]map[int]time.Duration)}
+}
+
+// addTransfer adds a transfer as an edge, the shortest time is kept when a pair of stop areas is listed twice.
+// Transfers within a stop area are kept as loops
+func (g *TransferGraph) addTransfer(transfer TransferWalkTime) {
+	targets, ok := g.Edges[transfer.FromStopAreaNo]
+	if !ok {
+		targets = make(map[int]time.Duration)
+		g.Edges[transfer.FromStopAreaNo] = targets
+	}
+	if current, ok := targets[transfer.ToStopAreaNo]; !ok || transfer.Time.Duration < current {
+		targets[transfer.ToStopAreaNo] = transfer.Time.Duration
+	}
+}
+
+// GetReachableStopAreas returns the stop areas reachable from a stop area within the given walk time,
+// including the stop area itself, with the minimum walk time to each of them
+func (g *TransferGraph) GetReachableStopAreas(fromStopAreaNo int, within time.Duration) map[int]time.Duration {
+	return g.shortestTimes(fromStopAreaNo, within, 0)
+}
+
+// GetMinTransferTime returns the minimum walk time from one stop area to another, possibly over several transfers.
+// Within a stop area it is the walk time given for the stop area itself, 0 if there is none.
+// A stop area without any transfer in the graph is not found, not even from itself
+func (g *TransferGraph) GetMinTransferTime(fromStopAreaNo, toStopAreaNo int) (time.Duration, bool) {
+	if fromStopAreaNo == toStopAreaNo {
+		if walkTime, ok := g.Edges[fromStopAreaNo][toStopAreaNo]; ok {
+			return walkTime, true
+		}
+		return 0, g.hasStopArea(fromStopAreaNo)
+	}
+	times := g.shortestTimes(fromStopAreaNo, -1, toStopAreaNo)
+	walkTime, found := times[toStopAreaNo]
+	return walkTime, found
+}
+
+// hasStopArea reports whether a transfer starts or ends at the stop area
+func (g *TransferGraph) hasStopArea(stopAreaNo int) bool {
+	if _, ok := g.Edges[stopAreaNo]; ok {
+		return true
+	}
+	for _, targets := range g.Edges {
+		if _, ok := targets[stopAreaNo]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// shortestTimes runs Dijkstra's algorithm from a stop area. The search ends at stop areas farther than limit
+// unless limit is negative, and once target is settled unless target is 0
+func (g *TransferGraph) shortestTimes(from int, limit time.Duration, target int) map[int]time.Duration {
+	settled := make(map[int]time.Duration)
+	queue := &transferQueue{{stopAreaNo: from}}
+	for queue.Len() > 0 {
+		current := heap.Pop(queue).(transferQueueItem)
+		if _, done := settled[current.stopAreaNo]; done {
+			continue
+		}
+		settled[current.stopAreaNo] = current.time
+		if current.stopAreaNo == target {
+			break
+		}
+		for next, walkTime := range g.Edges[current.stopAreaNo] {
+			total := current.time + walkTime
+			if _, done := settled[next]; done || (limit >= 0 && total > limit) {
+				continue
+			}
+			heap.Push(queue, transferQueueItem{stopAreaNo: next, time: total})
+		}
+	}
+	return settled
+}
+
+type transferQueueItem struct {
+	stopAreaNo int
+	time       time.Duration
+}
+
+// transferQueue is a min-heap of stop areas ordered by walk time
+type transferQueue []transferQueueItem
+
+func (q transferQueue) Len() int           { return len(q) }
+func (q transferQueue) Less(i, j int) bool { return q[i].time < q[j].time }
+func (q transferQueue) Swap(i, j int)      { q[i], q[j] = q[j], q[i] }
+func (q *transferQueue) Push(x any)        { *q = append(*q, x.(transferQueueItem)) }
+func (q *transferQueue) Pop() any {
+	old := *q
+	item := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return item
+}
@@ -0,0 +1,42 @@
+package ptvvisum
+
+import (
+	"testing"
+	"time"
+)
+
+func TestGetMinTransferTime(t *testing.T) {
+	walk := func(from, to int, minutes time.Duration) TransferWalkTime {
+		return TransferWalkTime{FromStopAreaNo: from, ToStopAreaNo: to, TSysCode: "W", Time: Duration{Duration: minutes * time.Minute}}
+	}
+	section := TransferWalkTimeStopAreaSection{Transfers: []TransferWalkTime{
+		walk(1, 1, 2),
+		walk(1, 2, 5),
+		walk(2, 3, 4),
+		walk(1, 3, 10),
+		walk(3, 1, 1),
+	}}
+	graph := section.GetTransferGraph("W")
+
+	tests := []struct {
+		from, to int
+		walkTime time.Duration
+		found    bool
+	}{
+		{1, 1, 2 * time.Minute, true},
+		{2, 2, 0, true}, // Known from its transfers, no walk time of its own
+		{3, 3, 0, true}, // Only the target of transfers
+		{1, 3, 9 * time.Minute, true},
+		{3, 2, 6 * time.Minute, true},
+		{2, 1, 5 * time.Minute, true},
+		{4, 4, 0, false}, // Unknown stop area
+		{1, 4, 0, false},
+		{4, 1, 0, false},
+	}
+	for _, test := range tests {
+		walkTime, found := graph.GetMinTransferTime(test.from, test.to)
+		if walkTime != test.walkTime || found != test.found {
+			t.Errorf("GetMinTransferTime(%d, %d) = %v, %v, want %v, %v", test.from, test.to, walkTime, found, test.walkTime, test.found)
+		}
+	}
+}
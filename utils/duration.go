@@ -0,0 +1,196 @@
+package utils
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ClockNotation is the notation of times like 00:01:30 or 1d 06:30:00
+const ClockNotation = "hh:mm:ss"
+
+// durationUnits lists the units of Visum times from the largest one
+var durationUnits = []struct {
+	name string
+	size time.Duration
+}{
+	{"d", 24 * time.Hour},
+	{"h", time.Hour},
+	{"min", time.Minute},
+	{"s", time.Second},
+}
+
+// ParseDuration parses a time in any Visum notation:
+// 72s, 4.00s, 1min 30s, 1h 5min, 2.5h, 00:01:30, 06:30 and day offsets like 1d 06:30:00.
+// Plain numbers are seconds
+func ParseDuration(value string) (time.Duration, error) {
+	duration, _, err := ParseDurationNotation(value)
+	return duration, err
+}
+
+// ParseDurationNotation is like ParseDuration and also returns the notation of the value to be passed to FormatDuration:
+// the largest unit (d, h, min or s), ClockNotation or empty for plain numbers
+func ParseDurationNotation(value string) (time.Duration, string, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, "", fmt.Errorf("empty duration")
+	}
+	negative := strings.HasPrefix(value, "-")
+	rest := strings.TrimPrefix(value, "-")
+	if rest == "" {
+		return 0, "", fmt.Errorf("invalid duration %q", value)
+	}
+
+	var duration time.Duration
+	notation := ""
+	next := 0 // Index in durationUnits the following part may start from
+	for rest != "" {
+		part := rest
+		if end := strings.IndexByte(rest, ' '); end >= 0 {
+			part, rest = rest[:end], strings.TrimSpace(rest[end:])
+		} else {
+			rest = ""
+		}
+
+		if strings.Contains(part, ":") {
+			// The clock is the last part, only days may precede it
+			if rest != "" || next > 1 {
+				return 0, "", fmt.Errorf("invalid duration %q", value)
+			}
+			clock, err := parseClock(part)
+			if err != nil {
+				return 0, "", fmt.Errorf("invalid duration %q: %w", value, err)
+			}
+			duration += clock
+			notation = ClockNotation
+			break
+		}
+
+		numberPart, unit := splitDurationPart(part)
+		number, err := strconv.ParseFloat(strings.Replace(numberPart, ",", ".", 1), 64)
+		if err != nil {
+			return 0, "", fmt.Errorf("invalid duration %q: %w", value, err)
+		}
+		if unit == "" {
+			// Plain number of seconds
+			if next != 0 || rest != "" {
+				return 0, "", fmt.Errorf("invalid duration %q", value)
+			}
+			duration = time.Duration(math.Round(number * float64(time.Second)))
+			break
+		}
+		i := durationUnitIndex(unit)
+		if i < 0 {
+			return 0, "", fmt.Errorf("invalid duration %q: unknown unit %q", value, unit)
+		}
+		if i < next {
+			return 0, "", fmt.Errorf("invalid duration %q: units must go from the largest one", value)
+		}
+		duration += time.Duration(math.Round(number * float64(durationUnits[i].size)))
+		if notation == "" {
+			notation = unit
+		}
+		next = i + 1
+	}
+	if negative {
+		duration = -duration
+	}
+	return duration, notation, nil
+}
+
+// FormatDuration formats a duration in the given notation as returned by ParseDurationNotation.
+// Unit notations start from the given unit and skip zero parts, e.g. 1h 5min, ClockNotation gives 1d 06:30:00
+// and an empty notation gives the plain number of seconds
+func FormatDuration(duration time.Duration, notation string) string {
+	sign := ""
+	if duration < 0 {
+		sign = "-"
+		duration = -duration
+	}
+	if notation == "" {
+		return sign + strconv.FormatFloat(duration.Seconds(), 'f', -1, 64)
+	}
+	if notation == ClockNotation {
+		days := duration / (24 * time.Hour)
+		duration -= days * 24 * time.Hour
+		hours := duration / time.Hour
+		minutes := (duration - hours*time.Hour) / time.Minute
+		seconds := (duration - hours*time.Hour - minutes*time.Minute).Seconds()
+		clock := fmt.Sprintf("%02d:%02d:%02d", hours, minutes, int(seconds))
+		if fraction := seconds - math.Trunc(seconds); fraction != 0 {
+			clock += strings.TrimPrefix(strconv.FormatFloat(fraction, 'f', -1, 64), "0")
+		}
+		if days > 0 {
+			return sign + strconv.FormatInt(int64(days), 10) + "d " + clock
+		}
+		return sign + clock
+	}
+
+	first := durationUnitIndex(notation)
+	if first < 0 {
+		return sign + strconv.FormatFloat(duration.Seconds(), 'f', -1, 64) + "s"
+	}
+	var parts []string
+	for _, unit := range durationUnits[first:] {
+		if unit.size == time.Second {
+			if duration != 0 || len(parts) == 0 {
+				parts = append(parts, strconv.FormatFloat(duration.Seconds(), 'f', -1, 64)+unit.name)
+			}
+			break
+		}
+		if n := duration / unit.size; n != 0 {
+			parts = append(parts, strconv.FormatInt(int64(n), 10)+unit.name)
+			duration -= n * unit.size
+		}
+	}
+	return sign + strings.Join(parts, " ")
+}
+
+// parseClock parses hh:mm:ss or hh:mm, hours may exceed 23 and seconds may have a fraction
+func parseClock(value string) (time.Duration, error) {
+	fields := strings.Split(value, ":")
+	if len(fields) != 2 && len(fields) != 3 {
+		return 0, fmt.Errorf("expected hh:mm:ss")
+	}
+	hours, err := strconv.Atoi(fields[0])
+	if err != nil {
+		return 0, err
+	}
+	minutes, err := strconv.Atoi(fields[1])
+	if err != nil {
+		return 0, err
+	}
+	seconds := 0.0
+	if len(fields) == 3 {
+		seconds, err = strconv.ParseFloat(fields[2], 64)
+		if err != nil {
+			return 0, err
+		}
+	}
+	if hours < 0 || minutes < 0 || minutes > 59 || seconds < 0 || seconds >= 60 {
+		return 0, fmt.Errorf("clock out of range")
+	}
+	return time.Duration(hours)*time.Hour + time.Duration(minutes)*time.Minute +
+		time.Duration(math.Round(seconds*float64(time.Second))), nil
+}
+
+// durationUnitIndex returns the position of the unit in durationUnits or -1 if it is unknown
+func durationUnitIndex(unit string) int {
+	for i, durationUnit := range durationUnits {
+		if strings.EqualFold(durationUnit.name, unit) {
+			return i
+		}
+	}
+	return -1
+}
+
+// splitDurationPart splits values like 30s into the number and the unit
+func splitDurationPart(value string) (string, string) {
+	i := 0
+	for i < len(value) && (IsDigit(rune(value[i])) || value[i] == '.' || value[i] == ',') {
+		i++
+	}
+	return value[:i], value[i:]
+}
@@ -0,0 +1,121 @@
+package utils
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseDurationNotation(t *testing.T) {
+	tests := []struct {
+		value    string
+		duration time.Duration
+		notation string
+	}{
+		{"72", 72 * time.Second, ""},
+		{"4.5", 4500 * time.Millisecond, ""},
+		{"-30", -30 * time.Second, ""},
+		{"72s", 72 * time.Second, "s"},
+		{"4.00s", 4 * time.Second, "s"},
+		{"0,25s", 250 * time.Millisecond, "s"},
+		{"1min 30s", 90 * time.Second, "min"},
+		{"1min 30.5s", 90500 * time.Millisecond, "min"},
+		{"1h 5min", 65 * time.Minute, "h"},
+		{"2.5h", 150 * time.Minute, "h"},
+		{"1d 2h", 26 * time.Hour, "d"},
+		{"-1h 5min", -65 * time.Minute, "h"},
+		{"1MIN", time.Minute, "MIN"},
+		{"00:01:30", 90 * time.Second, ClockNotation},
+		{"00:00:01.5", 1500 * time.Millisecond, ClockNotation},
+		{"06:30", 6*time.Hour + 30*time.Minute, ClockNotation},
+		{"25:00:00", 25 * time.Hour, ClockNotation},
+		{"1d 06:30:00", 30*time.Hour + 30*time.Minute, ClockNotation},
+		{"-1d 06:30:00", -(30*time.Hour + 30*time.Minute), ClockNotation},
+		{" 72s ", 72 * time.Second, "s"},
+	}
+	for _, test := range tests {
+		duration, notation, err := ParseDurationNotation(test.value)
+		if err != nil {
+			t.Errorf("ParseDurationNotation(%q): %v", test.value, err)
+			continue
+		}
+		if duration != test.duration || notation != test.notation {
+			t.Errorf("ParseDurationNotation(%q) = %v, %q, want %v, %q", test.value, duration, notation, test.duration, test.notation)
+		}
+		if plain, err := ParseDuration(test.value); err != nil || plain != test.duration {
+			t.Errorf("ParseDuration(%q) = %v, %v, want %v", test.value, plain, err, test.duration)
+		}
+	}
+}
+
+func TestParseDurationInvalid(t *testing.T) {
+	for _, value := range []string{
+		"",
+		"   ",
+		"-",
+		"abc",
+		"1x",
+		"1.2.3s",
+		"30s 1min",    // Units must go from the largest one
+		"1min 1min",   // A unit must not repeat
+		"1min 30",     // Plain seconds cannot follow a unit
+		"30 1min",     // Nor precede one
+		"1h 06:30:00", // Only days may precede a clock
+		"06:30:00 1d", // The clock is the last part
+		"06:60:00",    // Minutes out of range
+		"06:30:60",    // Seconds out of range
+		"06:30:00:00", // Too many fields
+		"aa:30",       // Hours not a number
+		"06:-5",       // Negative minutes
+		"1d 06:xx:00", // Minutes not a number
+		"1d 06:30:1s", // Seconds not a number
+	} {
+		if duration, err := ParseDuration(value); err == nil {
+			t.Errorf("ParseDuration(%q) = %v, want error", value, duration)
+		}
+	}
+}
+
+func TestFormatDuration(t *testing.T) {
+	tests := []struct {
+		duration time.Duration
+		notation string
+		want     string
+	}{
+		{72 * time.Second, "", "72"},
+		{1500 * time.Millisecond, "", "1.5"},
+		{-30 * time.Second, "", "-30"},
+		{72 * time.Second, "s", "72s"},
+		{72 * time.Second, "min", "1min 12s"},
+		{90500 * time.Millisecond, "min", "1min 30.5s"},
+		{time.Hour, "min", "60min"},
+		{65 * time.Minute, "h", "1h 5min"},
+		{time.Hour, "h", "1h"},
+		{0, "h", "0s"},
+		{-65 * time.Minute, "h", "-1h 5min"},
+		{26*time.Hour + 30*time.Second, "d", "1d 2h 30s"},
+		{90 * time.Second, ClockNotation, "00:01:30"},
+		{1500 * time.Millisecond, ClockNotation, "00:00:01.5"},
+		{30*time.Hour + 30*time.Minute, ClockNotation, "1d 06:30:00"},
+		{-(30*time.Hour + 30*time.Minute), ClockNotation, "-1d 06:30:00"},
+		{0, ClockNotation, "00:00:00"},
+		{90 * time.Second, "unknown", "90s"},
+	}
+	for _, test := range tests {
+		if got := FormatDuration(test.duration, test.notation); got != test.want {
+			t.Errorf("FormatDuration(%v, %q) = %q, want %q", test.duration, test.notation, got, test.want)
+		}
+	}
+}
+
+func TestFormatDurationRoundTrip(t *testing.T) {
+	for _, value := range []string{"72", "72s", "1min 30s", "1h 5min", "1d 2h", "00:01:30", "1d 06:30:00", "-1h 5min"} {
+		duration, notation, err := ParseDurationNotation(value)
+		if err != nil {
+			t.Errorf("ParseDurationNotation(%q): %v", value, err)
+			continue
+		}
+		if got := FormatDuration(duration, notation); got != value {
+			t.Errorf("FormatDuration(ParseDurationNotation(%q)) = %q", value, got)
+		}
+	}
+}
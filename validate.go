@@ -0,0 +1,819 @@
+package ptvvisum
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Issue is a reference from a record to a record which does not exist
+type Issue struct {
+	Section string // Section of the referencing record without "$", e.g. "LINK"
+	Record  string // Key of the referencing record as written in the file, e.g. "12;100;101" for a link
+	Column  string // Column holding the reference, e.g. "FROMNODENO"
+	Value   string // Referenced key
+	Target  string // Section expected to contain the referenced record, e.g. "NODE"
+}
+
+func (issue Issue) String() string {
+	return fmt.Sprintf("$%s %s: %s %s not found in $%s", issue.Section, issue.Record, issue.Column, issue.Value, issue.Target)
+}
+
+// Validate checks that the records of the data refer to existing records:
+// nodes of links, links of turns, zones and nodes of connectors, transport systems of TSYSSET columns,
+// types of links, surfaces of zones, edges of faces, vehicle units and combinations of $VEHUNITTOVEHCOMB
+// the stop hierarchy with the nodes and links stop points lie on and the line hierarchy with the nodes and stop points of routes.
+// References to a section which is not loaded (e.g. filtered out by ReadOptions.Sections) are not checked.
+// Issues are sorted by the order Visum writes the sections in, within a section they follow the records
+func Validate(data *PTVData) []Issue {
+	v := &validator{PTVData: data}
+	if data.TSys != nil {
+		v.tsysCodes = make(map[string]bool, len(data.TSys.Systems))
+		for _, system := range data.TSys.Systems {
+			v.tsysCodes[system.Code] = true
+		}
+	}
+
+	v.checkModes()
+	v.checkVehUnits()
+	v.checkVehUnitToVehCombs()
+	v.checkFaceItems()
+	v.checkNodes()
+	v.checkZones()
+	v.checkTerritories()
+	v.checkLinkTypes()
+	v.checkLinks()
+	v.checkTurns()
+	v.checkMainTurns()
+	v.checkConnectors()
+	v.checkScreenlinePolys()
+	v.checkCountLocations()
+	v.checkStopAreas()
+	v.checkStopPoints()
+	v.checkLines()
+	v.checkLineRoutes()
+	v.checkLineRouteItems()
+	v.checkTimeProfiles()
+	v.checkTimeProfileItems()
+	v.checkVehJourneys()
+	v.checkVehJourneySections()
+	v.checkTransferWalkTimes()
+	v.checkBlocks()
+	v.checkBlockItems()
+	v.checkLegs()
+	v.checkLanes()
+	v.checkLaneTurns()
+	v.checkCrosswalks()
+	v.checkSignalControlToNodes()
+	v.checkSignalGroups()
+	v.checkStages()
+	v.checkSignalGroupToStages()
+	v.checkSignalGroupToTurns()
+	v.checkSignalGroupToLaneTurns()
+	v.checkDetectors()
+	v.checkPOIs()
+
+	sortIssues(data, v.issues)
+	return v.issues
+}
+
+// validator collects the issues of Validate, each check method covers the references of one section
+type validator struct {
+	*PTVData
+	issues    []Issue
+	tsysCodes map[string]bool // Codes of $TSYS, nil when the section is not loaded
+}
+
+// report adds an issue for the reference of a record
+func (v *validator) report(section, record, column, value, target string) {
+	v.issues = append(v.issues, Issue{Section: section, Record: record, Column: column, Value: value, Target: target})
+}
+
+// checkTSysSet reports the codes of a TSYSSET column missing from $TSYS
+func (v *validator) checkTSysSet(section, record string, codes []string) {
+	if v.tsysCodes == nil {
+		return
+	}
+	for _, code := range codes {
+		if code = strings.TrimSpace(code); code != "" && !v.tsysCodes[code] {
+			v.report(section, record, "TSYSSET", code, "TSYS")
+		}
+	}
+}
+
+// checkJunction reports the node or main node of legs and lanes which does not exist, they belong to either of them
+func (v *validator) checkJunction(section, record string, key JunctionKey) {
+	if v.Node != nil && key.NodeNo != 0 {
+		if _, ok := v.Node.GetNodeByID(key.NodeNo); !ok {
+			v.report(section, record, "NODENO", formatInt(key.NodeNo), "NODE")
+		}
+	}
+	if v.MainNode != nil && key.MainNodeNo != 0 {
+		if _, ok := v.MainNode.GetMainNodeByID(key.MainNodeNo); !ok {
+			v.report(section, record, "MAINNODENO", formatInt(key.MainNodeNo), "MAINNODE")
+		}
+	}
+}
+
+func (v *validator) checkModes() {
+	if v.Mode == nil {
+		return
+	}
+	for _, mode := range v.Mode.Modes {
+		v.checkTSysSet("MODE", mode.Code, mode.TSysSet)
+	}
+}
+
+func (v *validator) checkVehUnits() {
+	if v.VehUnit == nil {
+		return
+	}
+	for _, unit := range v.VehUnit.Units {
+		v.checkTSysSet("VEHUNIT", formatInt(unit.No), strings.Split(unit.TSysSet, ","))
+	}
+}
+
+func (v *validator) checkVehUnitToVehCombs() {
+	if v.VehUnitToVehComb == nil {
+		return
+	}
+	var units, combinations map[int]bool
+	if v.VehUnit != nil {
+		units = make(map[int]bool, len(v.VehUnit.Units))
+		for _, unit := range v.VehUnit.Units {
+			units[unit.No] = true
+		}
+	}
+	if v.VehComb != nil {
+		combinations = make(map[int]bool, len(v.VehComb.Combinations))
+		for _, combination := range v.VehComb.Combinations {
+			combinations[combination.No] = true
+		}
+	}
+	for _, mapping := range v.VehUnitToVehComb.Mappings {
+		record := formatInt(mapping.VehCombNo) + ";" + formatInt(mapping.VehUnitNo)
+		if combinations != nil && !combinations[mapping.VehCombNo] {
+			v.report("VEHUNITTOVEHCOMB", record, "VEHCOMBNO", formatInt(mapping.VehCombNo), "VEHCOMB")
+		}
+		if units != nil && !units[mapping.VehUnitNo] {
+			v.report("VEHUNITTOVEHCOMB", record, "VEHUNITNO", formatInt(mapping.VehUnitNo), "VEHUNIT")
+		}
+	}
+}
+
+func (v *validator) checkFaceItems() {
+	if v.FaceItem == nil || v.Edge == nil {
+		return
+	}
+	for _, item := range v.FaceItem.Items {
+		if _, ok := v.Edge.GetEdgeByID(item.EdgeID); !ok {
+			v.report("FACEITEM", formatInt(item.FaceID)+";"+formatInt(item.Index), "EDGEID", formatInt(item.EdgeID), "EDGE")
+		}
+	}
+}
+
+func (v *validator) checkNodes() {
+	if v.Node == nil || v.MainNode == nil {
+		return
+	}
+	for _, node := range v.Node.Nodes {
+		if node.MainNodeNo == 0 {
+			continue
+		}
+		if _, ok := v.MainNode.GetMainNodeByID(node.MainNodeNo); !ok {
+			v.report("NODE", formatInt(node.ID), "MAINNODENO", formatInt(node.MainNodeNo), "MAINNODE")
+		}
+	}
+}
+
+func (v *validator) checkZones() {
+	if v.Zone == nil || v.Surface == nil {
+		return
+	}
+	for _, zone := range v.Zone.Zones {
+		// Zones without boundary have no surface
+		if zone.SurfaceID != 0 && !v.Surface.Contains(zone.SurfaceID) {
+			v.report("ZONE", formatInt(zone.No), "SURFACEID", formatInt(zone.SurfaceID), "SURFACE")
+		}
+	}
+}
+
+func (v *validator) checkTerritories() {
+	if v.Territory == nil || v.Surface == nil {
+		return
+	}
+	for _, territory := range v.Territory.Territories {
+		if territory.SurfaceID != 0 && !v.Surface.Contains(territory.SurfaceID) {
+			v.report("TERRITORY", formatInt(territory.No), "SURFACEID", formatInt(territory.SurfaceID), "SURFACE")
+		}
+	}
+}
+
+func (v *validator) checkLinkTypes() {
+	if v.LinkType == nil {
+		return
+	}
+	for _, linkType := range v.LinkType.LinkTypes {
+		v.checkTSysSet("LINKTYPE", formatInt(linkType.No), strings.Split(linkType.TSysSet, ","))
+	}
+}
+
+func (v *validator) checkLinks() {
+	if v.Link == nil {
+		return
+	}
+	for _, link := range v.Link.Links {
+		record := formatInt(link.No) + ";" + formatInt(link.FromNodeNo) + ";" + formatInt(link.ToNodeNo)
+		if v.Node != nil {
+			if _, ok := v.Node.GetNodeByID(link.FromNodeNo); !ok {
+				v.report("LINK", record, "FROMNODENO", formatInt(link.FromNodeNo), "NODE")
+			}
+			if _, ok := v.Node.GetNodeByID(link.ToNodeNo); !ok {
+				v.report("LINK", record, "TONODENO", formatInt(link.ToNodeNo), "NODE")
+			}
+		}
+		if v.LinkType != nil {
+			if _, ok := v.LinkType.GetLinkTypeByID(link.TypeNo); !ok {
+				v.report("LINK", record, "TYPENO", formatInt(link.TypeNo), "LINKTYPE")
+			}
+		}
+		v.checkTSysSet("LINK", record, strings.Split(link.TSysSet, ","))
+	}
+}
+
+func (v *validator) checkTurns() {
+	if v.Turn == nil {
+		return
+	}
+	for _, turn := range v.Turn.Turns {
+		record := formatInt(turn.FromNodeNo) + ";" + formatInt(turn.ViaNodeNo) + ";" + formatInt(turn.ToNodeNo)
+		if v.Link != nil {
+			if !hasDirectedLink(v.Link, turn.FromNodeNo, turn.ViaNodeNo) {
+				v.report("TURN", record, "FROMNODENO;VIANODENO", formatInt(turn.FromNodeNo)+";"+formatInt(turn.ViaNodeNo), "LINK")
+			}
+			if !hasDirectedLink(v.Link, turn.ViaNodeNo, turn.ToNodeNo) {
+				v.report("TURN", record, "VIANODENO;TONODENO", formatInt(turn.ViaNodeNo)+";"+formatInt(turn.ToNodeNo), "LINK")
+			}
+		}
+		v.checkTSysSet("TURN", record, strings.Split(turn.TSysSet, ","))
+	}
+}
+
+func (v *validator) checkMainTurns() {
+	if v.MainTurn == nil {
+		return
+	}
+	for _, turn := range v.MainTurn.MainTurns {
+		record := formatInt(turn.MainNodeNo) + ";" + formatInt(turn.FromLinkNo) + ";" + formatInt(turn.ToLinkNo)
+		if v.MainNode != nil {
+			if _, ok := v.MainNode.GetMainNodeByID(turn.MainNodeNo); !ok {
+				v.report("MAINTURN", record, "MAINNODENO", formatInt(turn.MainNodeNo), "MAINNODE")
+			}
+		}
+		if v.Link != nil {
+			if _, ok := v.Link.GetLinkByID(turn.FromLinkNo); !ok {
+				v.report("MAINTURN", record, "FROMLINKNO", formatInt(turn.FromLinkNo), "LINK")
+			}
+			if _, ok := v.Link.GetLinkByID(turn.ToLinkNo); !ok {
+				v.report("MAINTURN", record, "TOLINKNO", formatInt(turn.ToLinkNo), "LINK")
+			}
+		}
+		v.checkTSysSet("MAINTURN", record, strings.Split(turn.TSysSet, ","))
+	}
+}
+
+func (v *validator) checkConnectors() {
+	if v.Connector == nil {
+		return
+	}
+	for _, connector := range v.Connector.Connectors {
+		record := formatInt(connector.ZoneNo) + ";" + formatInt(connector.NodeNo) + ";" + connector.Direction
+		if v.Zone != nil {
+			if _, ok := v.Zone.GetZoneByID(connector.ZoneNo); !ok {
+				v.report("CONNECTOR", record, "ZONENO", formatInt(connector.ZoneNo), "ZONE")
+			}
+		}
+		if v.Node != nil {
+			if _, ok := v.Node.GetNodeByID(connector.NodeNo); !ok {
+				v.report("CONNECTOR", record, "NODENO", formatInt(connector.NodeNo), "NODE")
+			}
+		}
+		v.checkTSysSet("CONNECTOR", record, strings.Split(connector.TSysSet, ","))
+	}
+}
+
+func (v *validator) checkScreenlinePolys() {
+	if v.ScreenlinePoly == nil || v.Screenline == nil {
+		return
+	}
+	for _, point := range v.ScreenlinePoly.Points {
+		if _, ok := v.Screenline.GetScreenlineByID(point.ScreenlineNo); !ok {
+			record := formatInt(point.ScreenlineNo) + ";" + formatInt(point.Index)
+			v.report("SCREENLINEPOLY", record, "SCREENLINENO", formatInt(point.ScreenlineNo), "SCREENLINE")
+		}
+	}
+}
+
+func (v *validator) checkCountLocations() {
+	if v.CountLocation == nil || v.Link == nil {
+		return
+	}
+	for _, location := range v.CountLocation.CountLocations {
+		if _, ok := v.Link.GetLinkByFromNode(location.LinkNo, location.FromNodeNo); !ok {
+			v.report("COUNTLOCATION", formatInt(location.No), "LINKNO;FROMNODENO", formatInt(location.LinkNo)+";"+formatInt(location.FromNodeNo), "LINK")
+		}
+	}
+}
+
+func (v *validator) checkStopAreas() {
+	if v.StopArea == nil {
+		return
+	}
+	for _, area := range v.StopArea.StopAreas {
+		if v.Stop != nil {
+			if _, ok := v.Stop.GetStopByID(area.StopNo); !ok {
+				v.report("STOPAREA", formatInt(area.No), "STOPNO", formatInt(area.StopNo), "STOP")
+			}
+		}
+		if v.Node != nil && area.NodeNo != 0 {
+			if _, ok := v.Node.GetNodeByID(area.NodeNo); !ok {
+				v.report("STOPAREA", formatInt(area.No), "NODENO", formatInt(area.NodeNo), "NODE")
+			}
+		}
+	}
+}
+
+func (v *validator) checkStopPoints() {
+	if v.StopPoint == nil {
+		return
+	}
+	for _, point := range v.StopPoint.StopPoints {
+		record := formatInt(point.No)
+		if v.StopArea != nil {
+			if _, ok := v.StopArea.GetStopAreaByID(point.StopAreaNo); !ok {
+				v.report("STOPPOINT", record, "STOPAREANO", formatInt(point.StopAreaNo), "STOPAREA")
+			}
+		}
+		if point.IsNodeBased() {
+			if v.Node != nil {
+				if _, ok := v.Node.GetNodeByID(point.NodeNo); !ok {
+					v.report("STOPPOINT", record, "NODENO", formatInt(point.NodeNo), "NODE")
+				}
+			}
+		} else if v.Link != nil {
+			if _, ok := v.Link.GetLinkByFromNode(point.LinkNo, point.FromNodeNo); !ok {
+				v.report("STOPPOINT", record, "LINKNO;FROMNODENO", formatInt(point.LinkNo)+";"+formatInt(point.FromNodeNo), "LINK")
+			}
+		}
+		v.checkTSysSet("STOPPOINT", record, strings.Split(point.TSysSet, ","))
+	}
+}
+
+func (v *validator) checkLines() {
+	if v.Line == nil {
+		return
+	}
+	for _, line := range v.Line.Lines {
+		if v.TSys != nil {
+			if _, ok := v.TSys.GetTransportSystemByCode(line.TSysCode); !ok {
+				v.report("LINE", line.Name, "TSYSCODE", line.TSysCode, "TSYS")
+			}
+		}
+		if v.VehComb != nil && line.VehCombNo != 0 {
+			if _, ok := v.VehComb.GetVehicleCombinationByID(line.VehCombNo); !ok {
+				v.report("LINE", line.Name, "VEHCOMBNO", formatInt(line.VehCombNo), "VEHCOMB")
+			}
+		}
+	}
+}
+
+func (v *validator) checkLineRoutes() {
+	if v.LineRoute == nil {
+		return
+	}
+	for _, route := range v.LineRoute.LineRoutes {
+		record := route.LineName + ";" + route.Name + ";" + route.DirectionCode
+		if v.Line != nil {
+			if _, ok := v.Line.GetLineByName(route.LineName); !ok {
+				v.report("LINEROUTE", record, "LINENAME", route.LineName, "LINE")
+			}
+		}
+		if v.Direction != nil {
+			if _, ok := v.Direction.GetDirectionByCode(route.DirectionCode); !ok {
+				v.report("LINEROUTE", record, "DIRECTIONCODE", route.DirectionCode, "DIRECTION")
+			}
+		}
+	}
+}
+
+func (v *validator) checkLineRouteItems() {
+	if v.LineRouteItem == nil {
+		return
+	}
+	for _, item := range v.LineRouteItem.Items {
+		key := item.LineRouteKey()
+		record := key.LineName + ";" + key.LineRouteName + ";" + key.DirectionCode + ";" + formatInt(item.Index)
+		if v.LineRoute != nil {
+			if _, ok := v.LineRoute.GetLineRoute(key); !ok {
+				v.report("LINEROUTEITEM", record, "LINENAME;LINEROUTENAME;DIRECTIONCODE", key.LineName+";"+key.LineRouteName+";"+key.DirectionCode, "LINEROUTE")
+			}
+		}
+		if v.Node != nil && item.NodeNo != 0 {
+			if _, ok := v.Node.GetNodeByID(item.NodeNo); !ok {
+				v.report("LINEROUTEITEM", record, "NODENO", formatInt(item.NodeNo), "NODE")
+			}
+		}
+		if v.StopPoint != nil && item.StopPointNo != 0 {
+			if _, ok := v.StopPoint.GetStopPointByID(item.StopPointNo); !ok {
+				v.report("LINEROUTEITEM", record, "STOPPOINTNO", formatInt(item.StopPointNo), "STOPPOINT")
+			}
+		}
+	}
+}
+
+func (v *validator) checkTimeProfiles() {
+	if v.TimeProfile == nil {
+		return
+	}
+	for _, profile := range v.TimeProfile.TimeProfiles {
+		key := profile.LineRouteKey()
+		record := key.LineName + ";" + key.LineRouteName + ";" + key.DirectionCode + ";" + profile.Name
+		if v.LineRoute != nil {
+			if _, ok := v.LineRoute.GetLineRoute(key); !ok {
+				v.report("TIMEPROFILE", record, "LINENAME;LINEROUTENAME;DIRECTIONCODE", key.LineName+";"+key.LineRouteName+";"+key.DirectionCode, "LINEROUTE")
+			}
+		}
+		if v.VehComb != nil && profile.VehCombNo != 0 {
+			if _, ok := v.VehComb.GetVehicleCombinationByID(profile.VehCombNo); !ok {
+				v.report("TIMEPROFILE", record, "VEHCOMBNO", formatInt(profile.VehCombNo), "VEHCOMB")
+			}
+		}
+	}
+}
+
+func (v *validator) checkTimeProfileItems() {
+	if v.TimeProfileItem == nil {
+		return
+	}
+	for _, item := range v.TimeProfileItem.Items {
+		key := item.TimeProfileKey()
+		record := key.LineName + ";" + key.LineRouteName + ";" + key.DirectionCode + ";" + key.TimeProfileName + ";" + formatInt(item.Index)
+		if v.TimeProfile != nil {
+			if _, ok := v.TimeProfile.GetTimeProfile(key); !ok {
+				v.report("TIMEPROFILEITEM", record, "TIMEPROFILENAME", key.TimeProfileName, "TIMEPROFILE")
+			}
+		}
+		if v.LineRouteItem != nil {
+			if _, ok := v.LineRouteItem.GetItem(key.LineRouteKey, item.LRItemIndex); !ok {
+				v.report("TIMEPROFILEITEM", record, "LRITEMINDEX", formatInt(item.LRItemIndex), "LINEROUTEITEM")
+			}
+		}
+	}
+}
+
+func (v *validator) checkVehJourneys() {
+	if v.VehJourney == nil {
+		return
+	}
+	for _, journey := range v.VehJourney.Journeys {
+		key := journey.TimeProfileKey()
+		record := formatInt(journey.No)
+		if v.TimeProfile != nil {
+			if _, ok := v.TimeProfile.GetTimeProfile(key); !ok {
+				v.report("VEHJOURNEY", record, "TIMEPROFILENAME", key.TimeProfileName, "TIMEPROFILE")
+			}
+		}
+		if v.TimeProfileItem != nil {
+			for _, index := range []int{journey.FromTProfItemIndex, journey.ToTProfItemIndex} {
+				if _, ok := v.TimeProfileItem.GetItem(key, index); !ok {
+					v.report("VEHJOURNEY", record, "FROMTPROFITEMINDEX;TOTPROFITEMINDEX", formatInt(index), "TIMEPROFILEITEM")
+				}
+			}
+		}
+	}
+}
+
+func (v *validator) checkVehJourneySections() {
+	if v.VehJourneySection == nil {
+		return
+	}
+	for _, section := range v.VehJourneySection.Sections {
+		record := formatInt(section.VehJourneyNo) + ";" + formatInt(section.No)
+		if v.VehJourney != nil {
+			if _, ok := v.VehJourney.GetVehicleJourneyByID(section.VehJourneyNo); !ok {
+				v.report("VEHJOURNEYSECTION", record, "VEHJOURNEYNO", formatInt(section.VehJourneyNo), "VEHJOURNEY")
+			}
+		}
+		if v.ValidDays != nil {
+			if _, ok := v.ValidDays.GetValidDayByID(section.ValidDaysNo); !ok {
+				v.report("VEHJOURNEYSECTION", record, "VALIDDAYSNO", formatInt(section.ValidDaysNo), "VALIDDAYS")
+			}
+		}
+		if v.VehComb != nil && section.VehCombNo != 0 {
+			if _, ok := v.VehComb.GetVehicleCombinationByID(section.VehCombNo); !ok {
+				v.report("VEHJOURNEYSECTION", record, "VEHCOMBNO", formatInt(section.VehCombNo), "VEHCOMB")
+			}
+		}
+	}
+}
+
+func (v *validator) checkTransferWalkTimes() {
+	if v.TransferWalkTimeStopArea == nil {
+		return
+	}
+	for _, transfer := range v.TransferWalkTimeStopArea.Transfers {
+		record := formatInt(transfer.FromStopAreaNo) + ";" + formatInt(transfer.ToStopAreaNo) + ";" + transfer.TSysCode
+		if v.StopArea != nil {
+			if _, ok := v.StopArea.GetStopAreaByID(transfer.FromStopAreaNo); !ok {
+				v.report("TRANSFERWALKTIMESTOPAREA", record, "FROMSTOPAREANO", formatInt(transfer.FromStopAreaNo), "STOPAREA")
+			}
+			if _, ok := v.StopArea.GetStopAreaByID(transfer.ToStopAreaNo); !ok {
+				v.report("TRANSFERWALKTIMESTOPAREA", record, "TOSTOPAREANO", formatInt(transfer.ToStopAreaNo), "STOPAREA")
+			}
+		}
+		if v.TSys != nil {
+			if _, ok := v.TSys.GetTransportSystemByCode(transfer.TSysCode); !ok {
+				v.report("TRANSFERWALKTIMESTOPAREA", record, "TSYSCODE", transfer.TSysCode, "TSYS")
+			}
+		}
+	}
+}
+
+func (v *validator) checkBlocks() {
+	if v.Block == nil {
+		return
+	}
+	for _, block := range v.Block.Blocks {
+		record := formatInt(block.BlockVersionID) + ";" + formatInt(block.ID)
+		if v.BlockVersion != nil {
+			if _, ok := v.BlockVersion.GetBlockVersionByID(block.BlockVersionID); !ok {
+				v.report("BLOCK", record, "BLOCKVERSIONID", formatInt(block.BlockVersionID), "BLOCKVERSION")
+			}
+		}
+		if v.VehComb != nil && block.VehCombNo != 0 {
+			if _, ok := v.VehComb.GetVehicleCombinationByID(block.VehCombNo); !ok {
+				v.report("BLOCK", record, "VEHCOMBNO", formatInt(block.VehCombNo), "VEHCOMB")
+			}
+		}
+	}
+}
+
+func (v *validator) checkBlockItems() {
+	if v.BlockItem == nil {
+		return
+	}
+	for _, item := range v.BlockItem.Items {
+		record := formatInt(item.BlockVersionID) + ";" + formatInt(item.BlockID) + ";" + formatInt(item.Index)
+		if v.Block != nil {
+			if _, ok := v.Block.GetBlock(item.BlockKey()); !ok {
+				v.report("BLOCKITEM", record, "BLOCKVERSIONID;BLOCKID", formatInt(item.BlockVersionID)+";"+formatInt(item.BlockID), "BLOCK")
+			}
+		}
+		if v.BlockItemType != nil {
+			if _, ok := v.BlockItemType.GetBlockItemTypeByID(item.BlockItemTypeNo); !ok {
+				v.report("BLOCKITEM", record, "BLOCKITEMTYPENO", formatInt(item.BlockItemTypeNo), "BLOCKITEMTYPE")
+			}
+		}
+		if v.VehJourney != nil && item.VehJourneyNo != 0 {
+			if _, ok := v.VehJourney.GetVehicleJourneyByID(item.VehJourneyNo); !ok {
+				v.report("BLOCKITEM", record, "VEHJOURNEYNO", formatInt(item.VehJourneyNo), "VEHJOURNEY")
+			}
+		}
+		if v.VehJourneySection != nil && item.VehJourneySectionNo != 0 {
+			if _, ok := v.VehJourneySection.GetSection(item.VehJourneyNo, item.VehJourneySectionNo); !ok {
+				v.report("BLOCKITEM", record, "VEHJOURNEYNO;VEHJOURNEYSECTIONNO", formatInt(item.VehJourneyNo)+";"+formatInt(item.VehJourneySectionNo), "VEHJOURNEYSECTION")
+			}
+		}
+	}
+}
+
+func (v *validator) checkLegs() {
+	if v.Leg == nil {
+		return
+	}
+	for _, leg := range v.Leg.Legs {
+		v.checkJunction("LEG", formatInt(leg.NodeNo)+";"+formatInt(leg.MainNodeNo)+";"+leg.Orientation, leg.JunctionKey())
+	}
+}
+
+func (v *validator) checkLanes() {
+	if v.Lane == nil {
+		return
+	}
+	for _, lane := range v.Lane.Lanes {
+		record := formatInt(lane.NodeNo) + ";" + formatInt(lane.MainNodeNo) + ";" + formatInt(lane.LinkNo) + ";" + formatInt(lane.No)
+		v.checkJunction("LANE", record, lane.JunctionKey())
+		if v.Link != nil {
+			if _, ok := v.Link.GetLinkByID(lane.LinkNo); !ok {
+				v.report("LANE", record, "LINKNO", formatInt(lane.LinkNo), "LINK")
+			}
+		}
+	}
+}
+
+func (v *validator) checkLaneTurns() {
+	if v.LaneTurn == nil || v.Lane == nil {
+		return
+	}
+	for _, turn := range v.LaneTurn.LaneTurns {
+		record := formatInt(turn.NodeNo) + ";" + formatInt(turn.MainNodeNo) + ";" + formatInt(turn.FromLinkNo) + ";" +
+			formatInt(turn.FromLaneNo) + ";" + formatInt(turn.ToLinkNo) + ";" + formatInt(turn.ToLaneNo)
+		if _, ok := v.Lane.GetLane(turn.JunctionKey(), turn.FromLinkNo, turn.FromLaneNo); !ok {
+			v.report("LANETURN", record, "FROMLINKNO;FROMLANENO", formatInt(turn.FromLinkNo)+";"+formatInt(turn.FromLaneNo), "LANE")
+		}
+		if _, ok := v.Lane.GetLane(turn.JunctionKey(), turn.ToLinkNo, turn.ToLaneNo); !ok {
+			v.report("LANETURN", record, "TOLINKNO;TOLANENO", formatInt(turn.ToLinkNo)+";"+formatInt(turn.ToLaneNo), "LANE")
+		}
+	}
+}
+
+func (v *validator) checkCrosswalks() {
+	if v.Crosswalk == nil || v.Leg == nil {
+		return
+	}
+	for _, crosswalk := range v.Crosswalk.Crosswalks {
+		if _, ok := v.Leg.GetLeg(crosswalk.JunctionKey(), crosswalk.Orientation); !ok {
+			record := formatInt(crosswalk.NodeNo) + ";" + formatInt(crosswalk.MainNodeNo) + ";" + crosswalk.Orientation + ";" +
+				formatInt(crosswalk.Index) + ";" + formatInt(crosswalk.Direction)
+			v.report("CROSSWALK", record, "ORIENTATION", crosswalk.Orientation, "LEG")
+		}
+	}
+}
+
+func (v *validator) checkSignalControlToNodes() {
+	if v.SignalControlToNode == nil {
+		return
+	}
+	for _, item := range v.SignalControlToNode.Items {
+		record := formatInt(item.SCNo) + ";" + formatInt(item.NodeNo)
+		if v.SignalControl != nil {
+			if _, ok := v.SignalControl.GetSignalControlByID(item.SCNo); !ok {
+				v.report("SIGNALCONTROLTONODE", record, "SCNO", formatInt(item.SCNo), "SIGNALCONTROL")
+			}
+		}
+		if v.Node != nil {
+			if _, ok := v.Node.GetNodeByID(item.NodeNo); !ok {
+				v.report("SIGNALCONTROLTONODE", record, "NODENO", formatInt(item.NodeNo), "NODE")
+			}
+		}
+	}
+}
+
+func (v *validator) checkSignalGroups() {
+	if v.SignalGroup == nil || v.SignalControl == nil {
+		return
+	}
+	for _, group := range v.SignalGroup.SignalGroups {
+		if _, ok := v.SignalControl.GetSignalControlByID(group.SCNo); !ok {
+			v.report("SIGNALGROUP", formatInt(group.SCNo)+";"+formatInt(group.No), "SCNO", formatInt(group.SCNo), "SIGNALCONTROL")
+		}
+	}
+}
+
+func (v *validator) checkStages() {
+	if v.Stage == nil || v.SignalControl == nil {
+		return
+	}
+	for _, stage := range v.Stage.Stages {
+		if _, ok := v.SignalControl.GetSignalControlByID(stage.SCNo); !ok {
+			v.report("STAGE", formatInt(stage.SCNo)+";"+formatInt(stage.No), "SCNO", formatInt(stage.SCNo), "SIGNALCONTROL")
+		}
+	}
+}
+
+func (v *validator) checkSignalGroupToStages() {
+	if v.SignalGroupToStage == nil {
+		return
+	}
+	for _, item := range v.SignalGroupToStage.Items {
+		record := formatInt(item.SCNo) + ";" + formatInt(item.SGNo) + ";" + formatInt(item.StageNo)
+		if v.SignalGroup != nil {
+			if _, ok := v.SignalGroup.GetSignalGroup(item.SignalGroupKey()); !ok {
+				v.report("SIGNALGROUPTOSTAGE", record, "SCNO;SGNO", formatInt(item.SCNo)+";"+formatInt(item.SGNo), "SIGNALGROUP")
+			}
+		}
+		if v.Stage != nil {
+			if _, ok := v.Stage.GetStage(item.SCNo, item.StageNo); !ok {
+				v.report("SIGNALGROUPTOSTAGE", record, "SCNO;STAGENO", formatInt(item.SCNo)+";"+formatInt(item.StageNo), "STAGE")
+			}
+		}
+	}
+}
+
+func (v *validator) checkSignalGroupToTurns() {
+	if v.SignalGroupToTurn == nil {
+		return
+	}
+	for _, item := range v.SignalGroupToTurn.Items {
+		turn := formatInt(item.FromNodeNo) + ";" + formatInt(item.ViaNodeNo) + ";" + formatInt(item.ToNodeNo)
+		record := formatInt(item.SCNo) + ";" + formatInt(item.SGNo) + ";" + turn
+		if v.SignalGroup != nil {
+			if _, ok := v.SignalGroup.GetSignalGroup(item.SignalGroupKey()); !ok {
+				v.report("SIGNALGROUPTOTURN", record, "SCNO;SGNO", formatInt(item.SCNo)+";"+formatInt(item.SGNo), "SIGNALGROUP")
+			}
+		}
+		if v.Turn != nil {
+			if _, ok := v.Turn.GetTurn(item.FromNodeNo, item.ViaNodeNo, item.ToNodeNo); !ok {
+				v.report("SIGNALGROUPTOTURN", record, "FROMNODENO;VIANODENO;TONODENO", turn, "TURN")
+			}
+		}
+	}
+}
+
+func (v *validator) checkSignalGroupToLaneTurns() {
+	if v.SignalGroupToLaneTurn == nil {
+		return
+	}
+	for _, item := range v.SignalGroupToLaneTurn.Items {
+		laneTurn := formatInt(item.FromLinkNo) + ";" + formatInt(item.FromLaneNo) + ";" + formatInt(item.ToLinkNo) + ";" + formatInt(item.ToLaneNo)
+		record := formatInt(item.SCNo) + ";" + formatInt(item.SGNo) + ";" + formatInt(item.NodeNo) + ";" + formatInt(item.MainNodeNo) + ";" + laneTurn
+		if v.SignalGroup != nil {
+			if _, ok := v.SignalGroup.GetSignalGroup(item.SignalGroupKey()); !ok {
+				v.report("SIGNALGROUPTOLANETURN", record, "SCNO;SGNO", formatInt(item.SCNo)+";"+formatInt(item.SGNo), "SIGNALGROUP")
+			}
+		}
+		if v.LaneTurn != nil {
+			if _, ok := v.SignalGroupToLaneTurn.GetLaneTurn(item, v.PTVData); !ok {
+				v.report("SIGNALGROUPTOLANETURN", record, "FROMLINKNO;FROMLANENO;TOLINKNO;TOLANENO", laneTurn, "LANETURN")
+			}
+		}
+	}
+}
+
+func (v *validator) checkDetectors() {
+	if v.Detector == nil {
+		return
+	}
+	for _, detector := range v.Detector.Detectors {
+		record := formatInt(detector.No)
+		if v.CountLocation != nil && detector.CountLocationNo != 0 {
+			if _, ok := v.CountLocation.GetCountLocationByID(detector.CountLocationNo); !ok {
+				v.report("DETECTOR", record, "COUNTLOCATIONNO", formatInt(detector.CountLocationNo), "COUNTLOCATION")
+			}
+		}
+		if v.SignalControl != nil && detector.SCNo != 0 {
+			if _, ok := v.SignalControl.GetSignalControlByID(detector.SCNo); !ok {
+				v.report("DETECTOR", record, "SCNO", formatInt(detector.SCNo), "SIGNALCONTROL")
+			}
+		}
+		if v.Link != nil {
+			if _, ok := v.Link.GetLinkByFromNode(detector.LinkNo, detector.FromNodeNo); !ok {
+				v.report("DETECTOR", record, "LINKNO;FROMNODENO", formatInt(detector.LinkNo)+";"+formatInt(detector.FromNodeNo), "LINK")
+			}
+		}
+	}
+}
+
+// checkPOIs checks the POI sections in category order, as data.POIs is a map
+func (v *validator) checkPOIs() {
+	categories := make([]int, 0, len(v.POIs))
+	for catNo := range v.POIs {
+		categories = append(categories, catNo)
+	}
+	sort.Ints(categories)
+	for _, catNo := range categories {
+		section := v.POIs[catNo]
+		name := section.Name()
+		if v.POICategory != nil {
+			if _, ok := v.POICategory.GetCategoryByID(catNo); !ok {
+				v.report(name, "", "CATNO", formatInt(catNo), "POICATEGORY")
+			}
+		}
+		if v.Surface != nil {
+			for _, poi := range section.POIs {
+				if poi.SurfaceID != 0 && !v.Surface.Contains(poi.SurfaceID) {
+					v.report(name, formatInt(poi.CatNo)+";"+formatInt(poi.No), "SURFACEID", formatInt(poi.SurfaceID), "SURFACE")
+				}
+			}
+		}
+	}
+}
+
+// sortIssues orders the issues by their section in the order Visum writes sections, keeping the order within a section
+func sortIssues(data *PTVData, issues []Issue) {
+	rank := make(map[string]int)
+	for i, name := range orderedSectionNames(data) {
+		rank[name] = i
+	}
+	sort.SliceStable(issues, func(i, j int) bool {
+		return sectionRank(rank, issues[i].Section) < sectionRank(rank, issues[j].Section)
+	})
+}
+
+// sectionRank returns the position of the section in rank, sections missing from it go last
+func sectionRank(rank map[string]int, section string) int {
+	if i, ok := rank[section]; ok {
+		return i
+	}
+	return len(rank)
+}
+
+// hasDirectedLink reports whether there is a link going from one node to the other
+func hasDirectedLink(links *LinkSection, fromNodeNo, toNodeNo int) bool {
+	for _, i := range links.byFromNode.lookup(links.Links, fromNodeNo, linkFromNode) {
+		if links.Links[i].ToNodeNo == toNodeNo {
+			return true
+		}
+	}
+	return false
+}
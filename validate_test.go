@@ -0,0 +1,179 @@
+package ptvvisum
+
+import (
+	"reflect"
+	"testing"
+)
+
+// brokenReferences returns data in which every record refers to records which do not exist.
+// Referenced sections are loaded but hold no or other records
+func brokenReferences() *PTVData {
+	pois := &POISection{BaseSection: BaseSection{name: "POIOFCAT_3"}, CategoryNo: 3, POIs: []POI{{CatNo: 3, No: 1, SurfaceID: 5}}}
+	return &PTVData{
+		TSys:             &TSysSection{Systems: []TransportSystem{{Code: "CAR"}}},
+		Mode:             &ModeSection{Modes: []Mode{{Code: "B", TSysSet: []string{"BUS"}}}},
+		VehUnit:          &VehUnitSection{Units: []VehicleUnit{{No: 1, TSysSet: "BUS"}}},
+		VehComb:          &VehCombSection{},
+		VehUnitToVehComb: &VehUnitToVehCombSection{Mappings: []VehUnitToVehCombMapping{{VehCombNo: 2, VehUnitNo: 3}}},
+		Direction:        &DirectionSection{},
+		Edge:             &EdgeSection{},
+		FaceItem:         &FaceItemSection{Items: []FaceItem{{FaceID: 1, Index: 1, EdgeID: 5}}},
+		Surface:          &SurfaceSection{},
+		Node:             &NodeSection{Nodes: []Node{{ID: 1, MainNodeNo: 2}}},
+		MainNode:         &MainNodeSection{},
+		Zone:             &ZoneSection{Zones: []Zone{{No: 1, SurfaceID: 3}}},
+		Territory:        &TerritorySection{Territories: []Territory{{No: 1, SurfaceID: 4}}},
+		LinkType:         &LinkTypeSection{LinkTypes: []LinkType{{No: 1, TSysSet: "BUS"}}},
+		Link:             &LinkSection{Links: []Link{{No: 1, FromNodeNo: 98, ToNodeNo: 99, TypeNo: 2, TSysSet: "BUS"}}},
+		Turn:             &TurnSection{Turns: []Turn{{FromNodeNo: 7, ViaNodeNo: 8, ToNodeNo: 9, TSysSet: "BUS"}}},
+		MainTurn:         &MainTurnSection{MainTurns: []MainTurn{{MainNodeNo: 2, FromLinkNo: 5, ToLinkNo: 6, TSysSet: "BUS"}}},
+		Connector:        &ConnectorSection{Connectors: []Connector{{ZoneNo: 5, NodeNo: 99, Direction: "O", TSysSet: "BUS"}}},
+		Screenline:       &ScreenlineSection{},
+		ScreenlinePoly:   &ScreenlinePolySection{Points: []ScreenlinePoint{{ScreenlineNo: 1, Index: 1}}},
+		CountLocation:    &CountLocationSection{CountLocations: []CountLocation{{No: 1, LinkNo: 5, FromNodeNo: 98}}},
+		Stop:             &StopSection{},
+		StopArea:         &StopAreaSection{StopAreas: []StopArea{{No: 1, StopNo: 2, NodeNo: 99}}},
+		StopPoint: &StopPointSection{StopPoints: []StopPoint{
+			{No: 1, StopAreaNo: 5, NodeNo: 99, TSysSet: "BUS"},
+			{No: 2, StopAreaNo: 1, LinkNo: 5, FromNodeNo: 98},
+		}},
+		Line:          &LineSection{Lines: []Line{{Name: "L1", TSysCode: "BUS", VehCombNo: 2}}},
+		LineRoute:     &LineRouteSection{LineRoutes: []LineRoute{{LineName: "L2", Name: "R", DirectionCode: "<"}}},
+		LineRouteItem: &LineRouteItemSection{Items: []LineRouteItem{{LineName: "L1", LineRouteName: "R", DirectionCode: ">", Index: 1, NodeNo: 99, StopPointNo: 5}}},
+		TimeProfile:   &TimeProfileSection{TimeProfiles: []TimeProfile{{LineName: "L1", LineRouteName: "R", DirectionCode: ">", Name: "T", VehCombNo: 2}}},
+		TimeProfileItem: &TimeProfileItemSection{Items: []TimeProfileItem{
+			{LineName: "L1", LineRouteName: "R", DirectionCode: ">", TimeProfileName: "U", Index: 1, LRItemIndex: 3},
+		}},
+		VehJourney: &VehJourneySection{Journeys: []VehicleJourney{
+			{No: 1, LineName: "L1", LineRouteName: "R", DirectionCode: ">", TimeProfileName: "U", FromTProfItemIndex: 1, ToTProfItemIndex: 2},
+		}},
+		ValidDays:                &ValidDaysSection{},
+		VehJourneySection:        &VehJourneySectionSection{Sections: []VehicleJourneySection{{VehJourneyNo: 5, No: 1, ValidDaysNo: 3, VehCombNo: 2}}},
+		TransferWalkTimeStopArea: &TransferWalkTimeStopAreaSection{Transfers: []TransferWalkTime{{FromStopAreaNo: 1, ToStopAreaNo: 5, TSysCode: "BUS"}}},
+		BlockVersion:             &BlockVersionSection{},
+		BlockItemType:            &BlockItemTypeSection{},
+		Block:                    &BlockSection{Blocks: []Block{{BlockVersionID: 1, ID: 1, VehCombNo: 2}}},
+		BlockItem: &BlockItemSection{Items: []BlockItem{
+			{BlockVersionID: 1, BlockID: 2, Index: 1, BlockItemTypeNo: 3, VehJourneyNo: 6, VehJourneySectionNo: 1},
+		}},
+		POICategory:         &POICategorySection{},
+		POIs:                map[int]*POISection{3: pois},
+		Leg:                 &LegSection{Legs: []Leg{{NodeNo: 99, Orientation: "N"}, {MainNodeNo: 2, Orientation: "S"}}},
+		Lane:                &LaneSection{Lanes: []Lane{{NodeNo: 1, LinkNo: 5, No: 1}}},
+		LaneTurn:            &LaneTurnSection{LaneTurns: []LaneTurn{{NodeNo: 1, FromLinkNo: 5, FromLaneNo: 1, ToLinkNo: 6, ToLaneNo: 1}}},
+		Crosswalk:           &CrosswalkSection{Crosswalks: []Crosswalk{{NodeNo: 1, Orientation: "E", Index: 1, Direction: 1}}},
+		SignalControl:       &SignalControlSection{},
+		SignalControlToNode: &SignalControlToNodeSection{Items: []SignalControlToNode{{SCNo: 1, NodeNo: 99}}},
+		SignalGroup:         &SignalGroupSection{SignalGroups: []SignalGroup{{SCNo: 1, No: 1}}},
+		SignalGroupToTurn:   &SignalGroupToTurnSection{Items: []SignalGroupToTurn{{SCNo: 1, SGNo: 2, FromNodeNo: 1, ViaNodeNo: 2, ToNodeNo: 3}}},
+		SignalGroupToLaneTurn: &SignalGroupToLaneTurnSection{Items: []SignalGroupToLaneTurn{
+			{SCNo: 1, SGNo: 1, NodeNo: 1, FromLinkNo: 5, FromLaneNo: 1, ToLinkNo: 5, ToLaneNo: 2},
+		}},
+		Stage:              &StageSection{Stages: []Stage{{SCNo: 1, No: 1}}},
+		SignalGroupToStage: &SignalGroupToStageSection{Items: []SignalGroupToStage{{SCNo: 1, SGNo: 2, StageNo: 3}}},
+		Detector:           &DetectorSection{Detectors: []Detector{{No: 1, CountLocationNo: 5, SCNo: 2, LinkNo: 5, FromNodeNo: 98}}},
+		Sections:           map[string]Section{"POIOFCAT_3": pois},
+	}
+}
+
+func TestValidateReportsBrokenReferences(t *testing.T) {
+	want := []Issue{
+		{"MODE", "B", "TSYSSET", "BUS", "TSYS"},
+		{"VEHUNIT", "1", "TSYSSET", "BUS", "TSYS"},
+		{"VEHUNITTOVEHCOMB", "2;3", "VEHCOMBNO", "2", "VEHCOMB"},
+		{"VEHUNITTOVEHCOMB", "2;3", "VEHUNITNO", "3", "VEHUNIT"},
+		{"FACEITEM", "1;1", "EDGEID", "5", "EDGE"},
+		{"NODE", "1", "MAINNODENO", "2", "MAINNODE"},
+		{"ZONE", "1", "SURFACEID", "3", "SURFACE"},
+		{"TERRITORY", "1", "SURFACEID", "4", "SURFACE"},
+		{"LINKTYPE", "1", "TSYSSET", "BUS", "TSYS"},
+		{"LINK", "1;98;99", "FROMNODENO", "98", "NODE"},
+		{"LINK", "1;98;99", "TONODENO", "99", "NODE"},
+		{"LINK", "1;98;99", "TYPENO", "2", "LINKTYPE"},
+		{"LINK", "1;98;99", "TSYSSET", "BUS", "TSYS"},
+		{"TURN", "7;8;9", "FROMNODENO;VIANODENO", "7;8", "LINK"},
+		{"TURN", "7;8;9", "VIANODENO;TONODENO", "8;9", "LINK"},
+		{"TURN", "7;8;9", "TSYSSET", "BUS", "TSYS"},
+		{"MAINTURN", "2;5;6", "MAINNODENO", "2", "MAINNODE"},
+		{"MAINTURN", "2;5;6", "FROMLINKNO", "5", "LINK"},
+		{"MAINTURN", "2;5;6", "TOLINKNO", "6", "LINK"},
+		{"MAINTURN", "2;5;6", "TSYSSET", "BUS", "TSYS"},
+		{"CONNECTOR", "5;99;O", "ZONENO", "5", "ZONE"},
+		{"CONNECTOR", "5;99;O", "NODENO", "99", "NODE"},
+		{"CONNECTOR", "5;99;O", "TSYSSET", "BUS", "TSYS"},
+		{"SCREENLINEPOLY", "1;1", "SCREENLINENO", "1", "SCREENLINE"},
+		{"COUNTLOCATION", "1", "LINKNO;FROMNODENO", "5;98", "LINK"},
+		{"STOPAREA", "1", "STOPNO", "2", "STOP"},
+		{"STOPAREA", "1", "NODENO", "99", "NODE"},
+		{"STOPPOINT", "1", "STOPAREANO", "5", "STOPAREA"},
+		{"STOPPOINT", "1", "NODENO", "99", "NODE"},
+		{"STOPPOINT", "1", "TSYSSET", "BUS", "TSYS"},
+		{"STOPPOINT", "2", "LINKNO;FROMNODENO", "5;98", "LINK"},
+		{"LINE", "L1", "TSYSCODE", "BUS", "TSYS"},
+		{"LINE", "L1", "VEHCOMBNO", "2", "VEHCOMB"},
+		{"LINEROUTE", "L2;R;<", "LINENAME", "L2", "LINE"},
+		{"LINEROUTE", "L2;R;<", "DIRECTIONCODE", "<", "DIRECTION"},
+		{"LINEROUTEITEM", "L1;R;>;1", "LINENAME;LINEROUTENAME;DIRECTIONCODE", "L1;R;>", "LINEROUTE"},
+		{"LINEROUTEITEM", "L1;R;>;1", "NODENO", "99", "NODE"},
+		{"LINEROUTEITEM", "L1;R;>;1", "STOPPOINTNO", "5", "STOPPOINT"},
+		{"TIMEPROFILE", "L1;R;>;T", "LINENAME;LINEROUTENAME;DIRECTIONCODE", "L1;R;>", "LINEROUTE"},
+		{"TIMEPROFILE", "L1;R;>;T", "VEHCOMBNO", "2", "VEHCOMB"},
+		{"TIMEPROFILEITEM", "L1;R;>;U;1", "TIMEPROFILENAME", "U", "TIMEPROFILE"},
+		{"TIMEPROFILEITEM", "L1;R;>;U;1", "LRITEMINDEX", "3", "LINEROUTEITEM"},
+		{"VEHJOURNEY", "1", "TIMEPROFILENAME", "U", "TIMEPROFILE"},
+		{"VEHJOURNEY", "1", "FROMTPROFITEMINDEX;TOTPROFITEMINDEX", "2", "TIMEPROFILEITEM"},
+		{"VEHJOURNEYSECTION", "5;1", "VEHJOURNEYNO", "5", "VEHJOURNEY"},
+		{"VEHJOURNEYSECTION", "5;1", "VALIDDAYSNO", "3", "VALIDDAYS"},
+		{"VEHJOURNEYSECTION", "5;1", "VEHCOMBNO", "2", "VEHCOMB"},
+		{"TRANSFERWALKTIMESTOPAREA", "1;5;BUS", "TOSTOPAREANO", "5", "STOPAREA"},
+		{"TRANSFERWALKTIMESTOPAREA", "1;5;BUS", "TSYSCODE", "BUS", "TSYS"},
+		{"BLOCK", "1;1", "BLOCKVERSIONID", "1", "BLOCKVERSION"},
+		{"BLOCK", "1;1", "VEHCOMBNO", "2", "VEHCOMB"},
+		{"BLOCKITEM", "1;2;1", "BLOCKVERSIONID;BLOCKID", "1;2", "BLOCK"},
+		{"BLOCKITEM", "1;2;1", "BLOCKITEMTYPENO", "3", "BLOCKITEMTYPE"},
+		{"BLOCKITEM", "1;2;1", "VEHJOURNEYNO", "6", "VEHJOURNEY"},
+		{"BLOCKITEM", "1;2;1", "VEHJOURNEYNO;VEHJOURNEYSECTIONNO", "6;1", "VEHJOURNEYSECTION"},
+		{"POIOFCAT_3", "", "CATNO", "3", "POICATEGORY"},
+		{"POIOFCAT_3", "3;1", "SURFACEID", "5", "SURFACE"},
+		{"LEG", "99;0;N", "NODENO", "99", "NODE"},
+		{"LEG", "0;2;S", "MAINNODENO", "2", "MAINNODE"},
+		{"LANE", "1;0;5;1", "LINKNO", "5", "LINK"},
+		{"LANETURN", "1;0;5;1;6;1", "TOLINKNO;TOLANENO", "6;1", "LANE"},
+		{"CROSSWALK", "1;0;E;1;1", "ORIENTATION", "E", "LEG"},
+		{"SIGNALCONTROLTONODE", "1;99", "SCNO", "1", "SIGNALCONTROL"},
+		{"SIGNALCONTROLTONODE", "1;99", "NODENO", "99", "NODE"},
+		{"SIGNALGROUP", "1;1", "SCNO", "1", "SIGNALCONTROL"},
+		{"SIGNALGROUPTOTURN", "1;2;1;2;3", "SCNO;SGNO", "1;2", "SIGNALGROUP"},
+		{"SIGNALGROUPTOTURN", "1;2;1;2;3", "FROMNODENO;VIANODENO;TONODENO", "1;2;3", "TURN"},
+		{"SIGNALGROUPTOLANETURN", "1;1;1;0;5;1;5;2", "FROMLINKNO;FROMLANENO;TOLINKNO;TOLANENO", "5;1;5;2", "LANETURN"},
+		{"STAGE", "1;1", "SCNO", "1", "SIGNALCONTROL"},
+		{"SIGNALGROUPTOSTAGE", "1;2;3", "SCNO;SGNO", "1;2", "SIGNALGROUP"},
+		{"SIGNALGROUPTOSTAGE", "1;2;3", "SCNO;STAGENO", "1;3", "STAGE"},
+		{"DETECTOR", "1", "COUNTLOCATIONNO", "5", "COUNTLOCATION"},
+		{"DETECTOR", "1", "SCNO", "2", "SIGNALCONTROL"},
+		{"DETECTOR", "1", "LINKNO;FROMNODENO", "5;98", "LINK"},
+	}
+	got := Validate(brokenReferences())
+	if !reflect.DeepEqual(got, want) {
+		for i := 0; i < len(got) || i < len(want); i++ {
+			var g, w Issue
+			if i < len(got) {
+				g = got[i]
+			}
+			if i < len(want) {
+				w = want[i]
+			}
+			if g != w {
+				t.Errorf("issue %d: got %v, want %v", i, g, w)
+			}
+		}
+	}
+}
+
+func TestValidateSkipsSectionsNotLoaded(t *testing.T) {
+	// Without $NODE and $TSYS the references to them are not checked
+	data := &PTVData{Link: &LinkSection{Links: []Link{{No: 1, FromNodeNo: 98, ToNodeNo: 99, TSysSet: "BUS"}}}}
+	if issues := Validate(data); len(issues) != 0 {
+		t.Errorf("got issues %v, want none", issues)
+	}
+}
@@ -0,0 +1,855 @@
+package ptvvisum
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// sectionOrder lists the sections in the order Visum writes them to a network file
+var sectionOrder = []string{
+	"VERSION", "INFO", "POICATEGORY", "USERATTDEF", "CALENDARPERIOD", "VALIDDAYS", "NETWORK", "TSYS",
+	"MODE", "DEMANDSEGMENT", "BLOCKITEMTYPE", "FAREMODEL", "VEHUNIT", "VEHCOMB", "VEHUNITTOVEHCOMB",
+	"DIRECTION", "POINT", "EDGE", "EDGEITEM", "FACE", "FACEITEM", "SURFACE", "SURFACEITEM", "NODE",
+	"MAINNODE", "ZONE", "TERRITORY", "LINKTYPE", "LINK", "LINKPOLY", "TURN", "MAINTURN", "CONNECTOR",
+	"SCREENLINE", "SCREENLINEPOLY", "COUNTLOCATION", "STOP", "STOPAREA", "STOPPOINT", "LINE", "LINEROUTE",
+	"LINEROUTEITEM", "TIMEPROFILE", "TIMEPROFILEITEM", "VEHJOURNEY", "VEHJOURNEYSECTION",
+	"TRANSFERWALKTIMESTOPAREA", "BLOCKVERSION", "BLOCK", "BLOCKITEM", "POIOFCAT_", "LEG", "LANE",
+	"LANETURN", "CROSSWALK", "SIGNALCONTROL", "SIGNALCONTROLTONODE", "SIGNALGROUP", "SIGNALGROUPTOTURN",
+	"SIGNALGROUPTOLANETURN", "STAGE", "SIGNALGROUPTOSTAGE", "DETECTOR",
+}
+
+// sectionKeys lists the columns identifying a record of each section, used to match raw rows to records.
+// Sections holding a single record have none
+var sectionKeys = map[string][]string{
+	"INFO":                     {"INDEX"},
+	"POICATEGORY":              {"NO"},
+	"USERATTDEF":               {"OBJID", "ATTID"},
+	"VALIDDAYS":                {"NO"},
+	"TSYS":                     {"CODE"},
+	"MODE":                     {"CODE"},
+	"DEMANDSEGMENT":            {"CODE"},
+	"BLOCKITEMTYPE":            {"NO"},
+	"VEHUNIT":                  {"NO"},
+	"VEHCOMB":                  {"NO"},
+	"VEHUNITTOVEHCOMB":         {"VEHCOMBNO", "VEHUNITNO"},
+	"DIRECTION":                {"NO"},
+	"POINT":                    {"ID"},
+	"EDGE":                     {"ID"},
+	"EDGEITEM":                 {"EDGEID", "INDEX"},
+	"FACE":                     {"ID"},
+	"FACEITEM":                 {"FACEID", "INDEX"},
+	"SURFACE":                  {"ID"},
+	"SURFACEITEM":              {"SURFACEID", "FACEID"},
+	"NODE":                     {"NO"},
+	"MAINNODE":                 {"NO"},
+	"ZONE":                     {"NO"},
+	"TERRITORY":                {"NO"},
+	"LINKTYPE":                 {"NO"},
+	"LINK":                     {"NO", "FROMNODENO"},
+	"LINKPOLY":                 {"FROMNODENO", "TONODENO", "INDEX"},
+	"TURN":                     {"FROMNODENO", "VIANODENO", "TONODENO"},
+	"MAINTURN":                 {"MAINNODENO", "FROMLINKNO", "TOLINKNO"},
+	"CONNECTOR":                {"ZONENO", "NODENO", "DIRECTION"},
+	"SCREENLINE":               {"NO"},
+	"SCREENLINEPOLY":           {"SCREENLINENO", "INDEX"},
+	"COUNTLOCATION":            {"NO"},
+	"STOP":                     {"NO"},
+	"STOPAREA":                 {"NO"},
+	"STOPPOINT":                {"NO"},
+	"LINE":                     {"NAME"},
+	"LINEROUTE":                {"LINENAME", "NAME", "DIRECTIONCODE"},
+	"LINEROUTEITEM":            {"LINENAME", "LINEROUTENAME", "DIRECTIONCODE", "INDEX"},
+	"TIMEPROFILE":              {"LINENAME", "LINEROUTENAME", "DIRECTIONCODE", "NAME"},
+	"TIMEPROFILEITEM":          {"LINENAME", "LINEROUTENAME", "DIRECTIONCODE", "TIMEPROFILENAME", "INDEX"},
+	"VEHJOURNEY":               {"NO"},
+	"VEHJOURNEYSECTION":        {"VEHJOURNEYNO", "NO"},
+	"TRANSFERWALKTIMESTOPAREA": {"FROMSTOPAREANO", "TOSTOPAREANO", "TSYSCODE"},
+	"BLOCKVERSION":             {"ID"},
+	"BLOCK":                    {"BLOCKVERSIONID", "ID"},
+	"BLOCKITEM":                {"BLOCKVERSIONID", "BLOCKID", "INDEX"},
+	"POIOFCAT_":                {"NO"},
+	"LEG":                      {"NODENO", "MAINNODENO", "ORIENTATION"},
+	"LANE":                     {"NODENO", "MAINNODENO", "LINKNO", "NO"},
+	"LANETURN":                 {"NODENO", "MAINNODENO", "FROMLINKNO", "FROMLANENO", "TOLINKNO", "TOLANENO"},
+	"CROSSWALK":                {"NODENO", "MAINNODENO", "ORIENTATION", "INDEX"},
+	"SIGNALCONTROL":            {"NO"},
+	"SIGNALCONTROLTONODE":      {"SCNO", "NODENO"},
+	"SIGNALGROUP":              {"SCNO", "NO"},
+	"SIGNALGROUPTOTURN":        {"SCNO", "SGNO", "FROMNODENO", "VIANODENO", "TONODENO"},
+	"SIGNALGROUPTOLANETURN":    {"SCNO", "SGNO", "NODENO", "MAINNODENO", "FROMLINKNO", "FROMLANENO", "TOLINKNO", "TOLANENO"},
+	"STAGE":                    {"SCNO", "NO"},
+	"SIGNALGROUPTOSTAGE":       {"SCNO", "SGNO", "STAGENO"},
+	"DETECTOR":                 {"NO"},
+}
+
+// sectionTitles holds the table captions Visum writes as a comment above each section
+var sectionTitles = map[string]string{
+	"VERSION":                  "Version block",
+	"INFO":                     "Notepad lines",
+	"POICATEGORY":              "POI categories",
+	"USERATTDEF":               "User-defined attributes",
+	"CALENDARPERIOD":           "Calendar periods",
+	"VALIDDAYS":                "Valid days",
+	"NETWORK":                  "Network",
+	"TSYS":                     "Transport systems",
+	"MODE":                     "Modes",
+	"DEMANDSEGMENT":            "Demand segments",
+	"BLOCKITEMTYPE":            "Block item types",
+	"FAREMODEL":                "Fare model",
+	"VEHUNIT":                  "Vehicle units",
+	"VEHCOMB":                  "Vehicle combinations",
+	"VEHUNITTOVEHCOMB":         "Vehicle combination items",
+	"DIRECTION":                "Directions",
+	"POINT":                    "Points",
+	"EDGE":                     "Edges",
+	"EDGEITEM":                 "Intermediate points",
+	"FACE":                     "Faces",
+	"FACEITEM":                 "Face items",
+	"SURFACE":                  "Surfaces",
+	"SURFACEITEM":              "Surface items",
+	"NODE":                     "Nodes",
+	"MAINNODE":                 "Main nodes",
+	"ZONE":                     "Zones",
+	"TERRITORY":                "Territories",
+	"LINKTYPE":                 "Link types",
+	"LINK":                     "Links",
+	"LINKPOLY":                 "Link polygons",
+	"TURN":                     "Turns",
+	"MAINTURN":                 "Main turns",
+	"CONNECTOR":                "Connectors",
+	"SCREENLINE":               "Screenlines",
+	"SCREENLINEPOLY":           "Screenline polygons",
+	"COUNTLOCATION":            "Count locations",
+	"STOP":                     "Stops",
+	"STOPAREA":                 "Stop areas",
+	"STOPPOINT":                "Stop points",
+	"LINE":                     "Lines",
+	"LINEROUTE":                "Line routes",
+	"LINEROUTEITEM":            "Line route items",
+	"TIMEPROFILE":              "Time profiles",
+	"TIMEPROFILEITEM":          "Time profile items",
+	"VEHJOURNEY":               "Vehicle journeys",
+	"VEHJOURNEYSECTION":        "Vehicle journey sections",
+	"TRANSFERWALKTIMESTOPAREA": "Transfer walk times between stop areas",
+	"BLOCKVERSION":             "Block versions",
+	"BLOCK":                    "Blocks",
+	"BLOCKITEM":                "Block items",
+	"LEG":                      "Legs",
+	"LANE":                     "Lanes",
+	"LANETURN":                 "Lane turns",
+	"CROSSWALK":                "Crosswalks",
+	"SIGNALCONTROL":            "Signal controls",
+	"SIGNALCONTROLTONODE":      "Signal control to nodes",
+	"SIGNALGROUP":              "Signal groups",
+	"SIGNALGROUPTOTURN":        "Signal group to turns",
+	"SIGNALGROUPTOLANETURN":    "Signal group to lane turns",
+	"STAGE":                    "Stages",
+	"SIGNALGROUPTOSTAGE":       "Signal group to stages",
+	"DETECTOR":                 "Detectors",
+}
+
+// attributer is implemented by typed records which can be written back to a network file.
+// attribute returns the formatted value of the given column and false if the record does not model it
+type attributer interface {
+	attribute(column string) (string, bool)
+}
+
+// table is a section prepared for writing
+type table struct {
+	name    string
+	headers []string
+	rows    [][]string
+	numeric []bool // Columns modelled by the records as numbers or quantities, nil for sections without typed support
+}
+
+// setColumn replaces the value of the column in every row
+func (t *table) setColumn(column, value string) {
+	for i, header := range t.headers {
+		if header != column {
+			continue
+		}
+		for j, row := range t.rows {
+			if i < len(row) {
+				// Rows may be shared with the raw section
+				row = append([]string(nil), row...)
+				row[i] = value
+				t.rows[j] = row
+			}
+		}
+	}
+}
+
+// WritePTVToFile writes PTV data as a Visum network file.
+//
+// Typed sections are written from their structs, so changes made to e.g. Links or Nodes end up in the output.
+// Columns which are not modelled by the structs are copied from the raw rows kept by the reader,
+// rows are matched to the records by their key columns so records may be removed, added or reordered.
+// Sections without typed support are written as they were read.
+// The file is encoded as UTF-8 with byte order mark.
+func WritePTVToFile(writer io.Writer, data *PTVData) error {
+	return WritePTVToFileWithOptions(writer, data, WriteOptions{})
+}
+
+// WriteOptions controls the output of WritePTVToFileWithOptions
+type WriteOptions struct {
+	// Encoding of the output file, UTF-8 if empty.
+	// Unicode encodings are written with byte order mark, writing fails if a character can't be represented in a code page
+	Encoding Encoding
+	// Language of section and column names and of the decimal separator of numbers in modelled columns.
+	// The language of the source file (PTVData.Version.Language) is kept if empty
+	Language Language
+}
+
+// WritePTVToFileWithOptions writes PTV data as a Visum network file in the way selected by options
+func WritePTVToFileWithOptions(writer io.Writer, data *PTVData, options WriteOptions) error {
+	if data == nil {
+		return fmt.Errorf("no PTV data to write")
+	}
+	encoded, err := newEncoder(writer, options.Encoding)
+	if err != nil {
+		return err
+	}
+	w := bufio.NewWriter(encoded)
+
+	// BOM lets Visum recognize the encoding
+	if options.Encoding.hasBOM() {
+		if _, err := w.WriteString("\ufeff"); err != nil {
+			return fmt.Errorf("error writing PTV file: %w", err)
+		}
+	}
+	if _, err := w.WriteString("$VISION\r\n"); err != nil {
+		return fmt.Errorf("error writing PTV file: %w", err)
+	}
+
+	language := options.Language
+	if language == "" && data.Version != nil && strings.EqualFold(data.Version.Language, string(LanguageGerman)) {
+		language = LanguageGerman
+	}
+
+	for _, name := range orderedSectionNames(data) {
+		t, ok := data.table(name)
+		if !ok {
+			continue
+		}
+		if name == "VERSION" && options.Language != "" {
+			t.setColumn("LANGUAGE", string(options.Language))
+		}
+		t.localizeNumbers(language)
+		t.name = localizeSectionName(t.name, language)
+		t.headers = localizeHeaders(t.headers, language)
+		if err := writeTable(w, t, data.sectionTitle(name)); err != nil {
+			return fmt.Errorf("error writing %s section: %w", name, err)
+		}
+	}
+
+	if err := w.Flush(); err != nil {
+		return fmt.Errorf("error writing PTV file: %w", err)
+	}
+	return nil
+}
+
+// orderedSectionNames returns the names of all known sections followed by the ones present only in data.
+// POI sections are placed at the POIOFCAT_ slot ordered by category number, unknown sections go last
+func orderedSectionNames(data *PTVData) []string {
+	known := make(map[string]bool, len(sectionOrder))
+	for _, name := range sectionOrder {
+		known[name] = true
+	}
+
+	var pois, others []string
+	for name := range data.Sections {
+		switch {
+		case strings.HasPrefix(name, "POIOFCAT_"):
+			pois = append(pois, name)
+		case !known[name]:
+			others = append(others, name)
+		}
+	}
+	sort.Slice(pois, func(i, j int) bool {
+		ni, _ := strconv.Atoi(strings.TrimPrefix(pois[i], "POIOFCAT_"))
+		nj, _ := strconv.Atoi(strings.TrimPrefix(pois[j], "POIOFCAT_"))
+		return ni < nj
+	})
+	sort.Strings(others)
+
+	result := make([]string, 0, len(sectionOrder)+len(pois)+len(others))
+	for _, name := range sectionOrder {
+		if name == "POIOFCAT_" {
+			result = append(result, pois...)
+			continue
+		}
+		result = append(result, name)
+	}
+	return append(result, others...)
+}
+
+// table prepares the section with the given name for writing
+func (data *PTVData) table(name string) (table, bool) {
+	raw := data.rawSection(name)
+	switch name {
+	case "VERSION":
+		if data.Version != nil {
+			return buildTable(name, raw, &data.Version.BaseSection, versionColumns, []attributer{versionRecord{data.Version}}), true
+		}
+	case "INFO":
+		if data.Info != nil {
+			return buildTable(name, raw, &data.Info.BaseSection, infoColumns, records(data.Info.Lines)), true
+		}
+	case "POICATEGORY":
+		if data.POICategory != nil {
+			return buildTable(name, raw, &data.POICategory.BaseSection, poiCategoryColumns, records(data.POICategory.Categories)), true
+		}
+	case "USERATTDEF":
+		if data.UserAttDef != nil {
+			return buildTable(name, raw, &data.UserAttDef.BaseSection, userAttDefColumns, records(data.UserAttDef.Attributes)), true
+		}
+	case "CALENDARPERIOD":
+		if data.CalendarPeriod != nil {
+			return buildTable(name, raw, &data.CalendarPeriod.BaseSection, calendarPeriodColumns, records(data.CalendarPeriod.Periods)), true
+		}
+	case "VALIDDAYS":
+		if data.ValidDays != nil {
+			return buildTable(name, raw, &data.ValidDays.BaseSection, validDayColumns, records(data.ValidDays.Days)), true
+		}
+	case "NETWORK":
+		if data.Network != nil {
+			return buildTable(name, raw, &data.Network.BaseSection, networkColumns, []attributer{data.Network.Network}), true
+		}
+	case "TSYS":
+		if data.TSys != nil {
+			return buildTable(name, raw, &data.TSys.BaseSection, transportSystemColumns, records(data.TSys.Systems)), true
+		}
+	case "MODE":
+		if data.Mode != nil {
+			return buildTable(name, raw, &data.Mode.BaseSection, modeColumns, records(data.Mode.Modes)), true
+		}
+	case "DEMANDSEGMENT":
+		if data.DemandSegment != nil {
+			return buildTable(name, raw, &data.DemandSegment.BaseSection, demandSegmentColumns, records(data.DemandSegment.Segments)), true
+		}
+	case "BLOCKITEMTYPE":
+		if data.BlockItemType != nil {
+			return buildTable(name, raw, &data.BlockItemType.BaseSection, blockItemTypeColumns, records(data.BlockItemType.Types)), true
+		}
+	case "FAREMODEL":
+		if data.FareModel != nil {
+			return buildTable(name, raw, &data.FareModel.BaseSection, fareModelColumns, []attributer{fareModelRecord{data.FareModel}}), true
+		}
+	case "VEHUNIT":
+		if data.VehUnit != nil {
+			return buildTable(name, raw, &data.VehUnit.BaseSection, vehicleUnitColumns, records(data.VehUnit.Units)), true
+		}
+	case "VEHCOMB":
+		if data.VehComb != nil {
+			return buildTable(name, raw, &data.VehComb.BaseSection, vehicleCombinationColumns, records(data.VehComb.Combinations)), true
+		}
+	case "VEHUNITTOVEHCOMB":
+		if data.VehUnitToVehComb != nil {
+			return buildTable(name, raw, &data.VehUnitToVehComb.BaseSection, vehUnitToVehCombColumns, records(data.VehUnitToVehComb.Mappings)), true
+		}
+	case "DIRECTION":
+		if data.Direction != nil {
+			return buildTable(name, raw, &data.Direction.BaseSection, directionColumns, records(data.Direction.Directions)), true
+		}
+	case "POINT":
+		if data.Point != nil {
+			return buildTable(name, raw, &data.Point.BaseSection, pointColumns, records(data.Point.Points)), true
+		}
+	case "EDGE":
+		if data.Edge != nil {
+			return buildTable(name, raw, &data.Edge.BaseSection, edgeColumns, records(data.Edge.Edges)), true
+		}
+	case "EDGEITEM":
+		if data.EdgeItem != nil {
+			return buildTable(name, raw, &data.EdgeItem.BaseSection, edgeItemColumns, records(data.EdgeItem.Items)), true
+		}
+	case "FACE":
+		if data.Face != nil {
+			return buildTable(name, raw, &data.Face.BaseSection, faceColumns, records(data.Face.Faces)), true
+		}
+	case "FACEITEM":
+		if data.FaceItem != nil {
+			return buildTable(name, raw, &data.FaceItem.BaseSection, faceItemColumns, records(data.FaceItem.Items)), true
+		}
+	case "SURFACE":
+		if data.Surface != nil {
+			return buildTable(name, raw, &data.Surface.BaseSection, surfaceColumns, records(data.Surface.Surfaces)), true
+		}
+	case "SURFACEITEM":
+		if data.SurfaceItem != nil {
+			return buildTable(name, raw, &data.SurfaceItem.BaseSection, surfaceItemColumns, records(data.SurfaceItem.Items)), true
+		}
+	case "NODE":
+		if data.Node != nil {
+			return buildTable(name, raw, &data.Node.BaseSection, nodeColumns, records(data.Node.Nodes)), true
+		}
+	case "ZONE":
+		if data.Zone != nil {
+			return buildTable(name, raw, &data.Zone.BaseSection, zoneColumns, records(data.Zone.Zones)), true
+		}
+	case "LINKTYPE":
+		if data.LinkType != nil {
+			return buildTable(name, raw, &data.LinkType.BaseSection, data.LinkType.defaultColumns(), records(data.LinkType.LinkTypes)), true
+		}
+	case "LINK":
+		if data.Link != nil {
+			return buildTable(name, raw, &data.Link.BaseSection, data.Link.defaultColumns(), records(data.Link.Links)), true
+		}
+	case "LINKPOLY":
+		if data.LinkPoly != nil {
+			return buildTable(name, raw, &data.LinkPoly.BaseSection, linkPolyColumns, records(data.LinkPoly.Points)), true
+		}
+	case "TURN":
+		if data.Turn != nil {
+			return buildTable(name, raw, &data.Turn.BaseSection, turnColumns, records(data.Turn.Turns)), true
+		}
+	case "CONNECTOR":
+		if data.Connector != nil {
+			return buildTable(name, raw, &data.Connector.BaseSection, data.Connector.defaultColumns(), records(data.Connector.Connectors)), true
+		}
+	case "STOP":
+		if data.Stop != nil {
+			return buildTable(name, raw, &data.Stop.BaseSection, stopColumns, records(data.Stop.Stops)), true
+		}
+	case "STOPAREA":
+		if data.StopArea != nil {
+			return buildTable(name, raw, &data.StopArea.BaseSection, stopAreaColumns, records(data.StopArea.StopAreas)), true
+		}
+	case "STOPPOINT":
+		if data.StopPoint != nil {
+			return buildTable(name, raw, &data.StopPoint.BaseSection, stopPointColumns, records(data.StopPoint.StopPoints)), true
+		}
+	case "LINE":
+		if data.Line != nil {
+			return buildTable(name, raw, &data.Line.BaseSection, lineColumns, records(data.Line.Lines)), true
+		}
+	case "LINEROUTE":
+		if data.LineRoute != nil {
+			return buildTable(name, raw, &data.LineRoute.BaseSection, lineRouteColumns, records(data.LineRoute.LineRoutes)), true
+		}
+	case "LINEROUTEITEM":
+		if data.LineRouteItem != nil {
+			return buildTable(name, raw, &data.LineRouteItem.BaseSection, lineRouteItemColumns, records(data.LineRouteItem.Items)), true
+		}
+	case "TIMEPROFILE":
+		if data.TimeProfile != nil {
+			return buildTable(name, raw, &data.TimeProfile.BaseSection, timeProfileColumns, records(data.TimeProfile.TimeProfiles)), true
+		}
+	case "TIMEPROFILEITEM":
+		if data.TimeProfileItem != nil {
+			return buildTable(name, raw, &data.TimeProfileItem.BaseSection, timeProfileItemColumns, records(data.TimeProfileItem.Items)), true
+		}
+	case "VEHJOURNEY":
+		if data.VehJourney != nil {
+			return buildTable(name, raw, &data.VehJourney.BaseSection, vehicleJourneyColumns, records(data.VehJourney.Journeys)), true
+		}
+	case "VEHJOURNEYSECTION":
+		if data.VehJourneySection != nil {
+			return buildTable(name, raw, &data.VehJourneySection.BaseSection, vehicleJourneySectionColumns, records(data.VehJourneySection.Sections)), true
+		}
+	case "TRANSFERWALKTIMESTOPAREA":
+		if data.TransferWalkTimeStopArea != nil {
+			return buildTable(name, raw, &data.TransferWalkTimeStopArea.BaseSection, transferWalkTimeColumns, records(data.TransferWalkTimeStopArea.Transfers)), true
+		}
+	case "BLOCKVERSION":
+		if data.BlockVersion != nil {
+			return buildTable(name, raw, &data.BlockVersion.BaseSection, blockVersionColumns, records(data.BlockVersion.Versions)), true
+		}
+	case "BLOCK":
+		if data.Block != nil {
+			return buildTable(name, raw, &data.Block.BaseSection, blockColumns, records(data.Block.Blocks)), true
+		}
+	case "BLOCKITEM":
+		if data.BlockItem != nil {
+			return buildTable(name, raw, &data.BlockItem.BaseSection, blockItemColumns, records(data.BlockItem.Items)), true
+		}
+	case "LEG":
+		if data.Leg != nil {
+			return buildTable(name, raw, &data.Leg.BaseSection, legColumns, records(data.Leg.Legs)), true
+		}
+	case "LANE":
+		if data.Lane != nil {
+			return buildTable(name, raw, &data.Lane.BaseSection, laneColumns, records(data.Lane.Lanes)), true
+		}
+	case "LANETURN":
+		if data.LaneTurn != nil {
+			return buildTable(name, raw, &data.LaneTurn.BaseSection, laneTurnColumns, records(data.LaneTurn.LaneTurns)), true
+		}
+	case "CROSSWALK":
+		if data.Crosswalk != nil {
+			return buildTable(name, raw, &data.Crosswalk.BaseSection, crosswalkColumns, records(data.Crosswalk.Crosswalks)), true
+		}
+	case "SIGNALCONTROL":
+		if data.SignalControl != nil {
+			return buildTable(name, raw, &data.SignalControl.BaseSection, signalControlColumns, records(data.SignalControl.SignalControls)), true
+		}
+	case "SIGNALCONTROLTONODE":
+		if data.SignalControlToNode != nil {
+			return buildTable(name, raw, &data.SignalControlToNode.BaseSection, signalControlToNodeColumns, records(data.SignalControlToNode.Items)), true
+		}
+	case "SIGNALGROUP":
+		if data.SignalGroup != nil {
+			return buildTable(name, raw, &data.SignalGroup.BaseSection, signalGroupColumns, records(data.SignalGroup.SignalGroups)), true
+		}
+	case "SIGNALGROUPTOTURN":
+		if data.SignalGroupToTurn != nil {
+			return buildTable(name, raw, &data.SignalGroupToTurn.BaseSection, signalGroupToTurnColumns, records(data.SignalGroupToTurn.Items)), true
+		}
+	case "SIGNALGROUPTOLANETURN":
+		if data.SignalGroupToLaneTurn != nil {
+			return buildTable(name, raw, &data.SignalGroupToLaneTurn.BaseSection, signalGroupToLaneTurnColumns, records(data.SignalGroupToLaneTurn.Items)), true
+		}
+	case "STAGE":
+		if data.Stage != nil {
+			return buildTable(name, raw, &data.Stage.BaseSection, stageColumns, records(data.Stage.Stages)), true
+		}
+	case "SIGNALGROUPTOSTAGE":
+		if data.SignalGroupToStage != nil {
+			return buildTable(name, raw, &data.SignalGroupToStage.BaseSection, signalGroupToStageColumns, records(data.SignalGroupToStage.Items)), true
+		}
+	case "MAINNODE":
+		if data.MainNode != nil {
+			return buildTable(name, raw, &data.MainNode.BaseSection, mainNodeColumns, records(data.MainNode.MainNodes)), true
+		}
+	case "MAINTURN":
+		if data.MainTurn != nil {
+			return buildTable(name, raw, &data.MainTurn.BaseSection, mainTurnColumns, records(data.MainTurn.MainTurns)), true
+		}
+	case "TERRITORY":
+		if data.Territory != nil {
+			return buildTable(name, raw, &data.Territory.BaseSection, territoryColumns, records(data.Territory.Territories)), true
+		}
+	case "SCREENLINE":
+		if data.Screenline != nil {
+			return buildTable(name, raw, &data.Screenline.BaseSection, screenlineColumns, records(data.Screenline.Screenlines)), true
+		}
+	case "SCREENLINEPOLY":
+		if data.ScreenlinePoly != nil {
+			return buildTable(name, raw, &data.ScreenlinePoly.BaseSection, screenlinePointColumns, records(data.ScreenlinePoly.Points)), true
+		}
+	case "COUNTLOCATION":
+		if data.CountLocation != nil {
+			return buildTable(name, raw, &data.CountLocation.BaseSection, data.CountLocation.defaultColumns(), records(data.CountLocation.CountLocations)), true
+		}
+	case "DETECTOR":
+		if data.Detector != nil {
+			return buildTable(name, raw, &data.Detector.BaseSection, data.Detector.defaultColumns(), records(data.Detector.Detectors)), true
+		}
+	default:
+		if catNo, ok := poiCategoryNo(name); ok && data.POIs[catNo] != nil {
+			return buildTable(name, raw, &data.POIs[catNo].BaseSection, poiColumns, records(data.POIs[catNo].POIs)), true
+		}
+	}
+
+	// Sections without typed support are written as they were read
+	if raw != nil {
+		return table{name: name, headers: raw.Headers(), rows: raw.Rows()}, true
+	}
+	return table{}, false
+}
+
+// rawSection returns the generic section holding the raw rows of the given section
+func (data *PTVData) rawSection(name string) *BaseSection {
+	if section, ok := data.Sections[name].(*BaseSection); ok {
+		return section
+	}
+	return nil
+}
+
+// records converts a slice of typed records to attributers
+func records[T attributer](items []T) []attributer {
+	result := make([]attributer, len(items))
+	for i := range items {
+		result[i] = items[i]
+	}
+	return result
+}
+
+// buildTable merges typed records with the raw rows read from the file.
+// Headers come from the section as read, or from defaults for sections built in code
+func buildTable(name string, raw *BaseSection, section *BaseSection, defaults []string, items []attributer) table {
+	headers := section.Headers()
+	if len(headers) == 0 {
+		headers = defaults
+	}
+
+	rawRows := matchRawRows(name, raw, headers, items)
+	rows := make([][]string, len(items))
+	numeric := make([]bool, len(headers))
+	for j, header := range headers {
+		numeric[j] = isNumericColumn(header, items)
+	}
+	for i, item := range items {
+		row := make([]string, len(headers))
+		for j, header := range headers {
+			var rawValue string
+			hasRaw := j < len(rawRows[i])
+			if hasRaw {
+				rawValue = rawRows[i][j]
+			}
+			value, ok := item.attribute(header)
+			switch {
+			case ok && !(hasRaw && sameValue(rawValue, value)):
+				row[j] = value
+			case hasRaw:
+				// Unchanged values keep their original formatting, e.g. 1.000 instead of 1
+				row[j] = rawValue
+			}
+		}
+		rows[i] = row
+	}
+	return table{name: name, headers: headers, rows: rows, numeric: numeric}
+}
+
+// isNumericColumn reports whether the records model the column and every value they have for it is a number,
+// possibly with a unit such as 0.5km. Columns like VEHCOMBSET holding lists such as 1,2 are not numeric
+func isNumericColumn(column string, items []attributer) bool {
+	modelled := false
+	for _, item := range items {
+		value, ok := item.attribute(column)
+		if !ok {
+			continue
+		}
+		modelled = true
+		if value == "" {
+			continue
+		}
+		number, _ := splitNumber(value)
+		if _, err := strconv.ParseFloat(number, 64); err != nil {
+			return false
+		}
+	}
+	return modelled
+}
+
+// localizeNumbers writes the numbers of the numeric columns with the decimal separator of the language,
+// a comma for German and a point otherwise. Columns not modelled by the records are written as read
+func (t *table) localizeNumbers(language Language) {
+	separator, other := ".", ","
+	if language == LanguageGerman {
+		separator, other = ",", "."
+	}
+	for j, numeric := range t.numeric {
+		if !numeric {
+			continue
+		}
+		for _, row := range t.rows {
+			if j >= len(row) {
+				continue
+			}
+			number, unit := splitNumber(row[j])
+			row[j] = strings.Replace(number, other, separator, 1) + unit
+		}
+	}
+}
+
+// matchRawRows returns the raw row read for every record, nil for records added in code.
+// Rows are matched by the key columns of the section, so raw values never move to another record.
+// Without key columns they are matched by position as long as the number of records has not changed
+func matchRawRows(name string, raw *BaseSection, headers []string, items []attributer) [][]string {
+	matched := make([][]string, len(items))
+	if raw == nil {
+		return matched
+	}
+	if _, ok := poiCategoryNo(name); ok {
+		name = "POIOFCAT_"
+	}
+
+	var positions []int
+	for _, column := range sectionKeys[name] {
+		position := slices.Index(headers, column)
+		if position < 0 {
+			positions = nil
+			break
+		}
+		positions = append(positions, position)
+	}
+	if len(positions) == 0 {
+		if len(raw.Rows()) == len(items) {
+			copy(matched, raw.Rows())
+		}
+		return matched
+	}
+
+	// Rows with the same key are handed out in file order
+	rowsByKey := make(map[string][]int)
+	for i, row := range raw.Rows() {
+		values := make([]string, len(positions))
+		for k, position := range positions {
+			if position < len(row) {
+				values[k] = row[position]
+			}
+		}
+		key := recordKey(values)
+		rowsByKey[key] = append(rowsByKey[key], i)
+	}
+	for i, item := range items {
+		values := make([]string, len(positions))
+		for k, position := range positions {
+			values[k], _ = item.attribute(headers[position])
+		}
+		key := recordKey(values)
+		if candidates := rowsByKey[key]; len(candidates) > 0 {
+			matched[i] = raw.Rows()[candidates[0]]
+			rowsByKey[key] = candidates[1:]
+		}
+	}
+	return matched
+}
+
+// recordKey joins key values so that numbers formatted differently, e.g. 7 and 7.0, and empty and zero match
+func recordKey(values []string) string {
+	for i, value := range values {
+		if value == "" {
+			value = "0"
+		}
+		if number, err := strconv.ParseFloat(strings.Replace(value, ",", ".", 1), 64); err == nil {
+			value = formatFloat(number)
+		}
+		values[i] = value
+	}
+	return strings.Join(values, "\x00")
+}
+
+// writeTable writes a single section with its caption, header line and rows
+func writeTable(w *bufio.Writer, t table, title string) error {
+	var sb strings.Builder
+	sb.WriteString("* \r\n")
+	if title != "" {
+		sb.WriteString("* Table: " + title + "\r\n")
+	}
+	sb.WriteString("* \r\n")
+	sb.WriteString("$" + t.name)
+	if len(t.headers) > 0 {
+		sb.WriteString(":" + strings.Join(t.headers, ";"))
+	}
+	sb.WriteString("\r\n")
+	if _, err := w.WriteString(sb.String()); err != nil {
+		return err
+	}
+
+	values := []string{}
+	for _, row := range t.rows {
+		values = values[:0]
+		for _, value := range row {
+			values = append(values, quoteValue(value))
+		}
+		if _, err := w.WriteString(strings.Join(values, ";") + "\r\n"); err != nil {
+			return err
+		}
+	}
+	_, err := w.WriteString("\r\n")
+	return err
+}
+
+// sameValue reports whether a raw cell and a formatted attribute hold the same value.
+// Numbers are compared numerically and must carry the same unit suffix, an empty cell equals zero
+func sameValue(raw, formatted string) bool {
+	if raw == formatted {
+		return true
+	}
+	rawNumber, rawUnit := splitNumber(raw)
+	number, unit := splitNumber(formatted)
+	if rawUnit != unit {
+		// Durations like 1.5min are formatted as 1min 30s
+		rawDuration, rawErr := parseDuration(raw)
+		duration, err := parseDuration(formatted)
+		return rawErr == nil && err == nil && rawDuration == duration
+	}
+	if rawNumber == "" {
+		rawNumber = "0"
+	}
+	a, err := strconv.ParseFloat(strings.Replace(rawNumber, ",", ".", 1), 64)
+	if err != nil {
+		return false
+	}
+	b, err := strconv.ParseFloat(number, 64)
+	if err != nil {
+		return false
+	}
+	return a == b
+}
+
+// splitNumber splits values like 7.00m into the numeric part and the unit
+func splitNumber(value string) (string, string) {
+	i := 0
+	for i < len(value) && (value[i] == '-' || value[i] == '.' || value[i] == ',' || (value[i] >= '0' && value[i] <= '9')) {
+		i++
+	}
+	return value[:i], value[i:]
+}
+
+// sectionTitle returns the table caption of the section with the given name
+func (data *PTVData) sectionTitle(name string) string {
+	if title, ok := sectionTitles[name]; ok {
+		return title
+	}
+	no, found := strings.CutPrefix(name, "POIOFCAT_")
+	if !found {
+		return ""
+	}
+	if data.POICategory != nil {
+		for _, category := range data.POICategory.Categories {
+			if strconv.Itoa(category.No) == no {
+				return "Points of interest: " + category.Name + " (" + no + ")"
+			}
+		}
+	}
+	return "Points of interest (" + no + ")"
+}
+
+// formatInt formats an integer attribute value
+func formatInt(value int) string {
+	return strconv.Itoa(value)
+}
+
+// formatOptionalInt formats a reference to another record which is written empty when not set
+func formatOptionalInt(value int) string {
+	if value == 0 {
+		return ""
+	}
+	return strconv.Itoa(value)
+}
+
+// formatFloat formats a floating point attribute value with the shortest exact representation
+func formatFloat(value float64) string {
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
+
+// formatDate formats a date attribute value as DD.MM.YYYY
+func formatDate(value time.Time) string {
+	if value.IsZero() {
+		return ""
+	}
+	return value.Format("02.01.2006")
+}
+
+// systemColumn extracts the transport system from columns like T_PUTSYS(BUS) having the given prefix
+func systemColumn(column, prefix string) (string, bool) {
+	if !strings.HasPrefix(column, prefix+"(") || !strings.HasSuffix(column, ")") {
+		return "", false
+	}
+	return column[len(prefix)+1 : len(column)-1], true
+}
+
+// systemColumns builds columns like T_PUTSYS(BUS) for all keys of the given maps sorted by key
+func systemColumns[V any](prefix string, maps ...map[string]V) []string {
+	keys := make(map[string]bool)
+	for _, m := range maps {
+		for key := range m {
+			keys[key] = true
+		}
+	}
+	result := make([]string, 0, len(keys))
+	for key := range keys {
+		result = append(result, prefix+"("+key+")")
+	}
+	sort.Strings(result)
+	return result
+}
@@ -0,0 +1,116 @@
+package ptvvisum
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const writerTestNetwork = "$VISION\r\n" +
+	"$NODE:NO;CODE;XCOORD;YCOORD;EXTRA\r\n" +
+	"1;a;1;1;first\r\n" +
+	"2;b;2;2;second\r\n" +
+	"3;c;3;3;third\r\n"
+
+// sectionRows writes data and returns the rows of the named section read back as raw values
+func sectionRows(t *testing.T, data *PTVData, name string) [][]string {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := WritePTVToFile(&buf, data); err != nil {
+		t.Fatal(err)
+	}
+	written, err := ReadPTVFromFile(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return written.Sections[name].Rows()
+}
+
+func TestWriteKeepsUnmodelledColumns(t *testing.T) {
+	data, err := ReadPTVFromFile(strings.NewReader(writerTestNetwork))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := [][]string{{"1", "a", "1", "1", "first"}, {"2", "b", "2", "2", "second"}, {"3", "c", "3", "3", "third"}}
+	if got := sectionRows(t, data, "NODE"); !reflect.DeepEqual(got, want) {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestWriteAfterRemovingAndAppendingRecords(t *testing.T) {
+	data, err := ReadPTVFromFile(strings.NewReader(writerTestNetwork))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Same number of records as read, but the second one is replaced
+	nodes := data.Node.Nodes
+	data.Node.Nodes = append(append(nodes[:1:1], nodes[2]), Node{ID: 4, Code: "d", XCoord: 4, YCoord: 4})
+
+	want := [][]string{{"1", "a", "1", "1", "first"}, {"3", "c", "3", "3", "third"}, {"4", "d", "4", "4", ""}}
+	if got := sectionRows(t, data, "NODE"); !reflect.DeepEqual(got, want) {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestWriteAfterReorderingRecords(t *testing.T) {
+	data, err := ReadPTVFromFile(strings.NewReader(writerTestNetwork))
+	if err != nil {
+		t.Fatal(err)
+	}
+	nodes := data.Node.Nodes
+	nodes[0], nodes[2] = nodes[2], nodes[0]
+	nodes[1].Code = "changed"
+
+	want := [][]string{{"3", "c", "3", "3", "third"}, {"2", "changed", "2", "2", "second"}, {"1", "a", "1", "1", "first"}}
+	if got := sectionRows(t, data, "NODE"); !reflect.DeepEqual(got, want) {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+const germanTestNetwork = "$VISION\r\n" +
+	"$VERSION:VERSNR;DATEITYP;SPRACHE;EINHEIT\r\n" +
+	"12,00;Net;DEU;KM\r\n" +
+	"$KNOTEN:NR;NAME;XKOORD;YKOORD\r\n" +
+	"1;Nord 1,5;12,5;-0,25\r\n" +
+	"2;Süd;14;3,75\r\n" +
+	"$STRECKE:NR;VONKNOTNR;NACHKNOTNR;TYPNR;LAENGE;V0IV\r\n" +
+	"7;1;2;10;0,5km;50,5km/h\r\n"
+
+func TestWriteLocalizesDecimalSeparator(t *testing.T) {
+	german, err := ReadPTVFromFile(strings.NewReader(germanTestNetwork))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var english bytes.Buffer
+	if err := WritePTVToFileWithOptions(&english, german, WriteOptions{Language: LanguageEnglish}); err != nil {
+		t.Fatal(err)
+	}
+	for _, line := range []string{
+		"1;Nord 1,5;12.5;-0.25\r\n2;Süd;14;3.75\r\n",
+		"7;1;2;10;0.5km;50.5km/h\r\n",
+	} {
+		if !strings.Contains(english.String(), line) {
+			t.Errorf("English output lacks %q:\n%s", line, english.String())
+		}
+	}
+
+	// The English file is written back in German, a changed value is formatted with a comma as well
+	data, err := ReadPTVFromFile(&english)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data.Node.Nodes[1].XCoord = 14.125
+	var output bytes.Buffer
+	if err := WritePTVToFileWithOptions(&output, data, WriteOptions{Language: LanguageGerman}); err != nil {
+		t.Fatal(err)
+	}
+	for _, line := range []string{
+		"1;Nord 1,5;12,5;-0,25\r\n2;Süd;14,125;3,75\r\n",
+		"7;1;2;10;0,5km;50,5km/h\r\n",
+	} {
+		if !strings.Contains(output.String(), line) {
+			t.Errorf("German output lacks %q:\n%s", line, output.String())
+		}
+	}
+}